@@ -0,0 +1,22 @@
+// Package openapi menyimpan dokumen OpenAPI HTTP API. Dokumen ini ditulis
+// manual dan menjadi kontrak: middleware memvalidasi request terhadapnya dan
+// test kontrak memvalidasi response serta memastikan setiap route terdaftar.
+package openapi
+
+import (
+	_ "embed"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.json
+var Spec []byte
+
+// Load mem-parse Spec dan me-resolve seluruh $ref. doc.Validate sengaja tidak
+// dipanggil karena kin-openapi hanya memvalidasi dokumen 3.0 dan menolak tipe
+// "null" milik 3.1, padahal validasi request/response-nya mendukung tipe itu.
+// Untuk alasan yang sama exclusiveMinimum ditulis dalam bentuk boolean 3.0
+// (bersama minimum), satu-satunya bentuk yang dibaca kin-openapi.
+func Load() (*openapi3.T, error) {
+	return openapi3.NewLoader().LoadFromData(Spec)
+}
@@ -0,0 +1,9 @@
+package openapi
+
+import "testing"
+
+func TestLoad(t *testing.T) {
+	if _, err := Load(); err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+}
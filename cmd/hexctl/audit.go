@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func auditList(a *app, args []string) error {
+	fs := a.flagSet("audit list")
+	actorID := uuidFlag(fs, "actor", "filter by actor user ID")
+	targetID := uuidFlag(fs, "target", "filter by target user ID")
+	action := fs.String("action", "", "filter by action, e.g. LOGIN or TRANSFER")
+	limit := fs.Int("limit", 50, "maximum number of entries")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return a.usageError(fs, "-limit must be positive")
+	}
+
+	filter := domain.AuditFilter{Action: *action}
+	if *actorID != uuid.Nil {
+		filter.ActorID = actorID
+	}
+	if *targetID != uuid.Nil {
+		filter.TargetID = targetID
+	}
+
+	return a.withCore(func(c *core) error {
+		entries, _, err := c.audit.List(a.ctx, filter, 0, *limit)
+		if err != nil {
+			return err
+		}
+		return a.print(auditTable(entries))
+	})
+}
+
+func auditVerify(a *app, args []string) error {
+	fs := a.flagSet("audit verify")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	return a.withCore(func(c *core) error {
+		result, err := c.audit.Verify(a.ctx)
+		if err != nil {
+			return err
+		}
+		if err := a.print(verificationTable{result}); err != nil {
+			return err
+		}
+		if !result.Valid {
+			return fmt.Errorf("%w at sequence %d: %s", errAuditBroken, *result.BrokenAt, result.Reason)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/adapters/watchlist"
+	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/core/ports"
+	"hexagonal-go/internal/core/services"
+)
+
+// core menyatukan service dan repository yang dipakai hexctl. Perubahan akun
+// dan saldo tetap menulis domain event ke outbox dan entri audit log seperti
+// pada server.
+type core struct {
+	users          *services.UserService
+	transactions   *services.TransactionService
+	reconciliation *services.ReconciliationService
+	audit          *services.AuditService
+	reconRepo      ports.ReconciliationRepository
+}
+
+// newCore membangun core service. Jika SCREENING_CONFIG_FILE diset, user yang
+// dibuat atau diubah lewat hexctl disaring seperti pada server.
+func newCore(ctx context.Context, db *gorm.DB, cfg *config.Config) (*core, error) {
+	userRepo := repository.NewUserRepositoryImpl(db)
+	transactionRepo := repository.NewTransactionRepositoryImpl(db)
+	reconRepo := repository.NewReconciliationRepositoryImpl(db)
+	outboxRepo := repository.NewOutboxRepositoryImpl(db)
+	audit := services.NewAuditService(repository.NewAuditRepositoryImpl(db), db)
+	screening, err := newScreening(ctx, db, cfg.Policies, audit)
+	if err != nil {
+		return nil, err
+	}
+	return &core{
+		users: services.NewUserService(userRepo, services.WithUserOutbox(db, outboxRepo),
+			services.WithUserAudit(db, audit), services.WithUserScreening(db, screening),
+			services.WithBcryptCost(cfg.Auth.BcryptCost)),
+		transactions: services.NewTransactionService(transactionRepo, db,
+			services.WithTransactionOutbox(outboxRepo), services.WithTransactionAudit(audit),
+			services.WithTransactionScreening(screening)),
+		reconciliation: services.NewReconciliationService(reconRepo, userRepo, services.WithReconciliationAudit(db, audit)),
+		audit:          audit,
+		reconRepo:      reconRepo,
+	}, nil
+}
+
+// newScreening memuat daftar pantauan dari konfigurasi screening. Tanpa
+// konfigurasi atau daftar, screening tidak aktif dan nil dikembalikan.
+func newScreening(ctx context.Context, db *gorm.DB, policies config.PolicyConfig, audit *services.AuditService) (*services.ScreeningService, error) {
+	screeningConfig, err := config.LoadScreeningConfig(policies)
+	if err != nil || screeningConfig == nil || len(screeningConfig.Lists) == 0 {
+		return nil, err
+	}
+	screening := services.NewScreeningService(repository.NewScreeningRepositoryImpl(db), watchlist.NewFileSource(screeningConfig.Lists), db, *screeningConfig,
+		services.WithScreeningAudit(audit))
+	if err := screening.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return screening, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// exportFlags mendaftarkan flag -format dan -file untuk perintah export.
+func exportFlags(fs *flag.FlagSet) (format, file *string) {
+	format = fs.String("format", "csv", "export format: csv or json")
+	file = fs.String("file", "", "write to this file instead of stdout")
+	return format, file
+}
+
+// writeExport menulis data ke -file atau stdout dalam format yang diminta.
+func (a *app) writeExport(format, file string, data table) error {
+	out := a.stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return encodeExport(out, format, data)
+}
+
+func encodeExport(w io.Writer, format string, data table) error {
+	if format == "json" {
+		return writeJSON(w, data)
+	}
+	return writeCSV(w, data)
+}
+
+func checkExportFormat(a *app, fs *flag.FlagSet, format string) error {
+	if format != "csv" && format != "json" {
+		return a.usageError(fs, "invalid -format %q", format)
+	}
+	return nil
+}
+
+func exportUsers(a *app, args []string) error {
+	fs := a.flagSet("export users")
+	format, file := exportFlags(fs)
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkExportFormat(a, fs, *format); err != nil {
+		return err
+	}
+
+	return a.withCore(func(c *core) error {
+		users, err := c.reconRepo.FindUsers(a.ctx)
+		if err != nil {
+			return err
+		}
+		return a.writeExport(*format, *file, usersOf(users...))
+	})
+}
+
+func exportTransactions(a *app, args []string) error {
+	fs := a.flagSet("export transactions")
+	userID := uuidFlag(fs, "user", "export only this user's transactions")
+	format, file := exportFlags(fs)
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if err := checkExportFormat(a, fs, *format); err != nil {
+		return err
+	}
+
+	return a.withCore(func(c *core) error {
+		userIDs := []uuid.UUID{*userID}
+		if *userID == uuid.Nil {
+			users, err := c.reconRepo.FindUsers(a.ctx)
+			if err != nil {
+				return err
+			}
+			userIDs = userIDs[:0]
+			for _, u := range users {
+				userIDs = append(userIDs, u.UserID)
+			}
+		}
+
+		var txs []domain.Transaction
+		for _, id := range userIDs {
+			userTxs, err := c.reconRepo.FindUserTransactions(a.ctx, id)
+			if err != nil {
+				return err
+			}
+			txs = append(txs, userTxs...)
+		}
+		return a.writeExport(*format, *file, transactionsOf(txs...))
+	})
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"os/user"
+	"strings"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// hexctl adalah CLI admin untuk petugas operasional. Setiap perintah memanggil
+// core service langsung ke database tanpa melalui HTTP.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx = services.WithAuditActor(ctx, domain.AuditActor{Type: domain.ActorAdmin, Name: operator(), UserAgent: "hexctl"})
+	a := &app{ctx: ctx, stdout: os.Stdout, stderr: os.Stderr, connect: connectDB}
+	code := a.run(os.Args[1:])
+	stop()
+	os.Exit(code)
+}
+
+// connectDB memakai konfigurasi yang sama dengan server (file CONFIG_FILE dan
+// environment).
+func connectDB() (*config.Config, *gorm.DB, error) {
+	cfg, err := config.Load(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Database.Validate(); err != nil {
+		return nil, nil, err
+	}
+	db, err := config.ConnectDB(cfg.Database)
+	return cfg, db, err
+}
+
+// operator adalah nama petugas yang dicatat di audit log: HEXCTL_OPERATOR
+// jika diisi, selain itu user sistem operasi yang menjalankan hexctl.
+func operator() string {
+	if name := os.Getenv("HEXCTL_OPERATOR"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(a *app, args []string) error
+}
+
+var commands = []command{
+	{"user create", "-phone P -pin N [-first-name F] [-last-name L] [-address A]", "create a user", userCreate},
+	{"user find", "-id ID | -phone P", "show a user", userFind},
+	{"user deactivate", "-id ID", "deactivate a user", userSetActive(false)},
+	{"user activate", "-id ID", "activate a user", userSetActive(true)},
+	{"user unfreeze", "-id ID", "lift a freeze set by reconciliation", userUnfreeze},
+	{"user reset-pin", "-id ID [-pin N]", "set a new PIN, generated when -pin is empty", userResetPin},
+	{"adjust", "-user ID -amount A -reason R", "post a manual balance adjustment (negative amount debits)", adjust},
+	{"transactions list", "-user ID [-type T] [-category C] [-from DATE] [-to DATE] [-limit N]", "list transactions, newest first", transactionsList},
+	{"reconcile", "[-freeze]", "check ledger consistency; exit code 2 when issues are found", reconcile},
+	{"export users", "[-format csv|json] [-file PATH]", "export all users", exportUsers},
+	{"export transactions", "[-user ID] [-format csv|json] [-file PATH]", "export transactions of one or all users", exportTransactions},
+	{"audit list", "[-actor ID] [-target ID] [-action A] [-limit N]", "list audit log entries, newest first", auditList},
+	{"audit verify", "", "verify the audit log hash chain; exit code 2 when it is broken", auditVerify},
+}
+
+// errUsage menandai kesalahan pemakaian; pesan bantuan sudah ditulis flag.
+var errUsage = errors.New("usage error")
+
+// errIssuesFound dikembalikan reconcile ketika ada temuan (exit code 2).
+var errIssuesFound = errors.New("reconciliation found issues")
+
+// errAuditBroken dikembalikan audit verify ketika rantai hash rusak (exit code 2).
+var errAuditBroken = errors.New("audit log hash chain is broken")
+
+type app struct {
+	// ctx dibatalkan saat operator menekan Ctrl-C.
+	ctx     context.Context
+	stdout  io.Writer
+	stderr  io.Writer
+	connect func() (*config.Config, *gorm.DB, error)
+
+	// cmd adalah perintah yang sedang dijalankan, dipakai untuk pesan bantuan.
+	cmd *command
+
+	// Flag bersama yang tersedia di setiap perintah.
+	format string
+	dryRun bool
+}
+
+func (a *app) run(args []string) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		a.usage()
+		return 2
+	}
+	a.cmd = cmd
+	err := cmd.run(a, rest)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp), errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, errIssuesFound), errors.Is(err, errAuditBroken):
+		fmt.Fprintln(a.stderr, err)
+		return 2
+	}
+	fmt.Fprintln(a.stderr, "error:", err)
+	return 1
+}
+
+// findCommand mencocokkan nama perintah terpanjang (misalnya "user create")
+// dengan awal args.
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		words := strings.Fields(commands[i].name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == commands[i].name {
+			return &commands[i], args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "usage: hexctl <command> [flags]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-20s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(a.stderr, "\nevery command accepts -output table|json and -dry-run")
+}
+
+// flagSet membuat FlagSet untuk satu perintah beserta flag bersama.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("hexctl "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.format, "output", "table", "output format: table or json")
+	fs.BoolVar(&a.dryRun, "dry-run", false, "run inside a database transaction that is rolled back")
+	fs.Usage = func() {
+		if a.cmd != nil {
+			fmt.Fprintf(a.stderr, "usage: %s %s\n", fs.Name(), a.cmd.usage)
+		}
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse mem-parse flag dan memeriksa format output.
+func (a *app) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return a.usageError(fs, "unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if a.format != "table" && a.format != "json" {
+		return a.usageError(fs, "invalid -output %q", a.format)
+	}
+	return nil
+}
+
+func (a *app) usageError(fs *flag.FlagSet, format string, args ...interface{}) error {
+	fmt.Fprintf(a.stderr, format+"\n", args...)
+	fs.Usage()
+	return errUsage
+}
+
+// withCore membuka database dan menjalankan fn dengan core service. Pada mode
+// dry-run seluruh perubahan dijalankan di dalam transaksi yang di-rollback,
+// sehingga output menunjukkan hasil yang akan terjadi tanpa menyimpannya.
+func (a *app) withCore(fn func(c *core) error) error {
+	cfg, db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	if !a.dryRun {
+		c, err := newCore(a.ctx, db, cfg)
+		if err != nil {
+			return err
+		}
+		return fn(c)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+	c, err := newCore(a.ctx, tx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := fn(c); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stderr, "dry run: no changes were saved")
+	return nil
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// table adalah data yang dapat ditulis sebagai tabel (output table) maupun
+// CSV (export). Nilai JSON-nya tetap memakai struct record masing-masing.
+type table interface {
+	header() []string
+	rows() [][]string
+}
+
+// print menulis v sesuai flag -output.
+func (a *app) print(v table) error {
+	if a.format == "json" {
+		return writeJSON(a.stdout, v)
+	}
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(v.header(), "\t"))
+	for _, row := range v.rows() {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeCSV(w io.Writer, v table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(v.header()); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(v.rows()); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// userRecord adalah tampilan user tanpa hash PIN.
+type userRecord struct {
+	UserID      uuid.UUID `json:"user_id"`
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	PhoneNumber string    `json:"phone_number"`
+	Address     string    `json:"address"`
+	Balance     float64   `json:"balance"`
+	AccountTier string    `json:"account_tier"`
+	IsActive    bool      `json:"is_active"`
+	IsFrozen    bool      `json:"is_frozen"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type userTable []userRecord
+
+func usersOf(users ...domain.User) userTable {
+	records := make(userTable, len(users))
+	for i, u := range users {
+		records[i] = userRecord{u.UserID, u.FirstName, u.LastName, u.PhoneNumber, u.Address, u.Balance, u.AccountTier, u.IsActive, u.IsFrozen, u.CreatedAt}
+	}
+	return records
+}
+
+func (t userTable) header() []string {
+	return []string{"USER_ID", "FIRST_NAME", "LAST_NAME", "PHONE_NUMBER", "ADDRESS", "BALANCE", "TIER", "ACTIVE", "FROZEN", "CREATED_AT"}
+}
+
+func (t userTable) rows() [][]string {
+	rows := make([][]string, len(t))
+	for i, u := range t {
+		rows[i] = []string{u.UserID.String(), u.FirstName, u.LastName, u.PhoneNumber, u.Address,
+			formatAmount(u.Balance), u.AccountTier, strconv.FormatBool(u.IsActive), strconv.FormatBool(u.IsFrozen), formatTime(u.CreatedAt)}
+	}
+	return rows
+}
+
+type transactionRecord struct {
+	TransactionID   uuid.UUID  `json:"transaction_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	TransactionType string     `json:"transaction_type"`
+	Category        string     `json:"category"`
+	Amount          float64    `json:"amount"`
+	BalanceBefore   float64    `json:"balance_before"`
+	BalanceAfter    float64    `json:"balance_after"`
+	CounterpartyID  *uuid.UUID `json:"counterparty_id"`
+	Remarks         string     `json:"remarks"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type transactionTable []transactionRecord
+
+func transactionsOf(txs ...domain.Transaction) transactionTable {
+	records := make(transactionTable, len(txs))
+	for i, tx := range txs {
+		records[i] = transactionRecord{tx.TransactionID, tx.UserID, tx.TransactionType, tx.Category, tx.Amount,
+			tx.BalanceBefore, tx.BalanceAfter, tx.CounterpartyID, tx.Remarks, tx.CreatedAt}
+	}
+	return records
+}
+
+func (t transactionTable) header() []string {
+	return []string{"TRANSACTION_ID", "USER_ID", "TYPE", "CATEGORY", "AMOUNT", "BALANCE_BEFORE", "BALANCE_AFTER", "COUNTERPARTY_ID", "REMARKS", "CREATED_AT"}
+}
+
+func (t transactionTable) rows() [][]string {
+	rows := make([][]string, len(t))
+	for i, tx := range t {
+		counterparty := ""
+		if tx.CounterpartyID != nil {
+			counterparty = tx.CounterpartyID.String()
+		}
+		rows[i] = []string{tx.TransactionID.String(), tx.UserID.String(), tx.TransactionType, tx.Category,
+			formatAmount(tx.Amount), formatAmount(tx.BalanceBefore), formatAmount(tx.BalanceAfter),
+			counterparty, tx.Remarks, formatTime(tx.CreatedAt)}
+	}
+	return rows
+}
+
+// reportTable menampilkan temuan rekonsiliasi; JSON-nya adalah laporan lengkap.
+type reportTable struct {
+	*domain.ReconciliationReport
+}
+
+func (t reportTable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.ReconciliationReport)
+}
+
+func (t reportTable) header() []string {
+	return []string{"TYPE", "USER_ID", "TRANSACTION_ID", "EXPECTED", "ACTUAL", "DETAIL"}
+}
+
+func (t reportTable) rows() [][]string {
+	rows := make([][]string, len(t.Issues))
+	for i, issue := range t.Issues {
+		transactionID := ""
+		if issue.TransactionID != nil {
+			transactionID = issue.TransactionID.String()
+		}
+		rows[i] = []string{issue.Type, issue.UserID.String(), transactionID,
+			formatAmount(issue.Expected), formatAmount(issue.Actual), issue.Detail}
+	}
+	return rows
+}
+
+// pinTable menampilkan PIN baru hasil reset.
+type pinTable struct {
+	UserID uuid.UUID `json:"user_id"`
+	Pin    string    `json:"pin"`
+}
+
+func (t pinTable) header() []string { return []string{"USER_ID", "PIN"} }
+
+func (t pinTable) rows() [][]string { return [][]string{{t.UserID.String(), t.Pin}} }
+
+// auditTable menampilkan entri audit log; JSON-nya adalah entri lengkap.
+type auditTable []domain.AuditEntry
+
+func (t auditTable) header() []string {
+	return []string{"SEQUENCE", "OCCURRED_AT", "ACTOR_TYPE", "ACTOR", "ACTION", "TARGET_ID", "IP_ADDRESS", "CHANGES"}
+}
+
+func (t auditTable) rows() [][]string {
+	rows := make([][]string, len(t))
+	for i, e := range t {
+		actor, target := e.ActorName, ""
+		if e.ActorID != nil {
+			actor = e.ActorID.String()
+		}
+		if e.TargetID != nil {
+			target = e.TargetID.String()
+		}
+		rows[i] = []string{strconv.FormatInt(e.Sequence, 10), formatTime(e.OccurredAt), e.ActorType, actor,
+			e.Action, target, e.IPAddress, e.Changes}
+	}
+	return rows
+}
+
+// verificationTable menampilkan hasil verifikasi rantai hash audit log.
+type verificationTable struct {
+	*domain.AuditVerification
+}
+
+func (t verificationTable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.AuditVerification)
+}
+
+func (t verificationTable) header() []string {
+	return []string{"VALID", "ENTRIES_CHECKED", "LAST_SEQUENCE", "LAST_HASH", "BROKEN_AT", "REASON"}
+}
+
+func (t verificationTable) rows() [][]string {
+	brokenAt := ""
+	if t.BrokenAt != nil {
+		brokenAt = strconv.FormatInt(*t.BrokenAt, 10)
+	}
+	return [][]string{{strconv.FormatBool(t.Valid), strconv.FormatInt(t.EntriesChecked, 10),
+		strconv.FormatInt(t.LastSequence, 10), t.LastHash, brokenAt, t.Reason}}
+}
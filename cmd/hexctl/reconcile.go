@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"hexagonal-go/internal/core/services"
+)
+
+func reconcile(a *app, args []string) error {
+	fs := a.flagSet("reconcile")
+	freeze := fs.Bool("freeze", false, "freeze accounts with ledger inconsistencies")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	return a.withCore(func(c *core) error {
+		report, err := c.reconciliation.Run(a.ctx, *freeze)
+		if err != nil {
+			return err
+		}
+		if err := a.print(reportTable{report}); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stderr, services.SummarizeReconciliation(report))
+		if len(report.Issues) > 0 {
+			return errIssuesFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+// uuidFlag mendaftarkan flag UUID; nilai kosong menghasilkan uuid.Nil.
+func uuidFlag(fs *flag.FlagSet, name, usage string) *uuid.UUID {
+	id := new(uuid.UUID)
+	fs.Func(name, usage, func(s string) error {
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			return err
+		}
+		*id = parsed
+		return nil
+	})
+	return id
+}
+
+// dateFlag mendaftarkan flag tanggal YYYY-MM-DD (UTC); nilai kosong menghasilkan nil.
+func dateFlag(fs *flag.FlagSet, name, usage string) **time.Time {
+	date := new(*time.Time)
+	fs.Func(name, usage, func(s string) error {
+		parsed, err := time.Parse(dateLayout, s)
+		if err != nil {
+			return err
+		}
+		*date = &parsed
+		return nil
+	})
+	return date
+}
+
+func adjust(a *app, args []string) error {
+	fs := a.flagSet("adjust")
+	userID := uuidFlag(fs, "user", "user ID (required)")
+	amount := fs.Float64("amount", 0, "amount to credit; negative values debit (required)")
+	reason := fs.String("reason", "", "reason recorded in the transaction remarks (required)")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *userID == uuid.Nil || *amount == 0 || *reason == "" {
+		return a.usageError(fs, "-user, -amount and -reason are required")
+	}
+
+	return a.withCore(func(c *core) error {
+		tx, err := c.transactions.Adjust(a.ctx, *userID, *amount, *reason)
+		if err != nil {
+			return err
+		}
+		return a.print(transactionsOf(*tx))
+	})
+}
+
+func transactionsList(a *app, args []string) error {
+	fs := a.flagSet("transactions list")
+	userID := uuidFlag(fs, "user", "user ID (required)")
+	txType := fs.String("type", "", "filter by type: CREDIT or DEBIT")
+	category := fs.String("category", "", "filter by category, e.g. TRANSFER or ADJUSTMENT")
+	from := dateFlag(fs, "from", "first day to include (YYYY-MM-DD)")
+	to := dateFlag(fs, "to", "last day to include (YYYY-MM-DD)")
+	limit := fs.Int("limit", 50, "maximum number of transactions")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *userID == uuid.Nil {
+		return a.usageError(fs, "-user is required")
+	}
+	if *limit <= 0 {
+		return a.usageError(fs, "-limit must be positive")
+	}
+
+	filter := domain.TransactionFilter{TransactionType: *txType, Category: *category, From: *from}
+	if *to != nil {
+		// -to inklusif, sedangkan filter.To eksklusif.
+		end := (*to).AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	return a.withCore(func(c *core) error {
+		txs, _, err := c.transactions.ListTransactions(a.ctx, *userID, filter, nil, *limit)
+		if err != nil {
+			return err
+		}
+		return a.print(transactionsOf(txs...))
+	})
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func userCreate(a *app, args []string) error {
+	fs := a.flagSet("user create")
+	firstName := fs.String("first-name", "", "first name")
+	lastName := fs.String("last-name", "", "last name")
+	phone := fs.String("phone", "", "phone number (required)")
+	address := fs.String("address", "", "address")
+	pin := fs.String("pin", "", "initial PIN (required)")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *phone == "" || *pin == "" {
+		return a.usageError(fs, "-phone and -pin are required")
+	}
+
+	return a.withCore(func(c *core) error {
+		user := &domain.User{
+			FirstName:   *firstName,
+			LastName:    *lastName,
+			PhoneNumber: *phone,
+			Address:     *address,
+			Pin:         *pin,
+			IsActive:    true,
+		}
+		if err := c.users.Register(a.ctx, user); err != nil {
+			return err
+		}
+		return a.print(usersOf(*user))
+	})
+}
+
+func userFind(a *app, args []string) error {
+	fs := a.flagSet("user find")
+	id := fs.String("id", "", "user ID")
+	phone := fs.String("phone", "", "phone number")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if (*id == "") == (*phone == "") {
+		return a.usageError(fs, "exactly one of -id or -phone is required")
+	}
+	var userID uuid.UUID
+	if *id != "" {
+		var err error
+		if userID, err = uuid.Parse(*id); err != nil {
+			return a.usageError(fs, "invalid -id: %v", err)
+		}
+	}
+
+	return a.withCore(func(c *core) error {
+		var user *domain.User
+		var err error
+		if *phone != "" {
+			user, err = c.users.GetByPhoneNumber(a.ctx, *phone)
+		} else {
+			user, err = c.users.GetByID(a.ctx, userID)
+		}
+		if err != nil {
+			return err
+		}
+		return a.print(usersOf(*user))
+	})
+}
+
+func userSetActive(active bool) func(a *app, args []string) error {
+	name := "user deactivate"
+	if active {
+		name = "user activate"
+	}
+	return func(a *app, args []string) error {
+		fs := a.flagSet(name)
+		userID := uuidFlag(fs, "id", "user ID (required)")
+		if err := a.parse(fs, args); err != nil {
+			return err
+		}
+		if *userID == uuid.Nil {
+			return a.usageError(fs, "-id is required")
+		}
+
+		return a.withCore(func(c *core) error {
+			if _, err := c.users.GetByID(a.ctx, *userID); err != nil {
+				return err
+			}
+			if err := c.users.SetActive(a.ctx, *userID, active); err != nil {
+				return err
+			}
+			user, err := c.users.GetByID(a.ctx, *userID)
+			if err != nil {
+				return err
+			}
+			return a.print(usersOf(*user))
+		})
+	}
+}
+
+func userUnfreeze(a *app, args []string) error {
+	fs := a.flagSet("user unfreeze")
+	userID := uuidFlag(fs, "id", "user ID (required)")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *userID == uuid.Nil {
+		return a.usageError(fs, "-id is required")
+	}
+
+	return a.withCore(func(c *core) error {
+		if err := c.users.Unfreeze(a.ctx, *userID); err != nil {
+			return err
+		}
+		user, err := c.users.GetByID(a.ctx, *userID)
+		if err != nil {
+			return err
+		}
+		return a.print(usersOf(*user))
+	})
+}
+
+func userResetPin(a *app, args []string) error {
+	fs := a.flagSet("user reset-pin")
+	userID := uuidFlag(fs, "id", "user ID (required)")
+	pin := fs.String("pin", "", "new PIN; a random 6-digit PIN is generated when empty")
+	if err := a.parse(fs, args); err != nil {
+		return err
+	}
+	if *userID == uuid.Nil {
+		return a.usageError(fs, "-id is required")
+	}
+	newPin := *pin
+	if newPin == "" {
+		var err error
+		if newPin, err = randomPin(); err != nil {
+			return err
+		}
+	}
+
+	return a.withCore(func(c *core) error {
+		if err := c.users.ResetPin(a.ctx, *userID, newPin); err != nil {
+			return err
+		}
+		return a.print(pinTable{UserID: *userID, Pin: newPin})
+	})
+}
+
+func randomPin() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
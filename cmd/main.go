@@ -1,56 +1,486 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"hexagonal-go/api/openapi"
+	"hexagonal-go/internal/adapters/blobstore"
+	"hexagonal-go/internal/adapters/broadcast"
+	"hexagonal-go/internal/adapters/events"
+	"hexagonal-go/internal/adapters/graphql"
+	grpcadapter "hexagonal-go/internal/adapters/grpc"
 	"hexagonal-go/internal/adapters/http"
 	"hexagonal-go/internal/adapters/http/middleware"
+	"hexagonal-go/internal/adapters/metrics"
+	"hexagonal-go/internal/adapters/notifier"
+	"hexagonal-go/internal/adapters/ratelimit"
 	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/adapters/tracing"
+	"hexagonal-go/internal/adapters/watchlist"
+	"hexagonal-go/internal/adapters/webhook"
 	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
 	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/lifecycle"
+	"hexagonal-go/internal/utils"
 )
 
 func main() {
-	// Koneksi ke database
-	db, err := config.ConnectDB()
+	// Konfigurasi dari file, environment dan flag; secret tidak ikut tercetak
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		panic("failed to connect database")
+		panic(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+
+	// Log JSON terstruktur; paket log standar ikut diarahkan ke logger ini
+	logger := config.NewLogger(os.Stdout, cfg.Log)
+	slog.SetDefault(logger)
+	logger.Info("configuration loaded", "config", fmt.Sprintf("%+v", *cfg))
+
+	// Tracing OpenTelemetry; trace context W3C selalu dipropagasikan walaupun
+	// exporter tidak aktif agar trace dari hulu tetap tersambung
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	var tracerProvider *sdktrace.TracerProvider
+	if cfg.Tracing.Enabled() {
+		tracerProvider, err = config.NewTracerProvider(context.Background(), cfg.Tracing)
+		if err != nil {
+			panic(err)
+		}
+		otel.SetTracerProvider(tracerProvider)
+	}
+
+	// Koneksi ke database, dicoba ulang dengan backoff sampai database siap
+	db, err := config.ConnectDB(cfg.Database)
+	if err != nil {
+		panic(err)
+	}
+
+	// Registry Prometheus untuk metrik HTTP, database dan bisnis di /metrics
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	if err := metrics.InstrumentGORM(db, registry); err != nil {
+		panic(err)
 	}
+	businessMetrics := metrics.NewPrometheusMetrics(registry)
+	if err := tracing.InstrumentGORM(db); err != nil {
+		panic(err)
+	}
+
+	// Komponen dijalankan berurutan dan dihentikan dalam urutan terbalik:
+	// server berhenti dan menguras request lebih dulu, lalu job latar
+	// belakang, pool database ditutup, dan span tersisa dikirim paling akhir.
+	app := lifecycle.New()
+	if tracerProvider != nil {
+		app.Append(lifecycle.Hook{Name: "tracer provider", Stop: tracerProvider.Shutdown})
+	}
+	app.Append(lifecycle.Hook{
+		Name: "database",
+		Stop: func(context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		},
+	})
 
 	// Inisialisasi repository
 	userRepo := repository.NewUserRepositoryImpl(db)
 	transactionRepo := repository.NewTransactionRepositoryImpl(db)
+	interestRepo := repository.NewInterestRepositoryImpl(db)
+	reconciliationRepo := repository.NewReconciliationRepositoryImpl(db)
+	outboxRepo := repository.NewOutboxRepositoryImpl(db)
+	webhookRepo := repository.NewWebhookRepositoryImpl(db)
+	deviceRepo := repository.NewDeviceRepositoryImpl(db)
+	auditRepo := repository.NewAuditRepositoryImpl(db)
+	fraudRepo := repository.NewFraudRepositoryImpl(db)
+	screeningRepo := repository.NewScreeningRepositoryImpl(db)
+	kycRepo := repository.NewKYCRepositoryImpl(db)
+	notificationRepo := repository.NewNotificationRepositoryImpl(db)
+
+	// Konfigurasi biaya transaksi
+	feeRules, feeRevenueAccountID, err := config.LoadFeeRules(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+	// Biaya yang terkumpul harus bisa dibukukan ke akun pendapatan
+	if len(feeRules) > 0 {
+		if _, err := userRepo.FindByID(context.Background(), feeRevenueAccountID); err != nil {
+			panic(fmt.Errorf("fee revenue account %s (FEE_REVENUE_ACCOUNT_ID): %w", feeRevenueAccountID, err))
+		}
+	}
+
+	// Konfigurasi limit transaksi
+	limitPolicies, err := config.LoadLimitPolicies(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+
+	// Konfigurasi bunga tabungan
+	interestConfig, err := config.LoadInterestConfig(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+
+	// Aturan fraud; tanpa file, semua transaksi diizinkan
+	fraudConfig, err := config.LoadFraudConfig(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+	if fraudConfig == nil {
+		fraudConfig = &domain.FraudConfig{}
+	}
+
+	// Screening daftar pantauan; tanpa file, registrasi dan transfer tidak disaring
+	screeningConfig, err := config.LoadScreeningConfig(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+	if screeningConfig == nil {
+		screeningConfig = &domain.ScreeningConfig{}
+	}
+
+	// Kemampuan akun per level KYC; tanpa file, semua level diperlakukan sama
+	kycPolicies, err := config.LoadKYCPolicies(cfg.Policies)
+	if err != nil {
+		panic(err)
+	}
+
+	// Penyimpanan dokumen KYC
+	documents, err := blobstore.NewLocalStore(cfg.Storage.DocumentsDir)
+	if err != nil {
+		panic(err)
+	}
+
+	// Adapter SMS dan email; kedua kanal memakai adapter pengembangan yang sama
+	notificationOptions := []services.NotificationServiceOption{
+		services.WithDefaultLanguage(cfg.Notifications.DefaultLanguage),
+		services.WithLargeWithdrawalThreshold(cfg.Notifications.LargeWithdrawal),
+	}
+	var messageNotifier ports.Notifier
+	switch cfg.Notifications.Notifier {
+	case config.NotifierConsole:
+		messageNotifier = notifier.NewConsoleNotifier()
+	case config.NotifierFile:
+		messageNotifier, err = notifier.NewFileNotifier(cfg.Notifications.File)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if messageNotifier != nil {
+		notificationOptions = append(notificationOptions,
+			services.WithNotifier(domain.ChannelSMS, messageNotifier),
+			services.WithNotifier(domain.ChannelEmail, messageNotifier))
+	}
+
+	// Kebijakan rate limit per route
+	rateLimitPolicies, err := config.LoadRateLimitPolicies(cfg.RateLimit)
+	if err != nil {
+		panic(err)
+	}
+
+	// Penerbit dan validator token JWT
+	tokens := utils.NewTokenManager(cfg.Auth.JWTSecret.Value(), cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+
+	// Inisialisasi publisher event
+	publisher := events.NewInProcessPublisher()
+	if cfg.Events.Stdout {
+		publisher.Subscribe(events.NewStdoutPublisher().Publish)
+	}
+
+	// Broadcaster menyebarkan event ke semua replika; setiap replika meneruskannya
+	// ke localEvents untuk koneksi real-time (SSE dan WebSocket) miliknya
+	var broadcaster ports.Broadcaster = broadcast.NewMemoryBroadcaster()
+	if cfg.Events.Broadcaster == "postgres" {
+		broadcaster = broadcast.NewPostgresBroadcaster(db)
+	}
+	publisher.Subscribe(broadcaster.Broadcast)
+	localEvents := events.NewInProcessPublisher()
+
+	// Inisialisasi service; perubahan akun dan saldo dicatat ke audit log
+	auditService := services.NewAuditService(auditRepo, db)
+	fraudService := services.NewFraudService(fraudRepo, *fraudConfig)
+	screeningService := services.NewScreeningService(screeningRepo, watchlist.NewFileSource(screeningConfig.Lists), db, *screeningConfig,
+		services.WithScreeningAudit(auditService))
+	kycService := services.NewKYCService(kycRepo, documents, db, kycPolicies,
+		services.WithKYCAudit(auditService))
+	var screening *services.ScreeningService
+	if len(screeningConfig.Lists) > 0 {
+		if err := screeningService.Reload(context.Background()); err != nil {
+			panic(err)
+		}
+		screening = screeningService
+	}
+	userService := services.NewUserService(userRepo,
+		services.WithUserOutbox(db, outboxRepo),
+		services.WithUserAudit(db, auditService),
+		services.WithDeviceRepository(deviceRepo),
+		services.WithUserScreening(db, screening),
+		services.WithBcryptCost(cfg.Auth.BcryptCost),
+		services.WithUserMetrics(businessMetrics))
+	transactionService := services.NewTransactionService(transactionRepo, db,
+		services.WithFeeService(services.NewFeeService(feeRules, feeRevenueAccountID)),
+		services.WithLimitService(services.NewLimitService(limitPolicies)),
+		services.WithTransactionOutbox(outboxRepo),
+		services.WithTransactionAudit(auditService),
+		services.WithFraudService(fraudService),
+		services.WithTransactionScreening(screening),
+		services.WithKYCService(kycService),
+		services.WithTransactionMetrics(businessMetrics))
+	interestService := services.NewInterestService(interestRepo, transactionRepo, db, interestConfig,
+		services.WithInterestAudit(auditService), services.WithInterestLock(repository.NewAdvisoryJobLock(db)))
+	statementService := services.NewStatementService(transactionRepo)
+	reconciliationService := services.NewReconciliationService(reconciliationRepo, userRepo,
+		services.WithReconciliationAudit(db, auditService), services.WithReconciliationLock(repository.NewAdvisoryJobLock(db)))
+	var senderOpts []webhook.HTTPSenderOption
+	if cfg.Events.WebhookPrivateTargets {
+		senderOpts = append(senderOpts, webhook.WithPrivateTargets())
+	}
+	webhookService := services.NewWebhookService(webhookRepo, webhook.NewHTTPSender(10*time.Second, senderOpts...))
+	publisher.Subscribe(webhookService.HandleEvent)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, notificationOptions...)
+	publisher.Subscribe(notificationService.HandleEvent)
+	transactionStream := services.NewTransactionStream(transactionRepo)
+	localEvents.Subscribe(transactionStream.HandleEvent)
+	notificationHub := services.NewNotificationHub()
+	localEvents.Subscribe(notificationHub.HandleEvent)
+
+	// Pengiriman event dari semua replika ke koneksi lokal
+	app.Append(lifecycle.Worker("broadcast listener", func(ctx context.Context) {
+		broadcaster.Listen(ctx, func(event domain.OutboxEvent) {
+			if err := localEvents.Publish(context.Background(), event); err != nil {
+				slog.ErrorContext(ctx, "local event dispatch failed", "error", err)
+			}
+		})
+	}))
 
-	// Inisialisasi service
-	userService := services.NewUserService(userRepo)
-	transactionService := services.NewTransactionService(transactionRepo, db)
+	// Relay outbox ke publisher event
+	outboxRelay := services.NewOutboxRelay(outboxRepo, publisher, 100, services.WithRelayLock(repository.NewAdvisoryJobLock(db)))
+	app.Append(lifecycle.Worker("outbox relay", func(ctx context.Context) {
+		outboxRelay.Run(ctx, cfg.Events.RelayInterval)
+	}))
+
+	// Pengiriman webhook ke partner
+	app.Append(lifecycle.Worker("webhook delivery", func(ctx context.Context) {
+		webhookService.Run(ctx, 5*time.Second)
+	}))
+
+	// Pengiriman SMS dan email ke user
+	app.Append(lifecycle.Worker("notification delivery", func(ctx context.Context) {
+		notificationService.Run(ctx, cfg.Notifications.DeliveryInterval)
+	}))
+
+	// Job harian accrual dan posting bunga
+	app.Append(lifecycle.Worker("interest scheduler", func(ctx context.Context) {
+		interestService.RunScheduler(ctx, time.Hour)
+	}))
+
+	// Job rekonsiliasi ledger
+	if cfg.Reconciliation.Interval > 0 {
+		app.Append(lifecycle.Worker("reconciliation scheduler", func(ctx context.Context) {
+			reconciliationService.RunScheduler(ctx, cfg.Reconciliation.Interval, cfg.Reconciliation.Freeze)
+		}))
+	}
+
+	// Muat ulang daftar pantauan agar ekspor baru terpakai tanpa restart
+	if screening != nil && screeningConfig.ReloadMinutes > 0 {
+		app.Append(lifecycle.Worker("watchlist reloader", func(ctx context.Context) {
+			screeningService.RunReloader(ctx, time.Duration(screeningConfig.ReloadMinutes)*time.Minute)
+		}))
+	}
+
+	// Rate limiter; store database berbagi bucket antarreplika
+	var rateLimitStore ports.RateLimitStore = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == config.RateLimitStoreDatabase {
+		rateLimitStore = ratelimit.NewDatabaseStore(db)
+	}
+	rateLimitService := services.NewRateLimitService(rateLimitPolicies, rateLimitStore)
+	app.Append(lifecycle.Worker("rate limit janitor", func(ctx context.Context) {
+		rateLimitService.RunJanitor(ctx, time.Minute)
+	}))
+	rateLimit := middleware.RateLimit(rateLimitService)
 
 	// Inisialisasi handler
-	userHandler := http.NewUserHandler(*userService)
+	userHandler := http.NewUserHandler(*userService, tokens)
 	transactionHandler := http.NewTransactionHandler(*transactionService)
+	interestHandler := http.NewInterestHandler(*interestService)
+	statementHandler := http.NewStatementHandler(*statementService)
+	webhookHandler := http.NewWebhookHandler(*webhookService)
+	auditHandler := http.NewAuditHandler(*auditService)
+	fraudHandler := http.NewFraudHandler(*fraudService, *transactionService)
+	screeningHandler := http.NewScreeningHandler(screeningService)
+	kycHandler := http.NewKYCHandler(*kycService)
+	notificationHandler := http.NewNotificationHandler(*notificationService)
+	streamHandler := http.NewStreamHandler(transactionStream)
+	wsHandler := http.NewWebSocketHandler(notificationHub)
+	graphqlExecutor, err := graphql.NewExecutor(*userService, *transactionService)
+	if err != nil {
+		panic(err)
+	}
+	graphqlHandler := http.NewGraphQLHandler(graphqlExecutor)
+	docsHandler := http.NewDocsHandler(openapi.Spec)
 
-	// Setup router menggunakan Gin
-	r := gin.Default()
+	// Pemeriksaan kesehatan untuk probe readiness dan /health
+	healthService := services.NewHealthService()
+	healthService.Register("database", repository.NewDatabaseHealthChecker(db))
+	healthService.Register("migrations", repository.NewMigrationHealthChecker(db, config.Models()...))
+	healthService.Register("workers", ports.HealthCheckFunc(app.Check))
+	healthHandler := http.NewHealthHandler(healthService)
 
-	// Endpoint user
-	r.POST("/register", userHandler.Register)
-	r.POST("/login", userHandler.Login)
-	r.POST("/refresh", userHandler.RefreshToken)
+	// Server gRPC berjalan berdampingan dengan HTTP memakai service yang sama
+	if cfg.GRPC.Addr != "" {
+		var grpcOptions []grpc.ServerOption
+		if cfg.Server.TLSEnabled() {
+			creds, err := credentials.NewServerTLSFromFile(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+			if err != nil {
+				panic(err)
+			}
+			grpcOptions = append(grpcOptions, grpc.Creds(creds))
+		}
+		grpcServer := grpcadapter.NewServer(grpcadapter.NewWalletServer(*userService, *transactionService, tokens), tokens, rateLimitService, grpcOptions...)
+		app.Append(grpcServerHook(app, grpcServer, cfg.GRPC.Addr))
+	}
 
-	// Endpoint transaction with authentication middleware
+	// Setup router menggunakan Gin; setiap request divalidasi terhadap spec OpenAPI
+	spec, err := openapi.Load()
+	if err != nil {
+		panic(err)
+	}
+	openAPIValidator, err := middleware.OpenAPIValidator(spec)
+	if err != nil {
+		panic(err)
+	}
+	r := gin.New()
+	// Tanpa TRUSTED_PROXIES, X-Forwarded-For diabaikan dan IP klien (untuk rate
+	// limit dan audit) diambil dari alamat koneksi
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxyList()); err != nil {
+		panic(err)
+	}
+	r.Use(middleware.RequestID(), middleware.AuditActor(), middleware.Logger(logger), middleware.Recovery(logger),
+		middleware.Tracing(), middleware.Metrics(registry), openAPIValidator)
+
+	// Dokumentasi API
+	r.GET("/openapi.json", docsHandler.OpenAPI)
+	r.GET("/docs", docsHandler.SwaggerUI)
+
+	// Probe liveness dan readiness Kubernetes
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+	r.GET("/health", healthHandler.Health)
+
+	// Metrik Prometheus
+	r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})))
+
+	// Endpoint user, dibatasi per IP
+	public := r.Group("/")
+	public.Use(rateLimit)
+	{
+		public.POST("/register", userHandler.Register)
+		public.POST("/login", userHandler.Login)
+		public.POST("/refresh", userHandler.RefreshToken)
+	}
+
+	// Endpoint WebSocket notifikasi, token juga dapat dikirim lewat query access_token
+	r.GET("/ws", middleware.WebSocketAuthMiddleware(tokens), rateLimit, wsHandler.Connect)
+
+	// Endpoint transaction with authentication middleware; rate limit dipasang
+	// setelah autentikasi agar kebijakan per user mengenali user-nya
 	auth := r.Group("/")
-	auth.Use(middleware.AuthMiddleware())
+	auth.Use(middleware.AuthMiddleware(tokens), rateLimit)
 	{
 		auth.POST("/deposit", transactionHandler.Deposit)
 		auth.POST("/withdraw", transactionHandler.Withdraw)
 		auth.POST("/transfer", transactionHandler.Transfer)
 		auth.GET("/transactions/:user_id", transactionHandler.GetTransactions)
+		auth.GET("/fees/preview", transactionHandler.PreviewFee)
+		auth.GET("/limits", transactionHandler.GetLimits)
+		auth.GET("/interest/accrued", interestHandler.Accrued)
+		auth.GET("/statements", statementHandler.GetStatement)
+		auth.POST("/webhooks", webhookHandler.CreateSubscription)
+		auth.GET("/webhooks", webhookHandler.ListSubscriptions)
+		auth.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+		auth.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+		auth.GET("/webhook-deliveries/:id", webhookHandler.GetDelivery)
+		auth.POST("/webhook-deliveries/:id/redeliver", webhookHandler.Redeliver)
+		auth.GET("/stream/transactions", streamHandler.Transactions)
+		auth.POST("/graphql", graphqlHandler.Query)
 		auth.GET("/profile", userHandler.Profile)
 		auth.PUT("/profile", userHandler.UpdateProfile)
 		auth.PUT("/pin", userHandler.ChangePin)
 		auth.PUT("/deactivate", userHandler.Deactivate)
 		auth.PUT("/activate", userHandler.Activate)
+		auth.GET("/kyc", kycHandler.Status)
+		auth.POST("/kyc/submissions", kycHandler.Submit)
+		auth.GET("/notifications", notificationHandler.List)
+		auth.GET("/notifications/preferences", notificationHandler.GetPreference)
+		auth.PUT("/notifications/preferences", notificationHandler.UpdatePreference)
+	}
+
+	// Endpoint admin dengan token per operator ADMIN_API_TOKENS, nonaktif jika kosong
+	adminOperators, err := cfg.Auth.AdminOperators()
+	if err != nil {
+		panic(err)
+	}
+	admin := r.Group("/")
+	admin.Use(middleware.AdminAuthMiddleware(adminOperators))
+	{
+		admin.GET("/admin/audit", auditHandler.List)
+		admin.GET("/admin/audit/verify", auditHandler.Verify)
+		admin.GET("/admin/fraud/reviews", fraudHandler.ListReviews)
+		admin.GET("/admin/fraud/reviews/:review_id", fraudHandler.GetReview)
+		admin.POST("/admin/fraud/reviews/:review_id/approve", fraudHandler.ApproveReview)
+		admin.POST("/admin/fraud/reviews/:review_id/reject", fraudHandler.RejectReview)
+		admin.GET("/admin/screening/hits", screeningHandler.ListHits)
+		admin.GET("/admin/screening/hits/:hit_id", screeningHandler.GetHit)
+		admin.POST("/admin/screening/hits/:hit_id/clear", screeningHandler.ClearHit)
+		admin.POST("/admin/screening/hits/:hit_id/confirm", screeningHandler.ConfirmHit)
+		admin.GET("/admin/kyc/submissions", kycHandler.ListSubmissions)
+		admin.GET("/admin/kyc/submissions/:submission_id", kycHandler.GetSubmission)
+		admin.GET("/admin/kyc/submissions/:submission_id/documents/:document_id", kycHandler.GetDocument)
+		admin.POST("/admin/kyc/submissions/:submission_id/approve", kycHandler.ApproveSubmission)
+		admin.POST("/admin/kyc/submissions/:submission_id/reject", kycHandler.RejectSubmission)
+		admin.POST("/admin/users/:user_id/unfreeze", userHandler.Unfreeze)
 	}
 
-	// Jalankan server pada port 8080
-	r.Run(":8080")
+	// Server HTTP pada server.addr (default :8080). Koneksi SSE dan WebSocket
+	// ditutup saat shutdown dimulai agar tidak menahan pengurasan request.
+	httpServer := newHTTPServer(r, cfg.Server)
+	httpServer.RegisterOnShutdown(func() {
+		transactionStream.Close()
+		notificationHub.Close()
+	})
+	app.Append(httpServerHook(app, httpServer, cfg.Server))
+
+	// Jalankan sampai SIGINT/SIGTERM, lalu hentikan semua komponen
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := app.Run(ctx, cfg.Server.ShutdownTimeout); err != nil {
+		logger.Error("server stopped with error", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("server stopped")
 }
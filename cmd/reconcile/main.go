@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/core/services"
+)
+
+// Menjalankan rekonsiliasi ledger satu kali dan menulis laporannya dalam JSON.
+// Exit code 2 menandakan ada temuan.
+func main() {
+	freeze := flag.Bool("freeze", false, "freeze accounts with ledger inconsistencies")
+	output := flag.String("output", "", "write the JSON report to this file instead of stdout")
+	flag.Parse()
+
+	cfg, err := config.Load(nil)
+	if err == nil {
+		err = cfg.Database.Validate()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	db, err := config.ConnectDB(cfg.Database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	auditService := services.NewAuditService(repository.NewAuditRepositoryImpl(db), db)
+	reconciliationService := services.NewReconciliationService(
+		repository.NewReconciliationRepositoryImpl(db),
+		repository.NewUserRepositoryImpl(db),
+		services.WithReconciliationAudit(db, auditService),
+	)
+	report, err := reconciliationService.Run(context.Background(), *freeze)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer out.Close()
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintln(os.Stderr, services.SummarizeReconciliation(report))
+	if len(report.Issues) > 0 {
+		out.Close()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/lifecycle"
+)
+
+// newHTTPServer membuat http.Server dengan timeout dari konfigurasi.
+func newHTTPServer(handler http.Handler, cfg config.ServerConfig) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// httpServerHook membuka listener saat start sehingga kegagalan bind langsung
+// menggagalkan startup. Stop memanggil Shutdown yang menunggu request yang
+// sedang berjalan, termasuk transfer, selesai.
+func httpServerHook(app *lifecycle.Lifecycle, srv *http.Server, cfg config.ServerConfig) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "http server",
+		Start: func(context.Context) error {
+			listener, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				var err error
+				if cfg.TLSEnabled() {
+					slog.Info("http server listening", "addr", listener.Addr().String(), "tls", true)
+					err = srv.ServeTLS(listener, cfg.TLSCertFile, cfg.TLSKeyFile)
+				} else {
+					slog.Info("http server listening", "addr", listener.Addr().String(), "tls", false)
+					err = srv.Serve(listener)
+				}
+				if !errors.Is(err, http.ErrServerClosed) {
+					app.Fail(err)
+				}
+			}()
+			return nil
+		},
+		Stop: srv.Shutdown,
+	}
+}
+
+// grpcServerHook menjalankan server gRPC. Stop menunggu RPC yang sedang
+// berjalan selesai dan memutus paksa jika batas waktu shutdown terlewati.
+func grpcServerHook(app *lifecycle.Lifecycle, server *grpc.Server, addr string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: "grpc server",
+		Start: func(context.Context) error {
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			slog.Info("grpc server listening", "addr", listener.Addr().String())
+			go func() {
+				if err := server.Serve(listener); err != nil {
+					app.Fail(err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
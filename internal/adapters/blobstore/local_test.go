@@ -0,0 +1,54 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore returned error: %v", err)
+	}
+	if n, err := store.Put(ctx, "kyc/user/doc", strings.NewReader("content")); err != nil || n != 7 {
+		t.Fatalf("Put returned %d, %v", n, err)
+	}
+	r, err := store.Open(ctx, "kyc/user/doc")
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "content" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	if err := store.Delete(ctx, "kyc/user/doc"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := store.Delete(ctx, "kyc/user/doc"); err != nil {
+		t.Fatalf("expected deleting a missing blob to succeed, got %v", err)
+	}
+	if _, err := store.Open(ctx, "kyc/user/doc"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected ErrNotExist, got %v", err)
+	}
+
+	for _, key := range []string{"", "../escape", "kyc/../../escape", "/absolute", "kyc\\doc"} {
+		if _, err := store.Put(ctx, key, strings.NewReader("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.Put(cancelled, "kyc/cancelled", strings.NewReader("x")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := store.Open(ctx, "kyc/cancelled"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected cancelled upload to leave no file, got %v", err)
+	}
+}
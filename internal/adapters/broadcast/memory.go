@@ -0,0 +1,44 @@
+package broadcast
+
+import (
+	"context"
+	"sync"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// MemoryBroadcaster menyebarkan event hanya di dalam proses ini. Cocok untuk
+// deployment satu replika dan pengembangan lokal.
+type MemoryBroadcaster struct {
+	mu       sync.RWMutex
+	handlers map[int]func(domain.OutboxEvent)
+	nextID   int
+}
+
+func NewMemoryBroadcaster() *MemoryBroadcaster {
+	return &MemoryBroadcaster{handlers: make(map[int]func(domain.OutboxEvent))}
+}
+
+func (b *MemoryBroadcaster) Broadcast(ctx context.Context, event domain.OutboxEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+	return nil
+}
+
+func (b *MemoryBroadcaster) Listen(ctx context.Context, handler func(domain.OutboxEvent)) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return nil
+}
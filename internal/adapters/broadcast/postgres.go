@@ -0,0 +1,86 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+const postgresChannel = "domain_events"
+
+// PostgresBroadcaster menyebarkan event ke semua replika menggunakan
+// LISTEN/NOTIFY PostgreSQL, memakai koneksi dari pool GORM.
+type PostgresBroadcaster struct {
+	db *gorm.DB
+}
+
+func NewPostgresBroadcaster(db *gorm.DB) *PostgresBroadcaster {
+	return &PostgresBroadcaster{db: db}
+}
+
+func (b *PostgresBroadcaster) Broadcast(ctx context.Context, event domain.OutboxEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", postgresChannel, string(payload)).Error
+}
+
+// Listen memegang satu koneksi khusus untuk LISTEN dan menyambung ulang jika
+// koneksi terputus.
+func (b *PostgresBroadcaster) Listen(ctx context.Context, handler func(domain.OutboxEvent)) error {
+	for {
+		err := b.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		slog.WarnContext(ctx, "event broadcast listener stopped", "error", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (b *PostgresBroadcaster) listen(ctx context.Context, handler func(domain.OutboxEvent)) error {
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{postgresChannel}.Sanitize()); err != nil {
+			return err
+		}
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			var event domain.OutboxEvent
+			if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+				slog.WarnContext(ctx, "invalid event broadcast payload", "error", err)
+				continue
+			}
+			handler(event)
+		}
+	})
+}
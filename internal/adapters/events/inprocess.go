@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// Handler memproses satu event yang dipublikasikan.
+type Handler func(ctx context.Context, event domain.OutboxEvent) error
+
+// InProcessPublisher meneruskan event ke handler yang berlangganan di proses
+// yang sama. Publish gagal jika salah satu handler gagal, sehingga relay akan
+// mengulang event tersebut.
+type InProcessPublisher struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{}
+}
+
+func (p *InProcessPublisher) Subscribe(handler Handler) {
+	p.mu.Lock()
+	p.handlers = append(p.handlers, handler)
+	p.mu.Unlock()
+}
+
+func (p *InProcessPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	p.mu.RLock()
+	handlers := append([]Handler(nil), p.handlers...)
+	p.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
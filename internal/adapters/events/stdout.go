@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// StdoutPublisher menulis setiap event sebagai satu baris JSON, berguna untuk
+// pengembangan lokal.
+type StdoutPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewStdoutPublisher() *StdoutPublisher {
+	return &StdoutPublisher{w: os.Stdout}
+}
+
+func (p *StdoutPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	line, err := json.Marshal(struct {
+		domain.OutboxEvent
+		Payload json.RawMessage `json:"payload"`
+	}{event, json.RawMessage(event.Payload)})
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
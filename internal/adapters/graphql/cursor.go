@@ -0,0 +1,41 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor membuat cursor opaque dari posisi transaksi. Isinya
+// "<created_at unix nano>:<transaction id>" dalam base64 URL-safe.
+func encodeCursor(tx domain.Transaction) string {
+	raw := strconv.FormatInt(tx.CreatedAt.UnixNano(), 10) + ":" + tx.TransactionID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (*domain.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	transactionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return &domain.TransactionCursor{CreatedAt: time.Unix(0, unixNano), TransactionID: transactionID}, nil
+}
@@ -0,0 +1,56 @@
+package graphql
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/services"
+)
+
+// codedError membawa kode mesin pada extensions.code di response GraphQL.
+type codedError struct {
+	message string
+	code    string
+}
+
+func (e *codedError) Error() string { return e.message }
+
+func (e *codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func newError(code, message string) error {
+	return &codedError{message: message, code: code}
+}
+
+// toGraphQLError memetakan error domain ke error GraphQL. Error yang tidak
+// dikenal dikembalikan tanpa detail agar pesan database tidak bocor ke klien.
+func toGraphQLError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return newError("NOT_FOUND", "not found")
+	case errors.Is(err, services.ErrInsufficientBalance):
+		return newError("INSUFFICIENT_BALANCE", err.Error())
+	case errors.Is(err, services.ErrLimitExceeded):
+		return newError("LIMIT_EXCEEDED", err.Error())
+	case errors.Is(err, services.ErrFraudReview):
+		return newError("FRAUD_REVIEW", err.Error())
+	case errors.Is(err, services.ErrFraudBlocked):
+		return newError("FRAUD_BLOCKED", err.Error())
+	case errors.Is(err, services.ErrAccountBlocked):
+		return newError("ACCOUNT_BLOCKED", err.Error())
+	case errors.Is(err, services.ErrAccountFrozen):
+		return newError("ACCOUNT_FROZEN", err.Error())
+	case errors.Is(err, services.ErrCounterpartyUnderReview):
+		return newError("COUNTERPARTY_UNDER_REVIEW", err.Error())
+	case errors.Is(err, services.ErrAccountUnderReview):
+		return newError("ACCOUNT_UNDER_REVIEW", err.Error())
+	case errors.Is(err, services.ErrKYCRequired):
+		return newError("KYC_REQUIRED", err.Error())
+	case errors.Is(err, services.ErrBalanceCapExceeded):
+		return newError("BALANCE_CAP_EXCEEDED", err.Error())
+	case errors.Is(err, services.ErrUserInactive):
+		return newError("FORBIDDEN", err.Error())
+	}
+	return newError("INTERNAL", "internal error")
+}
@@ -0,0 +1,67 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"hexagonal-go/internal/core/services"
+)
+
+// Request adalah body standar request GraphQL over HTTP.
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor menjalankan query GraphQL atas nama user yang sudah diautentikasi.
+// Sebelum dieksekusi, query divalidasi lalu diperiksa kedalaman dan
+// complexity-nya.
+type Executor struct {
+	schema        graphql.Schema
+	userService   services.UserService
+	maxDepth      int
+	maxComplexity int
+}
+
+func NewExecutor(userService services.UserService, transactionService services.TransactionService) (*Executor, error) {
+	schema, err := NewSchema(userService, transactionService)
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{
+		schema:        schema,
+		userService:   userService,
+		maxDepth:      defaultMaxDepth,
+		maxComplexity: defaultMaxComplexity,
+	}, nil
+}
+
+func (e *Executor) Execute(ctx context.Context, userID uuid.UUID, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&e.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := checkLimits(doc, req.OperationName, req.Variables, e.maxDepth, e.maxComplexity); err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{{Message: err.Error(), Extensions: err.Extensions()}}}
+	}
+
+	ctx = withUserID(ctx, userID)
+	ctx = withLoader(ctx, newCounterpartyLoader(ctx, e.userService))
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		Args:          req.Variables,
+		OperationName: req.OperationName,
+		Context:       ctx,
+	})
+}
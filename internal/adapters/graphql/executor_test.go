@@ -0,0 +1,292 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+type userMigration struct {
+	UserID      uuid.UUID `gorm:"primaryKey;type:uuid"`
+	FirstName   string
+	LastName    string
+	PhoneNumber string `gorm:"unique;not null"`
+	Address     string
+	Pin         string
+	Balance     float64
+	IsActive    bool
+	AccountTier string
+	IsBlocked   bool
+	IsFrozen    bool
+	KYCLevel    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (userMigration) TableName() string { return "users" }
+
+type transactionMigration struct {
+	TransactionID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null"`
+	TransactionType string
+	Category        string
+	Amount          float64
+	Remarks         string
+	BalanceBefore   float64
+	BalanceAfter    float64
+	CounterpartyID  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time
+}
+
+func (transactionMigration) TableName() string { return "transactions" }
+
+// testUserRepo membuat UUID (SQLite tidak memiliki uuid_generate_v4()) dan
+// menghitung pemanggilan FindByIDs untuk memeriksa batching.
+type testUserRepo struct {
+	*repository.UserRepositoryImpl
+	findByIDsCalls *int
+}
+
+func (r testUserRepo) Create(ctx context.Context, user *domain.User) error {
+	user.UserID = uuid.New()
+	return r.UserRepositoryImpl.Create(ctx, user)
+}
+
+func (r testUserRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	*r.findByIDsCalls++
+	return r.UserRepositoryImpl.FindByIDs(ctx, ids)
+}
+
+type testTransactionRepo struct {
+	*repository.TransactionRepositoryImpl
+}
+
+func (r testTransactionRepo) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
+	tx.TransactionID = uuid.New()
+	return r.TransactionRepositoryImpl.CreateWithTx(ctx, dbTx, tx)
+}
+
+type fixture struct {
+	executor           *Executor
+	userService        *services.UserService
+	transactionService *services.TransactionService
+	findByIDsCalls     *int
+}
+
+func setup(t *testing.T) fixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&userMigration{}, &transactionMigration{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	calls := 0
+	userService := services.NewUserService(testUserRepo{repository.NewUserRepositoryImpl(db), &calls})
+	transactionService := services.NewTransactionService(testTransactionRepo{repository.NewTransactionRepositoryImpl(db)}, db)
+	executor, err := NewExecutor(*userService, *transactionService)
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+	return fixture{executor: executor, userService: userService, transactionService: transactionService, findByIDsCalls: &calls}
+}
+
+func (f fixture) register(t *testing.T, name, phone string) *domain.User {
+	user := &domain.User{FirstName: name, PhoneNumber: phone, Pin: "123456", IsActive: true}
+	if err := f.userService.Register(context.Background(), user); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	return user
+}
+
+func (f fixture) execute(t *testing.T, userID uuid.UUID, query string, variables map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	result := f.executor.Execute(context.Background(), userID, Request{Query: query, Variables: variables})
+	if result.HasErrors() {
+		t.Fatalf("unexpected errors: %+v", result.Errors)
+	}
+	// Round-trip JSON agar hasil dapat dibaca seperti oleh klien.
+	body, _ := json.Marshal(result.Data)
+	var data map[string]interface{}
+	_ = json.Unmarshal(body, &data)
+	return data
+}
+
+func errorCode(result *graphql.Result) string {
+	if len(result.Errors) == 0 {
+		return ""
+	}
+	code, _ := result.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+func TestExecutorMe(t *testing.T) {
+	f := setup(t)
+	alice := f.register(t, "Alice", "0811")
+
+	data := f.execute(t, alice.UserID, `{ me { id firstName balance } }`, nil)
+	me := data["me"].(map[string]interface{})
+	if me["id"] != alice.UserID.String() || me["firstName"] != "Alice" {
+		t.Fatalf("unexpected me: %+v", me)
+	}
+}
+
+func TestExecutorTransactionsPagination(t *testing.T) {
+	f := setup(t)
+	alice := f.register(t, "Alice", "0811")
+	for i := 1; i <= 5; i++ {
+		if _, err := f.transactionService.Deposit(context.Background(), alice.UserID, float64(i), fmt.Sprintf("deposit %d", i)); err != nil {
+			t.Fatalf("Deposit returned error: %v", err)
+		}
+	}
+
+	query := `query($after: String) {
+		transactions(first: 2, after: $after, filter: {type: CREDIT}) {
+			edges { cursor node { amount } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+	var amounts []float64
+	var after interface{}
+	for page := 0; page < 5; page++ {
+		data := f.execute(t, alice.UserID, query, map[string]interface{}{"after": after})
+		conn := data["transactions"].(map[string]interface{})
+		for _, edge := range conn["edges"].([]interface{}) {
+			node := edge.(map[string]interface{})["node"].(map[string]interface{})
+			amounts = append(amounts, node["amount"].(float64))
+		}
+		pageInfo := conn["pageInfo"].(map[string]interface{})
+		if !pageInfo["hasNextPage"].(bool) {
+			break
+		}
+		after = pageInfo["endCursor"]
+	}
+	if len(amounts) != 5 {
+		t.Fatalf("expected 5 transactions across pages, got %v", amounts)
+	}
+	seen := map[float64]bool{}
+	for _, amount := range amounts {
+		if seen[amount] {
+			t.Fatalf("transaction returned twice: %v", amounts)
+		}
+		seen[amount] = true
+	}
+}
+
+func TestExecutorBatchesCounterparties(t *testing.T) {
+	f := setup(t)
+	alice := f.register(t, "Alice", "0811")
+	bob := f.register(t, "Bob", "0822")
+	carol := f.register(t, "Carol", "0833")
+	if _, err := f.transactionService.Deposit(context.Background(), alice.UserID, 100, "topup"); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	for _, to := range []uuid.UUID{bob.UserID, carol.UserID, bob.UserID} {
+		if _, _, err := f.transactionService.Transfer(context.Background(), alice.UserID, to, 10, "split"); err != nil {
+			t.Fatalf("Transfer returned error: %v", err)
+		}
+	}
+
+	data := f.execute(t, alice.UserID, `{ transactions { edges { node { category counterparty { firstName } } } } }`, nil)
+	if *f.findByIDsCalls != 1 {
+		t.Fatalf("expected counterparties to be loaded in 1 batch, got %d", *f.findByIDsCalls)
+	}
+	names := map[string]int{}
+	for _, edge := range data["transactions"].(map[string]interface{})["edges"].([]interface{}) {
+		node := edge.(map[string]interface{})["node"].(map[string]interface{})
+		if counterparty, ok := node["counterparty"].(map[string]interface{}); ok {
+			names[counterparty["firstName"].(string)]++
+		} else if node["category"] != domain.CategoryDeposit {
+			t.Fatalf("transfer without counterparty: %+v", node)
+		}
+	}
+	if names["Bob"] != 2 || names["Carol"] != 1 {
+		t.Fatalf("unexpected counterparties: %+v", names)
+	}
+}
+
+func TestExecutorTransferMutation(t *testing.T) {
+	f := setup(t)
+	alice := f.register(t, "Alice", "0811")
+	bob := f.register(t, "Bob", "0822")
+	if _, err := f.transactionService.Deposit(context.Background(), alice.UserID, 50, "topup"); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+
+	mutation := `mutation($input: TransferInput!) {
+		transfer(input: $input) { debit { balanceAfter } credit { balanceAfter counterparty { id } } }
+	}`
+	input := map[string]interface{}{"toUserId": bob.UserID.String(), "amount": 20.0, "remarks": "lunch"}
+	data := f.execute(t, alice.UserID, mutation, map[string]interface{}{"input": input})
+	payload := data["transfer"].(map[string]interface{})
+	debit := payload["debit"].(map[string]interface{})
+	credit := payload["credit"].(map[string]interface{})
+	if debit["balanceAfter"] != 30.0 || credit["balanceAfter"] != 20.0 {
+		t.Fatalf("unexpected transfer payload: %+v", payload)
+	}
+	if credit["counterparty"].(map[string]interface{})["id"] != alice.UserID.String() {
+		t.Fatalf("expected credit counterparty to be the sender: %+v", credit)
+	}
+
+	input["amount"] = 1000.0
+	result := f.executor.Execute(context.Background(), alice.UserID, Request{Query: mutation, Variables: map[string]interface{}{"input": input}})
+	if code := errorCode(result); code != "INSUFFICIENT_BALANCE" {
+		t.Fatalf("expected INSUFFICIENT_BALANCE, got %q (%+v)", code, result.Errors)
+	}
+}
+
+func TestExecutorQueryLimits(t *testing.T) {
+	f := setup(t)
+	alice := f.register(t, "Alice", "0811")
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{
+			name:  "too complex",
+			query: `{ transactions(first: 100) { edges { node { id amount remarks createdAt counterparty { id } } } } }`,
+			code:  "QUERY_TOO_COMPLEX",
+		},
+		{
+			name:  "invalid cursor",
+			query: `{ transactions(after: "not-a-cursor") { pageInfo { hasNextPage } } }`,
+			code:  "BAD_USER_INPUT",
+		},
+		{
+			name:  "page size over maximum",
+			query: `{ transactions(first: 101) { pageInfo { hasNextPage } } }`,
+			code:  "BAD_USER_INPUT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := f.executor.Execute(context.Background(), alice.UserID, Request{Query: tt.query})
+			if code := errorCode(result); code != tt.code {
+				t.Fatalf("expected code %q, got %q (%+v)", tt.code, code, result.Errors)
+			}
+		})
+	}
+
+	// Kedalaman dihitung menembus fragment.
+	f.executor.maxDepth = 3
+	result := f.executor.Execute(context.Background(), alice.UserID, Request{Query: `{ transactions { ...Page } }
+		fragment Page on TransactionConnection { edges { node { counterparty { id } } } }`})
+	if code := errorCode(result); code != "QUERY_TOO_DEEP" {
+		t.Fatalf("expected QUERY_TOO_DEEP, got %q (%+v)", code, result.Errors)
+	}
+}
@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	defaultMaxDepth      = 8
+	defaultMaxComplexity = 500
+)
+
+// connectionDefaults adalah nilai first yang dipakai untuk menghitung
+// complexity field connection ketika klien tidak mengirim argumen first.
+var connectionDefaults = map[string]int{
+	"transactions": defaultPageSize,
+}
+
+// queryCost menghitung kedalaman dan complexity sebuah operasi. Setiap field
+// bernilai 1; biaya field di dalam connection dikalikan jumlah item yang
+// diminta (first). Field introspection (__schema, __type) tidak dihitung.
+type queryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+func checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}, maxDepth, maxComplexity int) *codedError {
+	cost := queryCost{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			cost.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	depth, complexity := cost.selectionSet(operation.SelectionSet, map[string]bool{})
+	if depth > maxDepth {
+		return &codedError{code: "QUERY_TOO_DEEP", message: fmt.Sprintf("query depth %d exceeds limit %d", depth, maxDepth)}
+	}
+	if complexity > maxComplexity {
+		return &codedError{code: "QUERY_TOO_COMPLEX", message: fmt.Sprintf("query complexity %d exceeds limit %d", complexity, maxComplexity)}
+	}
+	return nil
+}
+
+// visited mencegah rekursi tak berujung pada fragment yang saling memakai;
+// dokumen seperti itu tetap ditolak oleh validasi.
+func (q queryCost) selectionSet(set *ast.SelectionSet, visited map[string]bool) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+	maxDepth, total := 0, 0
+	for _, selection := range set.Selections {
+		var depth, complexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			depth, complexity = q.field(selection, visited)
+		case *ast.InlineFragment:
+			depth, complexity = q.selectionSet(selection.SelectionSet, visited)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := q.fragments[name]
+			if !ok || visited[name] {
+				continue
+			}
+			visited[name] = true
+			depth, complexity = q.selectionSet(fragment.SelectionSet, visited)
+			delete(visited, name)
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		total += complexity
+	}
+	return maxDepth, total
+}
+
+func (q queryCost) field(field *ast.Field, visited map[string]bool) (int, int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+	depth, complexity := q.selectionSet(field.SelectionSet, visited)
+	return depth + 1, 1 + q.multiplier(field)*complexity
+}
+
+func (q queryCost) multiplier(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			if n, ok := q.variables[value.Name.Value].(float64); ok && n > 0 {
+				return int(n)
+			}
+			if n, ok := q.variables[value.Name.Value].(int); ok && n > 0 {
+				return n
+			}
+		}
+	}
+	if n, ok := connectionDefaults[field.Name.Value]; ok {
+		return n
+	}
+	return 1
+}
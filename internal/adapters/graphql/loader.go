@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// counterpartyLoader mengumpulkan ID counterparty yang diminta selama satu
+// request lalu mengambilnya dengan satu query. Resolver mengembalikan thunk,
+// sehingga graphql-go baru mengeksekusinya setelah seluruh field pada level
+// yang sama selesai dikumpulkan. Loader dibuat per request sehingga ctx milik
+// request tersebut ikut disimpan untuk query batch.
+type counterpartyLoader struct {
+	ctx         context.Context
+	userService services.UserService
+
+	mu      sync.Mutex
+	pending []uuid.UUID
+	cache   map[uuid.UUID]*domain.User
+	err     error
+}
+
+func newCounterpartyLoader(ctx context.Context, userService services.UserService) *counterpartyLoader {
+	return &counterpartyLoader{ctx: ctx, userService: userService, cache: map[uuid.UUID]*domain.User{}}
+}
+
+// load mendaftarkan id ke batch berikutnya dan mengembalikan thunk yang
+// menghasilkan user tersebut, atau nil jika user tidak ditemukan.
+func (l *counterpartyLoader) load(id uuid.UUID) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.cache[id]; !ok {
+		l.pending = append(l.pending, id)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.dispatch()
+		}
+		if l.err != nil {
+			return nil, l.err
+		}
+		if user := l.cache[id]; user != nil {
+			return user, nil
+		}
+		return nil, nil
+	}
+}
+
+// dispatch dipanggil dengan mu terkunci.
+func (l *counterpartyLoader) dispatch() {
+	ids := uniqueIDs(l.pending)
+	l.pending = nil
+	users, err := l.userService.GetByIDs(l.ctx, ids)
+	if err != nil {
+		l.err = err
+		return
+	}
+	for _, id := range ids {
+		l.cache[id] = nil
+	}
+	for i := range users {
+		l.cache[users[i].UserID] = &users[i]
+	}
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, loader *counterpartyLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func loaderFrom(ctx context.Context) *counterpartyLoader {
+	loader, _ := ctx.Value(loaderKey{}).(*counterpartyLoader)
+	return loader
+}
@@ -0,0 +1,306 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type resolver struct {
+	userService        services.UserService
+	transactionService services.TransactionService
+}
+
+// NewSchema membangun schema GraphQL di atas UserService dan
+// TransactionService yang juga dipakai adapter HTTP dan gRPC.
+func NewSchema(userService services.UserService, transactionService services.TransactionService) (graphql.Schema, error) {
+	r := resolver{userService: userService, transactionService: transactionService}
+
+	transactionTypeEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "TransactionType",
+		Values: graphql.EnumValueConfigMap{
+			domain.TransactionTypeCredit: {Value: domain.TransactionTypeCredit},
+			domain.TransactionTypeDebit:  {Value: domain.TransactionTypeDebit},
+		},
+	})
+	categoryEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "TransactionCategory",
+		Values: graphql.EnumValueConfigMap{
+			domain.CategoryDeposit:    {Value: domain.CategoryDeposit},
+			domain.CategoryWithdraw:   {Value: domain.CategoryWithdraw},
+			domain.CategoryTransfer:   {Value: domain.CategoryTransfer},
+			domain.CategoryFee:        {Value: domain.CategoryFee},
+			domain.CategoryInterest:   {Value: domain.CategoryInterest},
+			domain.CategoryAdjustment: {Value: domain.CategoryAdjustment},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":          userField(graphql.NewNonNull(graphql.ID), func(u *domain.User) interface{} { return u.UserID.String() }),
+			"firstName":   userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.FirstName }),
+			"lastName":    userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.LastName }),
+			"phoneNumber": userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.PhoneNumber }),
+			"address":     userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.Address }),
+			"balance":     userField(graphql.NewNonNull(graphql.Float), func(u *domain.User) interface{} { return u.Balance }),
+			"accountTier": userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.AccountTier }),
+			"isActive":    userField(graphql.NewNonNull(graphql.Boolean), func(u *domain.User) interface{} { return u.IsActive }),
+			"createdAt":   userField(graphql.NewNonNull(graphql.DateTime), func(u *domain.User) interface{} { return u.CreatedAt }),
+		},
+	})
+
+	// Counterparty hanya membuka data publik user di sisi lain transfer.
+	counterpartyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Counterparty",
+		Fields: graphql.Fields{
+			"id":        userField(graphql.NewNonNull(graphql.ID), func(u *domain.User) interface{} { return u.UserID.String() }),
+			"firstName": userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.FirstName }),
+			"lastName":  userField(graphql.NewNonNull(graphql.String), func(u *domain.User) interface{} { return u.LastName }),
+		},
+	})
+
+	transactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.Fields{
+			"id":            transactionField(graphql.NewNonNull(graphql.ID), func(t *domain.Transaction) interface{} { return t.TransactionID.String() }),
+			"type":          transactionField(graphql.NewNonNull(transactionTypeEnum), func(t *domain.Transaction) interface{} { return t.TransactionType }),
+			"category":      transactionField(graphql.NewNonNull(categoryEnum), func(t *domain.Transaction) interface{} { return t.Category }),
+			"amount":        transactionField(graphql.NewNonNull(graphql.Float), func(t *domain.Transaction) interface{} { return t.Amount }),
+			"remarks":       transactionField(graphql.NewNonNull(graphql.String), func(t *domain.Transaction) interface{} { return t.Remarks }),
+			"balanceBefore": transactionField(graphql.NewNonNull(graphql.Float), func(t *domain.Transaction) interface{} { return t.BalanceBefore }),
+			"balanceAfter":  transactionField(graphql.NewNonNull(graphql.Float), func(t *domain.Transaction) interface{} { return t.BalanceAfter }),
+			"createdAt":     transactionField(graphql.NewNonNull(graphql.DateTime), func(t *domain.Transaction) interface{} { return t.CreatedAt }),
+			"counterparty": &graphql.Field{
+				Type:    counterpartyType,
+				Resolve: r.counterparty,
+			},
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TransactionEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(transactionType)},
+		},
+	})
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hasPreviousPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"startCursor":     &graphql.Field{Type: graphql.String},
+			"endCursor":       &graphql.Field{Type: graphql.String},
+		},
+	})
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TransactionConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	filterInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TransactionFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type":     &graphql.InputObjectFieldConfig{Type: transactionTypeEnum},
+			"category": &graphql.InputObjectFieldConfig{Type: categoryEnum},
+			"from":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+			"to":       &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		},
+	})
+	transferInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TransferInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"toUserId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"amount":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+			"remarks":  &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: ""},
+		},
+	})
+	transferPayloadType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TransferPayload",
+		Fields: graphql.Fields{
+			"debit":  &graphql.Field{Type: graphql.NewNonNull(transactionType)},
+			"credit": &graphql.Field{Type: graphql.NewNonNull(transactionType)},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type:    graphql.NewNonNull(userType),
+				Resolve: r.me,
+			},
+			"transactions": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterInput},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.transactions,
+			},
+		},
+	})
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"transfer": &graphql.Field{
+				Type: graphql.NewNonNull(transferPayloadType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(transferInput)},
+				},
+				Resolve: r.transfer,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func userField(typ graphql.Output, get func(*domain.User) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*domain.User)), nil
+		},
+	}
+}
+
+func transactionField(typ graphql.Output, get func(*domain.Transaction) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*domain.Transaction)), nil
+		},
+	}
+}
+
+type userIDKey struct{}
+
+func withUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func currentUserID(ctx context.Context) (uuid.UUID, error) {
+	userID, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+	if !ok {
+		return uuid.Nil, newError("UNAUTHENTICATED", "unauthenticated")
+	}
+	return userID, nil
+}
+
+func (r resolver) me(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := currentUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.userService.GetByID(p.Context, userID)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return user, nil
+}
+
+func (r resolver) transactions(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := currentUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	first, _ := p.Args["first"].(int)
+	if first < 1 || first > maxPageSize {
+		return nil, newError("BAD_USER_INPUT", "first must be between 1 and 100")
+	}
+	var after *domain.TransactionCursor
+	if cursor, ok := p.Args["after"].(string); ok {
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, newError("BAD_USER_INPUT", err.Error())
+		}
+	}
+	filter, err := transactionFilter(p.Args["filter"])
+	if err != nil {
+		return nil, err
+	}
+
+	txs, hasNext, err := r.transactionService.ListTransactions(p.Context, userID, filter, after, first)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	edges := make([]map[string]interface{}, len(txs))
+	for i := range txs {
+		edges[i] = map[string]interface{}{"cursor": encodeCursor(txs[i]), "node": &txs[i]}
+	}
+	pageInfo := map[string]interface{}{"hasNextPage": hasNext, "hasPreviousPage": after != nil}
+	if len(edges) > 0 {
+		pageInfo["startCursor"] = edges[0]["cursor"]
+		pageInfo["endCursor"] = edges[len(edges)-1]["cursor"]
+	}
+	return map[string]interface{}{"edges": edges, "pageInfo": pageInfo}, nil
+}
+
+func transactionFilter(arg interface{}) (domain.TransactionFilter, error) {
+	var filter domain.TransactionFilter
+	values, _ := arg.(map[string]interface{})
+	filter.TransactionType, _ = values["type"].(string)
+	filter.Category, _ = values["category"].(string)
+	if from, ok := values["from"].(time.Time); ok {
+		filter.From = &from
+	}
+	if to, ok := values["to"].(time.Time); ok {
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return filter, newError("BAD_USER_INPUT", "filter.to must not be before filter.from")
+	}
+	return filter, nil
+}
+
+// counterparty dimuat lewat loader per request agar daftar transaksi tidak
+// memicu satu query user per transaksi.
+func (r resolver) counterparty(p graphql.ResolveParams) (interface{}, error) {
+	tx := p.Source.(*domain.Transaction)
+	if tx.CounterpartyID == nil {
+		return nil, nil
+	}
+	loader := loaderFrom(p.Context)
+	if loader == nil {
+		return nil, errors.New("counterparty loader missing from context")
+	}
+	return loader.load(*tx.CounterpartyID), nil
+}
+
+func (r resolver) transfer(p graphql.ResolveParams) (interface{}, error) {
+	fromID, err := currentUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+	input := p.Args["input"].(map[string]interface{})
+	toID, err := uuid.Parse(input["toUserId"].(string))
+	if err != nil {
+		return nil, newError("BAD_USER_INPUT", "invalid toUserId")
+	}
+	amount := input["amount"].(float64)
+	if amount <= 0 {
+		return nil, newError("BAD_USER_INPUT", "amount must be greater than zero")
+	}
+	remarks, _ := input["remarks"].(string)
+
+	debit, credit, err := r.transactionService.Transfer(p.Context, fromID, toID, amount, remarks)
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return map[string]interface{}{"debit": debit, "credit": credit}, nil
+}
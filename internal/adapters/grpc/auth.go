@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"hexagonal-go/internal/adapters/grpc/walletpb"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/logging"
+	"hexagonal-go/internal/utils"
+)
+
+type userIDKey struct{}
+
+// publicMethods dapat dipanggil tanpa access token.
+var publicMethods = map[string]bool{
+	walletpb.WalletService_Register_FullMethodName: true,
+	walletpb.WalletService_Login_FullMethodName:    true,
+}
+
+func isPublicMethod(fullMethod string) bool {
+	return publicMethods[fullMethod] || strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+// authenticate memvalidasi metadata "authorization: Bearer <token>" dan
+// menyimpan userID ke context, setara dengan AuthMiddleware pada adapter HTTP.
+// Pelaku audit (alamat peer dan user agent) disimpan juga untuk method publik.
+func authenticate(ctx context.Context, tokens *utils.TokenManager, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	actor := domain.AuditActor{Type: domain.ActorAnonymous}
+	if p, ok := peer.FromContext(ctx); ok {
+		actor.IPAddress = p.Addr.String()
+	}
+	if ua := md.Get("user-agent"); len(ua) > 0 {
+		actor.UserAgent = ua[0]
+	}
+	if isPublicMethod(fullMethod) {
+		return services.WithAuditActor(ctx, actor), nil
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata missing")
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+	userID, err := tokens.ValidateJWT(parts[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user id")
+	}
+	actor.Type, actor.ID = domain.ActorUser, &id
+	ctx = services.WithAuditActor(logging.WithUserID(ctx, userID), actor)
+	return context.WithValue(ctx, userIDKey{}, id), nil
+}
+
+// UnaryAuthInterceptor menolak panggilan unary tanpa access token yang valid.
+func UnaryAuthInterceptor(tokens *utils.TokenManager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, tokens, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor menolak panggilan stream tanpa access token yang valid.
+func StreamAuthInterceptor(tokens *utils.TokenManager) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), tokens, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// currentUserID mengambil userID hasil interceptor dari context.
+func currentUserID(ctx context.Context) (uuid.UUID, error) {
+	id, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+	if !ok {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	return id, nil
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/services"
+)
+
+// toStatus memetakan error domain ke status code gRPC. Error yang tidak
+// dikenal dikembalikan sebagai Internal tanpa detail agar pesan database tidak
+// bocor ke klien.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, services.ErrInsufficientBalance):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, services.ErrFraudReview):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, services.ErrFraudBlocked), errors.Is(err, services.ErrAccountBlocked), errors.Is(err, services.ErrCounterpartyUnderReview), errors.Is(err, services.ErrAccountUnderReview):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, services.ErrAccountFrozen):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, services.ErrKYCRequired), errors.Is(err, services.ErrBalanceCapExceeded):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, services.ErrInvalidPin):
+		return status.Error(codes.Unauthenticated, "invalid phone number or pin")
+	case errors.Is(err, services.ErrUserInactive):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
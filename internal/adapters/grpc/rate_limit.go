@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"hexagonal-go/internal/adapters/grpc/walletpb"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// rateLimitedRoutes memetakan method gRPC ke route HTTP yang setara, sehingga
+// kebijakan dan bucket rate limit yang sama berlaku di kedua adapter dan
+// klien tidak bisa menghindari limit dengan berpindah protokol.
+var rateLimitedRoutes = map[string]string{
+	walletpb.WalletService_Register_FullMethodName: "/register",
+	walletpb.WalletService_Login_FullMethodName:    "/login",
+	walletpb.WalletService_Deposit_FullMethodName:  "/deposit",
+	walletpb.WalletService_Withdraw_FullMethodName: "/withdraw",
+	walletpb.WalletService_Transfer_FullMethodName: "/transfer",
+}
+
+// UnaryRateLimitInterceptor menerapkan RateLimitService pada method di
+// rateLimitedRoutes, setara dengan middleware RateLimit pada adapter HTTP.
+// Dipasang setelah UnaryAuthInterceptor agar kebijakan per user melihat
+// userID. Panggilan yang ditolak mendapat RESOURCE_EXHAUSTED dengan metadata
+// retry-after; jika store gagal, panggilan diteruskan.
+func UnaryRateLimitInterceptor(limiter *services.RateLimitService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		route, ok := rateLimitedRoutes[info.FullMethod]
+		if !ok || limiter == nil {
+			return handler(ctx, req)
+		}
+		request := domain.RateLimitRequest{Method: http.MethodPost, Route: route, IPAddress: peerIP(ctx)}
+		if userID, err := currentUserID(ctx); err == nil {
+			request.UserID = userID.String()
+		}
+		decision, err := limiter.Allow(ctx, request)
+		if err != nil {
+			slog.WarnContext(ctx, "rate limit check failed", "error", err)
+			return handler(ctx, req)
+		}
+		if decision != nil && !decision.Allowed {
+			retryAfter := strconv.FormatInt(int64(math.Ceil(decision.RetryAfter.Seconds())), 10)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// peerIP mengembalikan IP klien tanpa port.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
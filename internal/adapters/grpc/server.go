@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"hexagonal-go/internal/adapters/grpc/walletpb"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/utils"
+)
+
+// NewServer membuat server gRPC dengan interceptor tracing, autentikasi JWT,
+// dan rate limit, WalletService, dan server reflection untuk grpcurl/grpcui.
+// opts menambah opsi server lain, mis. kredensial TLS.
+func NewServer(wallet *WalletServer, tokens *utils.TokenManager, limiter *services.RateLimitService, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryTracingInterceptor(), UnaryAuthInterceptor(tokens), UnaryRateLimitInterceptor(limiter)),
+		grpc.ChainStreamInterceptor(StreamTracingInterceptor(), StreamAuthInterceptor(tokens)),
+	}, opts...)...)
+	walletpb.RegisterWalletServiceServer(server, wallet)
+	reflection.Register(server)
+	return server
+}
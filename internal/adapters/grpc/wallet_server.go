@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/grpc/walletpb"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/utils"
+)
+
+// WalletServer adalah driving adapter gRPC yang memakai service yang sama
+// dengan adapter HTTP.
+type WalletServer struct {
+	walletpb.UnimplementedWalletServiceServer
+	userService        services.UserService
+	transactionService services.TransactionService
+	tokens             *utils.TokenManager
+}
+
+func NewWalletServer(userService services.UserService, transactionService services.TransactionService, tokens *utils.TokenManager) *WalletServer {
+	return &WalletServer{userService: userService, transactionService: transactionService, tokens: tokens}
+}
+
+func (s *WalletServer) Register(ctx context.Context, req *walletpb.RegisterRequest) (*walletpb.User, error) {
+	if req.GetPhoneNumber() == "" || req.GetPin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "phone_number and pin are required")
+	}
+	user := &domain.User{
+		FirstName:   req.GetFirstName(),
+		LastName:    req.GetLastName(),
+		PhoneNumber: req.GetPhoneNumber(),
+		Address:     req.GetAddress(),
+		Pin:         req.GetPin(),
+		IsActive:    true,
+	}
+	if err := s.userService.Register(ctx, user); err != nil {
+		return nil, toStatus(err)
+	}
+	return toUserPB(user), nil
+}
+
+func (s *WalletServer) Login(ctx context.Context, req *walletpb.LoginRequest) (*walletpb.LoginResponse, error) {
+	user, err := s.userService.Login(ctx, req.GetPhoneNumber(), req.GetPin())
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, services.ErrInvalidPin) {
+		return nil, status.Error(codes.Unauthenticated, "invalid phone number or pin")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// Catat perangkat login; kegagalan pencatatan tidak menggagalkan login.
+	md, _ := metadata.FromIncomingContext(ctx)
+	userAgent := firstValue(md, "user-agent")
+	deviceKey := firstValue(md, "x-device-id")
+	if deviceKey == "" {
+		deviceKey = userAgent
+	}
+	var ipAddress string
+	if p, ok := peer.FromContext(ctx); ok {
+		ipAddress = p.Addr.String()
+	}
+	if _, err := s.userService.RecordLogin(ctx, user.UserID, deviceKey, userAgent, ipAddress); err != nil {
+		slog.WarnContext(ctx, "failed to record login device", "error", err)
+	}
+
+	token, err := s.tokens.GenerateJWT(user.UserID.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+	refreshToken, err := s.tokens.GenerateRefreshToken(user.UserID.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate refresh token")
+	}
+	return &walletpb.LoginResponse{AccessToken: token, RefreshToken: refreshToken}, nil
+}
+
+func (s *WalletServer) GetProfile(ctx context.Context, req *walletpb.GetProfileRequest) (*walletpb.User, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUserPB(user), nil
+}
+
+func (s *WalletServer) Deposit(ctx context.Context, req *walletpb.DepositRequest) (*walletpb.Transaction, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	tx, err := s.transactionService.Deposit(ctx, userID, req.GetAmount(), req.GetRemarks())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTransactionPB(tx), nil
+}
+
+func (s *WalletServer) Withdraw(ctx context.Context, req *walletpb.WithdrawRequest) (*walletpb.Transaction, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	tx, err := s.transactionService.Withdraw(ctx, userID, req.GetAmount(), req.GetRemarks())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTransactionPB(tx), nil
+}
+
+func (s *WalletServer) Transfer(ctx context.Context, req *walletpb.TransferRequest) (*walletpb.TransferResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := uuid.Parse(req.GetToUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to_user_id")
+	}
+	if toID == userID {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer to the same account")
+	}
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	debit, credit, err := s.transactionService.Transfer(ctx, userID, toID, req.GetAmount(), req.GetRemarks())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &walletpb.TransferResponse{Debit: toTransactionPB(debit), Credit: toTransactionPB(credit)}, nil
+}
+
+func (s *WalletServer) ListTransactions(ctx context.Context, req *walletpb.ListTransactionsRequest) (*walletpb.ListTransactionsResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.transactionService.GetTransactionsByUser(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &walletpb.ListTransactionsResponse{Transactions: make([]*walletpb.Transaction, 0, len(txs))}
+	for i := range txs {
+		resp.Transactions = append(resp.Transactions, toTransactionPB(&txs[i]))
+	}
+	return resp, nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func toUserPB(user *domain.User) *walletpb.User {
+	return &walletpb.User{
+		UserId:      user.UserID.String(),
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		PhoneNumber: user.PhoneNumber,
+		Address:     user.Address,
+		Balance:     user.Balance,
+		IsActive:    user.IsActive,
+		AccountTier: user.AccountTier,
+		CreatedAt:   timestamppb.New(user.CreatedAt),
+		UpdatedAt:   timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func toTransactionPB(tx *domain.Transaction) *walletpb.Transaction {
+	return &walletpb.Transaction{
+		TransactionId:   tx.TransactionID.String(),
+		UserId:          tx.UserID.String(),
+		TransactionType: tx.TransactionType,
+		Category:        tx.Category,
+		Amount:          tx.Amount,
+		Remarks:         tx.Remarks,
+		BalanceBefore:   tx.BalanceBefore,
+		BalanceAfter:    tx.BalanceAfter,
+		CreatedAt:       timestamppb.New(tx.CreatedAt),
+	}
+}
@@ -0,0 +1,234 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/grpc/walletpb"
+	"hexagonal-go/internal/adapters/ratelimit"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/utils"
+)
+
+type userMigration struct {
+	UserID      uuid.UUID `gorm:"primaryKey;type:uuid"`
+	FirstName   string
+	LastName    string
+	PhoneNumber string `gorm:"unique;not null"`
+	Address     string
+	Pin         string
+	Balance     float64
+	IsActive    bool
+	AccountTier string
+	IsBlocked   bool
+	IsFrozen    bool
+	KYCLevel    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (userMigration) TableName() string { return "users" }
+
+type transactionMigration struct {
+	TransactionID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null"`
+	TransactionType string
+	Category        string
+	Amount          float64
+	Remarks         string
+	BalanceBefore   float64
+	BalanceAfter    float64
+	CounterpartyID  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time
+}
+
+func (transactionMigration) TableName() string { return "transactions" }
+
+// SQLite tidak memiliki uuid_generate_v4(), sehingga ID dibuat di repository test.
+type testUserRepo struct {
+	*repository.UserRepositoryImpl
+}
+
+func (r testUserRepo) Create(ctx context.Context, user *domain.User) error {
+	user.UserID = uuid.New()
+	return r.UserRepositoryImpl.Create(ctx, user)
+}
+
+type testTransactionRepo struct {
+	*repository.TransactionRepositoryImpl
+}
+
+func (r testTransactionRepo) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
+	tx.TransactionID = uuid.New()
+	return r.TransactionRepositoryImpl.CreateWithTx(ctx, dbTx, tx)
+}
+
+func setupClient(t *testing.T) (walletpb.WalletServiceClient, *grpc.ClientConn) {
+	return setupClientWithLimits(t, nil)
+}
+
+// setupClientWithLimits menjalankan server dengan kebijakan rate limit
+// policies; nil berarti tanpa limit.
+func setupClientWithLimits(t *testing.T, policies []domain.RateLimitPolicy) (walletpb.WalletServiceClient, *grpc.ClientConn) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&userMigration{}, &transactionMigration{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	userService := services.NewUserService(testUserRepo{repository.NewUserRepositoryImpl(db)})
+	transactionService := services.NewTransactionService(testTransactionRepo{repository.NewTransactionRepositoryImpl(db)}, db)
+	tokens := utils.NewTokenManager("test-secret", time.Hour, 24*time.Hour)
+	limiter := services.NewRateLimitService(policies, ratelimit.NewMemoryStore())
+	server := NewServer(NewWalletServer(*userService, *transactionService, tokens), tokens, limiter)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return walletpb.NewWalletServiceClient(conn), conn
+}
+
+func registerAndLogin(t *testing.T, client walletpb.WalletServiceClient, phone string) (*walletpb.User, context.Context) {
+	user, err := client.Register(context.Background(), &walletpb.RegisterRequest{FirstName: "Test", PhoneNumber: phone, Pin: "123456"})
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	login, err := client.Login(context.Background(), &walletpb.LoginRequest{PhoneNumber: phone, Pin: "123456"})
+	if err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	return user, metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+login.GetAccessToken())
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %v, got %v (%v)", want, got, err)
+	}
+}
+
+func TestWalletServerDepositTransferAndList(t *testing.T) {
+	client, _ := setupClient(t)
+	alice, aliceCtx := registerAndLogin(t, client, "0811")
+	bob, bobCtx := registerAndLogin(t, client, "0822")
+
+	if _, err := client.Deposit(aliceCtx, &walletpb.DepositRequest{Amount: 100, Remarks: "topup"}); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	resp, err := client.Transfer(aliceCtx, &walletpb.TransferRequest{ToUserId: bob.GetUserId(), Amount: 40, Remarks: "lunch"})
+	if err != nil {
+		t.Fatalf("Transfer returned error: %v", err)
+	}
+	if resp.GetDebit().GetBalanceAfter() != 60 || resp.GetCredit().GetUserId() != bob.GetUserId() {
+		t.Fatalf("unexpected transfer response: %+v", resp)
+	}
+
+	profile, err := client.GetProfile(bobCtx, &walletpb.GetProfileRequest{})
+	if err != nil || profile.GetBalance() != 40 {
+		t.Fatalf("unexpected profile: %+v, err %v", profile, err)
+	}
+	list, err := client.ListTransactions(aliceCtx, &walletpb.ListTransactionsRequest{})
+	if err != nil {
+		t.Fatalf("ListTransactions returned error: %v", err)
+	}
+	if len(list.GetTransactions()) != 2 || list.GetTransactions()[0].GetUserId() != alice.GetUserId() {
+		t.Fatalf("unexpected transactions: %+v", list.GetTransactions())
+	}
+}
+
+func TestWalletServerStatusCodes(t *testing.T) {
+	client, _ := setupClient(t)
+	_, ctx := registerAndLogin(t, client, "0811")
+
+	_, err := client.GetProfile(context.Background(), &walletpb.GetProfileRequest{})
+	assertCode(t, err, codes.Unauthenticated)
+
+	badCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = client.GetProfile(badCtx, &walletpb.GetProfileRequest{})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = client.Login(context.Background(), &walletpb.LoginRequest{PhoneNumber: "0811", Pin: "000000"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = client.Withdraw(ctx, &walletpb.WithdrawRequest{Amount: 10})
+	assertCode(t, err, codes.FailedPrecondition)
+
+	_, err = client.Deposit(ctx, &walletpb.DepositRequest{Amount: -5})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = client.Transfer(ctx, &walletpb.TransferRequest{ToUserId: uuid.NewString(), Amount: 5})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestWalletServerReflection(t *testing.T) {
+	_, conn := setupClient(t)
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to open reflection stream: %v", err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("failed to send reflection request: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive reflection response: %v", err)
+	}
+	found := false
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		found = found || svc.GetName() == walletpb.WalletService_ServiceDesc.ServiceName
+	}
+	if !found {
+		t.Fatalf("wallet service not listed by reflection: %+v", resp)
+	}
+}
+
+func TestWalletServerRateLimit(t *testing.T) {
+	client, _ := setupClientWithLimits(t, []domain.RateLimitPolicy{
+		{Name: "login", Routes: []string{"POST /login"}, Key: domain.RateLimitByIP, Limit: 2, PeriodSeconds: 60},
+		{Name: "money-user", Routes: []string{"POST /deposit", "POST /withdraw", "POST /transfer"}, Key: domain.RateLimitByUser, Limit: 1, PeriodSeconds: 60},
+	})
+	_, aliceCtx := registerAndLogin(t, client, "0811")
+
+	_, err := client.Login(context.Background(), &walletpb.LoginRequest{PhoneNumber: "0811", Pin: "000000"})
+	assertCode(t, err, codes.Unauthenticated)
+	var header metadata.MD
+	_, err = client.Login(context.Background(), &walletpb.LoginRequest{PhoneNumber: "0811", Pin: "123456"}, grpc.Header(&header))
+	assertCode(t, err, codes.ResourceExhausted)
+	if len(header.Get("retry-after")) != 1 {
+		t.Fatalf("expected retry-after metadata, got %v", header)
+	}
+
+	if _, err := client.Deposit(aliceCtx, &walletpb.DepositRequest{Amount: 100}); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	_, err = client.Withdraw(aliceCtx, &walletpb.WithdrawRequest{Amount: 10})
+	assertCode(t, err, codes.ResourceExhausted)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// Batas jumlah entri per halaman pada /admin/audit.
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// auditPage adalah satu halaman audit log. NextBefore diisi jika masih ada
+// entri yang lebih lama; kirim sebagai query before untuk halaman berikutnya.
+type auditPage struct {
+	Entries    []domain.AuditEntry `json:"entries"`
+	NextBefore *int64              `json:"next_before,omitempty"`
+}
+
+// List handler untuk endpoint /admin/audit. Semua query opsional: actor_id,
+// target_id, action, from dan to (RFC 3339, to eksklusif), before (sequence)
+// dan limit (default 50, maksimal 200). Entri diurutkan dari yang terbaru.
+func (h *AuditHandler) List(c *gin.Context) {
+	var filter domain.AuditFilter
+	for _, param := range []struct {
+		name   string
+		target **uuid.UUID
+	}{{"actor_id", &filter.ActorID}, {"target_id", &filter.TargetID}} {
+		if v := c.Query(param.name); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param.name})
+				return
+			}
+			*param.target = &id
+		}
+	}
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := c.Query(param.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param.name + " time"})
+				return
+			}
+			*param.target = &t
+		}
+	}
+	filter.Action = strings.ToUpper(c.Query("action"))
+
+	before, err := strconv.ParseInt(c.DefaultQuery("before", "0"), 10, 64)
+	if err != nil || before < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultAuditPageSize)))
+	if err != nil || limit < 1 || limit > maxAuditPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	entries, next, err := h.auditService.List(c.Request.Context(), filter, before, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if entries == nil {
+		entries = []domain.AuditEntry{}
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": auditPage{Entries: entries, NextBefore: next}})
+}
+
+// Verify handler untuk endpoint /admin/audit/verify. Rantai yang rusak tetap
+// dijawab 200 dengan valid=false beserta sequence entri yang rusak.
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.auditService.Verify(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": result})
+}
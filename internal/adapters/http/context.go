@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// currentUserID mengambil userID hasil AuthMiddleware dari context. Jika gagal,
+// response error sudah ditulis dan ok bernilai false.
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDVal, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "userID not found"})
+		return uuid.Nil, false
+	}
+	userIDStr, ok := userIDVal.(string)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid userID type"})
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
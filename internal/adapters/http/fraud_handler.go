@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// Batas jumlah kasus per response pada /admin/fraud/reviews.
+const (
+	defaultFraudReviewLimit = 50
+	maxFraudReviewLimit     = 200
+)
+
+type FraudHandler struct {
+	fraudService       services.FraudService
+	transactionService services.TransactionService
+}
+
+func NewFraudHandler(fraudService services.FraudService, transactionService services.TransactionService) *FraudHandler {
+	return &FraudHandler{fraudService: fraudService, transactionService: transactionService}
+}
+
+// ListReviews handler untuk endpoint /admin/fraud/reviews. Query status
+// (default PENDING, ALL untuk semua) dan limit (default 50, maksimal 200).
+func (h *FraudHandler) ListReviews(c *gin.Context) {
+	status := strings.ToUpper(c.DefaultQuery("status", domain.FraudReviewPending))
+	switch status {
+	case "ALL":
+		status = ""
+	case domain.FraudReviewPending, domain.FraudReviewApproved, domain.FraudReviewRejected, domain.FraudReviewBlocked:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultFraudReviewLimit)))
+	if err != nil || limit < 1 || limit > maxFraudReviewLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	reviews, err := h.fraudService.ListReviews(c.Request.Context(), status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": reviews})
+}
+
+// GetReview handler untuk endpoint /admin/fraud/reviews/:review_id
+func (h *FraudHandler) GetReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review id"})
+		return
+	}
+	review, err := h.fraudService.GetReview(c.Request.Context(), reviewID)
+	if err != nil {
+		respondReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": review})
+}
+
+// ApproveReview handler untuk endpoint /admin/fraud/reviews/:review_id/approve.
+// Dana dipindahkan saat itu juga; saldo dan limit diperiksa ulang.
+func (h *FraudHandler) ApproveReview(c *gin.Context) {
+	h.decide(c, h.transactionService.ApproveReview)
+}
+
+// RejectReview handler untuk endpoint /admin/fraud/reviews/:review_id/reject
+func (h *FraudHandler) RejectReview(c *gin.Context) {
+	h.decide(c, h.transactionService.RejectReview)
+}
+
+func (h *FraudHandler) decide(c *gin.Context, decide func(ctx context.Context, reviewID uuid.UUID, note string) (*domain.FraudReview, error)) {
+	reviewID, err := uuid.Parse(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review id"})
+		return
+	}
+	var request struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	review, err := decide(c.Request.Context(), reviewID, request.Note)
+	if err != nil {
+		respondReviewError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": review})
+}
+
+// respondReviewError memetakan error keputusan analis: kasus tidak ada 404,
+// kasus yang sudah diputuskan 409, dan sisanya seperti error transaksi.
+func respondReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fraud review not found"})
+	case errors.Is(err, services.ErrReviewNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		respondTransactionError(c, err)
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/adapters/graphql"
+)
+
+type GraphQLHandler struct {
+	executor *graphql.Executor
+}
+
+func NewGraphQLHandler(executor *graphql.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+// Query handler untuk endpoint /graphql. Response mengikuti format GraphQL
+// ({"data": ..., "errors": [...]}) dan selalu 200 selama body request valid.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req graphql.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, h.executor.Execute(c.Request.Context(), userID, req))
+}
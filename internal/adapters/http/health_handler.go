@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Liveness handler untuk endpoint /healthz. Hanya menandakan proses hidup dan
+// sengaja tidak memeriksa dependensi agar gangguan database tidak membuat
+// pod di-restart.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": domain.HealthStatusUp})
+}
+
+// Readiness handler untuk endpoint /readyz. Mengembalikan 503 jika ada
+// pemeriksaan yang gagal sehingga pod dikeluarkan dari load balancer.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.healthService.Check(c.Request.Context())
+	c.JSON(healthStatusCode(report), gin.H{"status": report.Status})
+}
+
+// Health handler untuk endpoint /health berisi status dan latensi setiap
+// pemeriksaan.
+func (h *HealthHandler) Health(c *gin.Context) {
+	report := h.healthService.Check(c.Request.Context())
+	c.JSON(healthStatusCode(report), report)
+}
+
+func healthStatusCode(report domain.HealthReport) int {
+	if report.Status != domain.HealthStatusUp {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
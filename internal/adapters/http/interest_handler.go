@@ -0,0 +1,29 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/core/services"
+	"net/http"
+)
+
+type InterestHandler struct {
+	interestService services.InterestService
+}
+
+func NewInterestHandler(interestService services.InterestService) *InterestHandler {
+	return &InterestHandler{interestService: interestService}
+}
+
+// Accrued handler untuk endpoint /interest/accrued
+func (h *InterestHandler) Accrued(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	accrued, err := h.interestService.AccruedInterest(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": gin.H{"accrued_interest": accrued}})
+}
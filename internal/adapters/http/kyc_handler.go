@@ -0,0 +1,219 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// Batas jumlah pengajuan per response pada /admin/kyc/submissions.
+const (
+	defaultKYCSubmissionLimit = 50
+	maxKYCSubmissionLimit     = 200
+)
+
+// kycDocumentFields adalah field file multipart untuk setiap jenis dokumen
+// KYC; nama field adalah jenis dokumen dalam huruf kecil.
+var kycDocumentFields = []string{domain.KYCDocumentIDCard, domain.KYCDocumentSelfie, domain.KYCDocumentProofOfAddress}
+
+type KYCHandler struct {
+	kycService services.KYCService
+}
+
+func NewKYCHandler(kycService services.KYCService) *KYCHandler {
+	return &KYCHandler{kycService: kycService}
+}
+
+// Status handler untuk endpoint GET /kyc
+func (h *KYCHandler) Status(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	status, err := h.kycService.Status(c.Request.Context(), userID)
+	if err != nil {
+		respondKYCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": status})
+}
+
+// Submit handler untuk endpoint POST /kyc/submissions. Body multipart/form-data
+// berisi level, full_name, id_number, date_of_birth, dan file id_card, selfie,
+// atau proof_of_address.
+func (h *KYCHandler) Submit(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(len(kycDocumentFields))*domain.MaxKYCDocumentSize+1<<20)
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart form"})
+		return
+	}
+	defer form.RemoveAll()
+
+	application := domain.KYCApplication{
+		Level:       strings.ToUpper(formValue(form, "level")),
+		FullName:    formValue(form, "full_name"),
+		IDNumber:    formValue(form, "id_number"),
+		DateOfBirth: formValue(form, "date_of_birth"),
+	}
+	for _, kind := range kycDocumentFields {
+		field := strings.ToLower(kind)
+		files := form.File[field]
+		if len(files) == 0 {
+			continue
+		}
+		if len(files) > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Only one %s file is allowed", field)})
+			return
+		}
+		file, err := files[0].Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid multipart form"})
+			return
+		}
+		defer file.Close()
+		application.Documents = append(application.Documents, domain.KYCUpload{Kind: kind, FileName: files[0].Filename, Content: file})
+	}
+
+	submission, err := h.kycService.Submit(c.Request.Context(), userID, application)
+	if err != nil {
+		respondKYCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": submission})
+}
+
+func formValue(form *multipart.Form, name string) string {
+	if values := form.Value[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ListSubmissions handler untuk endpoint /admin/kyc/submissions. Query status
+// (default PENDING, ALL untuk semua) dan limit (default 50, maksimal 200);
+// pengajuan terlama lebih dulu.
+func (h *KYCHandler) ListSubmissions(c *gin.Context) {
+	status := strings.ToUpper(c.DefaultQuery("status", domain.KYCSubmissionPending))
+	switch status {
+	case "ALL":
+		status = ""
+	case domain.KYCSubmissionPending, domain.KYCSubmissionApproved, domain.KYCSubmissionRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultKYCSubmissionLimit)))
+	if err != nil || limit < 1 || limit > maxKYCSubmissionLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	submissions, err := h.kycService.ListSubmissions(c.Request.Context(), status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": submissions})
+}
+
+// GetSubmission handler untuk endpoint /admin/kyc/submissions/:submission_id
+func (h *KYCHandler) GetSubmission(c *gin.Context) {
+	submissionID, err := uuid.Parse(c.Param("submission_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission id"})
+		return
+	}
+	submission, err := h.kycService.GetSubmission(c.Request.Context(), submissionID)
+	if err != nil {
+		respondKYCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": submission})
+}
+
+// GetDocument handler untuk endpoint
+// /admin/kyc/submissions/:submission_id/documents/:document_id. Isi dokumen
+// dikirim apa adanya dengan content type yang terdeteksi saat diunggah.
+func (h *KYCHandler) GetDocument(c *gin.Context) {
+	submissionID, err := uuid.Parse(c.Param("submission_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission id"})
+		return
+	}
+	documentID, err := uuid.Parse(c.Param("document_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document id"})
+		return
+	}
+	document, content, err := h.kycService.OpenDocument(c.Request.Context(), submissionID, documentID)
+	if err != nil {
+		respondKYCError(c, err)
+		return
+	}
+	defer content.Close()
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, strings.ToLower(document.Kind)))
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, io.LimitReader(content, document.Size), nil)
+}
+
+// ApproveSubmission handler untuk endpoint
+// /admin/kyc/submissions/:submission_id/approve. Level KYC user langsung naik.
+func (h *KYCHandler) ApproveSubmission(c *gin.Context) {
+	h.decide(c, h.kycService.ApproveSubmission)
+}
+
+// RejectSubmission handler untuk endpoint
+// /admin/kyc/submissions/:submission_id/reject
+func (h *KYCHandler) RejectSubmission(c *gin.Context) {
+	h.decide(c, h.kycService.RejectSubmission)
+}
+
+func (h *KYCHandler) decide(c *gin.Context, decide func(ctx context.Context, submissionID uuid.UUID, note string) (*domain.KYCSubmission, error)) {
+	submissionID, err := uuid.Parse(c.Param("submission_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission id"})
+		return
+	}
+	var request struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	submission, err := decide(c.Request.Context(), submissionID, request.Note)
+	if err != nil {
+		respondKYCError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": submission})
+}
+
+func respondKYCError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrInvalidKYCSubmission):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrKYCSubmissionPending), errors.Is(err, services.ErrSubmissionNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAccountBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "ACCOUNT_BLOCKED"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
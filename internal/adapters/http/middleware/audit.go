@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/logging"
+)
+
+// AuditActor stores the origin of the request (client IP, user agent and
+// request ID) in the request context for the audit log. Requests start as
+// anonymous; AuthMiddleware and AdminAuthMiddleware fill in who is acting.
+// Register it after RequestID.
+func AuditActor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(services.WithAuditActor(c.Request.Context(), requestActor(c)))
+		c.Next()
+	}
+}
+
+// requestActor returns the actor already stored for this request, or a new
+// anonymous actor describing where the request came from.
+func requestActor(c *gin.Context) domain.AuditActor {
+	actor := services.AuditActorFrom(c.Request.Context())
+	if actor.Type == domain.ActorSystem {
+		actor = domain.AuditActor{
+			Type:      domain.ActorAnonymous,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		}
+	}
+	return actor
+}
+
+// AdminAuthMiddleware protects the admin API with per-operator bearer tokens
+// (ADMIN_API_TOKENS), keyed by operator name. The operator owning the token is
+// recorded as the actor, so every admin action in the audit log names a
+// person. When no operator is configured every request is rejected, so the
+// admin API is off by default.
+func AdminAuthMiddleware(operators map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(operators) == 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin API is disabled"})
+			return
+		}
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		operator := ""
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+			// every token is compared so the time taken does not reveal which operator matched
+			for name, token := range operators {
+				if subtle.ConstantTimeCompare([]byte(parts[1]), []byte(token)) == 1 {
+					operator = name
+				}
+			}
+		}
+		if operator == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+
+		actor := requestActor(c)
+		actor.Type, actor.Name = domain.ActorAdmin, operator
+		c.Request = c.Request.WithContext(services.WithAuditActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/logging"
 	"hexagonal-go/internal/utils"
 )
 
 // AuthMiddleware validates JWT tokens from the Authorization header.
 // It expects the header to be in the format: "Bearer <token>".
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(tokens *utils.TokenManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -24,14 +28,52 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		userID, err := utils.ValidateJWT(parts[1])
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		authenticate(c, tokens, parts[1])
+	}
+}
+
+// WebSocketAuthMiddleware behaves like AuthMiddleware but also accepts the
+// token in the access_token query parameter, because browsers cannot set
+// headers on a WebSocket handshake.
+func WebSocketAuthMiddleware(tokens *utils.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query("access_token")
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+				return
+			}
+			token = parts[1]
+		}
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authorization header missing"})
 			return
 		}
 
-		// store userID in context for downstream handlers
-		c.Set("userID", userID)
-		c.Next()
+		authenticate(c, tokens, token)
+	}
+}
+
+func authenticate(c *gin.Context, tokens *utils.TokenManager, token string) {
+	claims, err := tokens.ParseJWT(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		return
+	}
+
+	// store userID and token expiry in context for downstream handlers; the
+	// request context carries the user ID into service and access logs
+	c.Set("userID", claims.UserID)
+	if claims.ExpiresAt != nil {
+		c.Set("tokenExpiresAt", claims.ExpiresAt.Time)
+	}
+	ctx := logging.WithUserID(c.Request.Context(), claims.UserID)
+	if id, err := uuid.Parse(claims.UserID); err == nil {
+		actor := requestActor(c)
+		actor.Type, actor.ID = domain.ActorUser, &id
+		ctx = services.WithAuditActor(ctx, actor)
 	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
 }
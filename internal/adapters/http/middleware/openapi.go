@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	openapi3filter.RegisterBodyDecoder("application/pdf", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("image/jpeg", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.PlainBodyDecoder)
+}
+
+// OpenAPIOption mengatur perilaku OpenAPIValidator.
+type OpenAPIOption func(*openAPIValidator)
+
+// WithResponseValidation ikut memvalidasi response terhadap spec dan
+// melaporkan ketidaksesuaian ke report. Dipakai di test untuk mendeteksi
+// drift antara handler dan spec; response tetap dikirim apa adanya.
+func WithResponseValidation(report func(c *gin.Context, err error)) OpenAPIOption {
+	return func(v *openAPIValidator) {
+		v.reportResponse = report
+	}
+}
+
+type openAPIValidator struct {
+	router         routers.Router
+	reportResponse func(c *gin.Context, err error)
+}
+
+// OpenAPIValidator menolak request yang tidak sesuai dengan dokumen OpenAPI
+// dengan 400. Route yang tidak ada di spec diteruskan tanpa validasi, dan
+// autentikasi tetap menjadi tugas AuthMiddleware.
+func OpenAPIValidator(doc *openapi3.T, opts ...OpenAPIOption) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	v := &openAPIValidator{router: router}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v.handle, nil
+}
+
+func (v *openAPIValidator) handle(c *gin.Context) {
+	route, pathParams, err := v.router.FindRoute(c.Request)
+	if err != nil {
+		c.Next()
+		return
+	}
+	input := &openapi3filter.RequestValidationInput{
+		Request:    c.Request,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			SkipSettingDefaults: true,
+		},
+	}
+	if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if v.reportResponse == nil || isStreaming(route) {
+		c.Next()
+		return
+	}
+	recorder := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	err = openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.Status(),
+		Header:                 recorder.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		v.reportResponse(c, err)
+	}
+}
+
+// isStreaming melaporkan operasi yang response-nya tidak pernah selesai (SSE)
+// atau berpindah protokol (WebSocket), sehingga body-nya tidak direkam.
+func isStreaming(route *routers.Route) bool {
+	responses := route.Operation.Responses
+	if responses.Status(http.StatusSwitchingProtocols) != nil {
+		return true
+	}
+	ok := responses.Status(http.StatusOK)
+	return ok != nil && ok.Value != nil && ok.Value.Content.Get("text/event-stream") != nil
+}
+
+// bodyRecorder menyalin body response ke buffer sambil tetap menulisnya ke klien.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
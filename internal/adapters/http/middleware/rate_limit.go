@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// RateLimit applies the rate limit policies of the matched route. Register it
+// after AuthMiddleware on authenticated routes so per-user policies see the
+// user ID. Every limited response carries the RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers; rejected
+// requests get 429 with Retry-After. When the store fails the request is let
+// through, so an outage of the shared store does not take the API down.
+func RateLimit(limiter *services.RateLimitService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		decision, err := limiter.Allow(c.Request.Context(), domain.RateLimitRequest{
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			IPAddress: c.ClientIP(),
+			UserID:    c.GetString("userID"),
+		})
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limit check failed", "error", err)
+			c.Next()
+			return
+		}
+		if decision == nil {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		policy := decision.Policy
+		header.Set("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", seconds(decision.Reset))
+		header.Set("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(policy.PeriodSeconds))
+		if !decision.Allowed {
+			header.Set("Retry-After", seconds(decision.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// seconds formats d as whole seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
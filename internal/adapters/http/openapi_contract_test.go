@@ -0,0 +1,936 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"hexagonal-go/api/openapi"
+	"hexagonal-go/internal/adapters/blobstore"
+	"hexagonal-go/internal/adapters/graphql"
+	"hexagonal-go/internal/adapters/http/middleware"
+	"hexagonal-go/internal/adapters/ratelimit"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/config"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+	"hexagonal-go/internal/core/services"
+	"hexagonal-go/internal/utils"
+)
+
+type userMigration struct {
+	UserID      uuid.UUID `gorm:"primaryKey;type:uuid"`
+	FirstName   string
+	LastName    string
+	PhoneNumber string `gorm:"unique;not null"`
+	Address     string
+	Pin         string
+	Balance     float64
+	IsActive    bool
+	AccountTier string
+	IsBlocked   bool
+	IsFrozen    bool
+	KYCLevel    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (userMigration) TableName() string { return "users" }
+
+type transactionMigration struct {
+	TransactionID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null"`
+	TransactionType string
+	Category        string
+	Amount          float64
+	Remarks         string
+	BalanceBefore   float64
+	BalanceAfter    float64
+	CounterpartyID  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time
+}
+
+func (transactionMigration) TableName() string { return "transactions" }
+
+type interestAccrualMigration struct {
+	AccrualID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	AccrualDate   time.Time
+	Balance       float64
+	AnnualRate    float64
+	Amount        float64
+	PostedAt      *time.Time
+	TransactionID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time
+}
+
+func (interestAccrualMigration) TableName() string { return "interest_accruals" }
+
+// SQLite tidak memiliki uuid_generate_v4(), sehingga ID dibuat di repository test.
+type testUserRepo struct {
+	*repository.UserRepositoryImpl
+}
+
+func (r testUserRepo) Create(ctx context.Context, user *domain.User) error {
+	user.UserID = uuid.New()
+	return r.UserRepositoryImpl.Create(ctx, user)
+}
+
+func (r testUserRepo) WithTx(dbTx *gorm.DB) ports.UserRepository {
+	return testUserRepo{repository.NewUserRepositoryImpl(dbTx)}
+}
+
+type testTransactionRepo struct {
+	*repository.TransactionRepositoryImpl
+}
+
+func (r testTransactionRepo) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
+	tx.TransactionID = uuid.New()
+	return r.TransactionRepositoryImpl.CreateWithTx(ctx, dbTx, tx)
+}
+
+// staticWatchlist adalah daftar pantauan tetap untuk test.
+type staticWatchlist []domain.WatchlistEntry
+
+func (w staticWatchlist) Load(context.Context) ([]domain.WatchlistEntry, error) {
+	return w, nil
+}
+
+var contractWatchlist = staticWatchlist{{List: "test", EntryID: "WL-1", Name: "Viktor Bout", Program: "ARMS"}}
+
+// contractClient mengirim request ke router yang memvalidasi request dan
+// response terhadap spec OpenAPI; response yang menyimpang menggagalkan test.
+type contractClient struct {
+	t      *testing.T
+	router *gin.Engine
+	token  string
+	health *services.HealthService
+	// notifications mengirim pesan ke messages; event dimasukkan langsung
+	// karena router contract tidak menjalankan relay outbox.
+	notifications *services.NotificationService
+	messages      *recordingNotifier
+	// db dipakai test untuk menyiapkan state yang hanya diubah job latar,
+	// misalnya pembekuan akun oleh rekonsiliasi.
+	db *gorm.DB
+}
+
+// recordingNotifier menyimpan pesan yang dikirim NotificationService.
+type recordingNotifier struct {
+	sent []domain.NotificationMessage
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, message domain.NotificationMessage) error {
+	n.sent = append(n.sent, message)
+	return nil
+}
+
+// contractAdminToken adalah token admin API milik operator contractOperator
+// pada router contract test.
+const (
+	contractOperator   = "alice.ops"
+	contractAdminToken = "test-admin-token"
+)
+
+func setupContract(t *testing.T) *contractClient {
+	gin.SetMode(gin.TestMode)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&userMigration{}, &transactionMigration{}, &interestAccrualMigration{},
+		&domain.WebhookSubscription{}, &domain.WebhookDelivery{}, &domain.WebhookAttempt{}, &domain.AuditEntry{}, &domain.FraudReview{}, &domain.ScreeningHit{},
+		&domain.KYCSubmission{}, &domain.KYCDocument{}, &domain.NotificationPreference{}, &domain.NotificationDelivery{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	transactionRepo := testTransactionRepo{repository.NewTransactionRepositoryImpl(db)}
+	auditService := services.NewAuditService(repository.NewAuditRepositoryImpl(db), db)
+	screeningService := services.NewScreeningService(repository.NewScreeningRepositoryImpl(db), contractWatchlist, db,
+		domain.ScreeningConfig{MatchThreshold: 0.85, BlockThreshold: 0.95}, services.WithScreeningAudit(auditService))
+	if err := screeningService.Reload(context.Background()); err != nil {
+		t.Fatalf("failed to load watchlist: %v", err)
+	}
+	userService := services.NewUserService(testUserRepo{repository.NewUserRepositoryImpl(db)}, services.WithUserAudit(db, auditService),
+		services.WithUserScreening(db, screeningService))
+	limitService := services.NewLimitService([]domain.LimitPolicy{
+		{AccountTier: domain.AccountTierRegular, Operation: domain.CategoryWithdraw, PerTransaction: 500},
+	})
+	fraudService := services.NewFraudService(repository.NewFraudRepositoryImpl(db), domain.FraudConfig{
+		ReviewScore:  50,
+		BlockScore:   100,
+		NewPayee:     &domain.NewPayeeRule{Score: 50, MinAmount: 300},
+		RapidCashOut: &domain.RapidCashOutRule{Score: 50, WindowMinutes: 60, Ratio: 0.8},
+	})
+	documents, err := blobstore.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create document store: %v", err)
+	}
+	kycService := services.NewKYCService(repository.NewKYCRepositoryImpl(db), documents, db, []domain.KYCPolicy{
+		{Level: domain.KYCLevelUnverified, Withdraw: true, TransferOut: true, MaxBalance: 10000},
+	}, services.WithKYCAudit(auditService))
+	transactionService := services.NewTransactionService(transactionRepo, db, services.WithLimitService(limitService),
+		services.WithTransactionAudit(auditService), services.WithFraudService(fraudService), services.WithTransactionScreening(screeningService),
+		services.WithKYCService(kycService))
+	interestService := services.NewInterestService(repository.NewInterestRepositoryImpl(db), transactionRepo, db, domain.InterestConfig{})
+	statementService := services.NewStatementService(transactionRepo)
+	webhookService := services.NewWebhookService(repository.NewWebhookRepositoryImpl(db), nil)
+	messages := &recordingNotifier{}
+	notificationService := services.NewNotificationService(repository.NewNotificationRepositoryImpl(db), repository.NewUserRepositoryImpl(db),
+		services.WithNotifier(domain.ChannelSMS, messages), services.WithNotifier(domain.ChannelEmail, messages))
+	executor, err := graphql.NewExecutor(*userService, *transactionService)
+	if err != nil {
+		t.Fatalf("failed to build graphql schema: %v", err)
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+	validator, err := middleware.OpenAPIValidator(spec, middleware.WithResponseValidation(func(c *gin.Context, err error) {
+		t.Errorf("%s %s: response does not match spec: %v", c.Request.Method, c.Request.URL, err)
+	}))
+	if err != nil {
+		t.Fatalf("failed to build validator: %v", err)
+	}
+
+	tokens := utils.NewTokenManager("test-secret", time.Hour, 24*time.Hour)
+	userHandler := NewUserHandler(*userService, tokens)
+	transactionHandler := NewTransactionHandler(*transactionService)
+	interestHandler := NewInterestHandler(*interestService)
+	statementHandler := NewStatementHandler(*statementService)
+	webhookHandler := NewWebhookHandler(*webhookService)
+	auditHandler := NewAuditHandler(*auditService)
+	fraudHandler := NewFraudHandler(*fraudService, *transactionService)
+	screeningHandler := NewScreeningHandler(screeningService)
+	kycHandler := NewKYCHandler(*kycService)
+	notificationHandler := NewNotificationHandler(*notificationService)
+	graphqlHandler := NewGraphQLHandler(executor)
+	docsHandler := NewDocsHandler(openapi.Spec)
+	healthService := services.NewHealthService()
+	healthService.Register("database", repository.NewDatabaseHealthChecker(db))
+	healthService.Register("migrations", repository.NewMigrationHealthChecker(db, &domain.User{}, &domain.Transaction{}))
+	healthHandler := NewHealthHandler(healthService)
+
+	registry := prometheus.NewRegistry()
+	r := gin.New()
+	if err := r.SetTrustedProxies(nil); err != nil {
+		t.Fatalf("failed to set trusted proxies: %v", err)
+	}
+	r.Use(middleware.RequestID(), middleware.AuditActor(), middleware.Logger(slog.New(slog.NewJSONHandler(io.Discard, nil))),
+		middleware.Tracing(), middleware.Metrics(registry), validator)
+	r.GET("/openapi.json", docsHandler.OpenAPI)
+	r.GET("/docs", docsHandler.SwaggerUI)
+	r.GET("/healthz", healthHandler.Liveness)
+	r.GET("/readyz", healthHandler.Readiness)
+	r.GET("/health", healthHandler.Health)
+	r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
+	rateLimit := middleware.RateLimit(services.NewRateLimitService(config.DefaultRateLimitPolicies(), ratelimit.NewMemoryStore()))
+	public := r.Group("/")
+	public.Use(rateLimit)
+	{
+		public.POST("/register", userHandler.Register)
+		public.POST("/login", userHandler.Login)
+		public.POST("/refresh", userHandler.RefreshToken)
+	}
+	auth := r.Group("/")
+	auth.Use(middleware.AuthMiddleware(tokens), rateLimit)
+	{
+		auth.POST("/deposit", transactionHandler.Deposit)
+		auth.POST("/withdraw", transactionHandler.Withdraw)
+		auth.POST("/transfer", transactionHandler.Transfer)
+		auth.GET("/transactions/:user_id", transactionHandler.GetTransactions)
+		auth.GET("/fees/preview", transactionHandler.PreviewFee)
+		auth.GET("/limits", transactionHandler.GetLimits)
+		auth.GET("/interest/accrued", interestHandler.Accrued)
+		auth.GET("/statements", statementHandler.GetStatement)
+		auth.POST("/webhooks", webhookHandler.CreateSubscription)
+		auth.GET("/webhooks", webhookHandler.ListSubscriptions)
+		auth.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription)
+		auth.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+		auth.GET("/webhook-deliveries/:id", webhookHandler.GetDelivery)
+		auth.POST("/webhook-deliveries/:id/redeliver", webhookHandler.Redeliver)
+		auth.POST("/graphql", graphqlHandler.Query)
+		auth.GET("/profile", userHandler.Profile)
+		auth.PUT("/profile", userHandler.UpdateProfile)
+		auth.PUT("/pin", userHandler.ChangePin)
+		auth.PUT("/deactivate", userHandler.Deactivate)
+		auth.PUT("/activate", userHandler.Activate)
+		auth.GET("/kyc", kycHandler.Status)
+		auth.POST("/kyc/submissions", kycHandler.Submit)
+		auth.GET("/notifications", notificationHandler.List)
+		auth.GET("/notifications/preferences", notificationHandler.GetPreference)
+		auth.PUT("/notifications/preferences", notificationHandler.UpdatePreference)
+	}
+	admin := r.Group("/")
+	admin.Use(middleware.AdminAuthMiddleware(map[string]string{contractOperator: contractAdminToken, "bob.ops": "other-admin-token"}))
+	{
+		admin.GET("/admin/audit", auditHandler.List)
+		admin.GET("/admin/audit/verify", auditHandler.Verify)
+		admin.GET("/admin/fraud/reviews", fraudHandler.ListReviews)
+		admin.GET("/admin/fraud/reviews/:review_id", fraudHandler.GetReview)
+		admin.POST("/admin/fraud/reviews/:review_id/approve", fraudHandler.ApproveReview)
+		admin.POST("/admin/fraud/reviews/:review_id/reject", fraudHandler.RejectReview)
+		admin.GET("/admin/screening/hits", screeningHandler.ListHits)
+		admin.GET("/admin/screening/hits/:hit_id", screeningHandler.GetHit)
+		admin.POST("/admin/screening/hits/:hit_id/clear", screeningHandler.ClearHit)
+		admin.POST("/admin/screening/hits/:hit_id/confirm", screeningHandler.ConfirmHit)
+		admin.GET("/admin/kyc/submissions", kycHandler.ListSubmissions)
+		admin.GET("/admin/kyc/submissions/:submission_id", kycHandler.GetSubmission)
+		admin.GET("/admin/kyc/submissions/:submission_id/documents/:document_id", kycHandler.GetDocument)
+		admin.POST("/admin/kyc/submissions/:submission_id/approve", kycHandler.ApproveSubmission)
+		admin.POST("/admin/kyc/submissions/:submission_id/reject", kycHandler.RejectSubmission)
+		admin.POST("/admin/users/:user_id/unfreeze", userHandler.Unfreeze)
+	}
+	return &contractClient{t: t, router: r, health: healthService, notifications: notificationService, messages: messages, db: db}
+}
+
+func (c *contractClient) do(method, path string, body interface{}, wantStatus int) map[string]interface{} {
+	c.t.Helper()
+	var reader *bytes.Reader
+	if s, ok := body.(string); ok {
+		reader = bytes.NewReader([]byte(s))
+	} else {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := c.serve(req, wantStatus)
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return resp
+}
+
+// serve mengirim req dengan token klien dan memastikan status response-nya.
+func (c *contractClient) serve(req *http.Request, wantStatus int) *httptest.ResponseRecorder {
+	c.t.Helper()
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	if w.Code != wantStatus {
+		c.t.Fatalf("%s %s: expected status %d, got %d: %s", req.Method, req.URL, wantStatus, w.Code, w.Body.String())
+	}
+	return w
+}
+
+func resultOf(resp map[string]interface{}) map[string]interface{} {
+	result, _ := resp["result"].(map[string]interface{})
+	return result
+}
+
+func TestContract(t *testing.T) {
+	c := setupContract(t)
+
+	c.do(http.MethodGet, "/openapi.json", nil, http.StatusOK)
+	c.do(http.MethodGet, "/docs", nil, http.StatusOK)
+
+	alice := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	bob := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Bob", "phone_number": "0822", "pin": "123456"}, http.StatusOK))
+	aliceID, bobID := alice["UserID"].(string), bob["UserID"].(string)
+
+	c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "000000"}, http.StatusUnauthorized)
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	tokens = resultOf(c.do(http.MethodPost, "/refresh", gin.H{"refresh_token": tokens["refresh_token"]}, http.StatusOK))
+
+	c.do(http.MethodGet, "/profile", nil, http.StatusUnauthorized)
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodGet, "/profile", nil, http.StatusOK)
+
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": aliceID, "amount": 1000, "remarks": "topup"}, http.StatusOK)
+	c.do(http.MethodPost, "/withdraw", gin.H{"user_id": aliceID, "amount": 50}, http.StatusOK)
+	c.do(http.MethodPost, "/withdraw", gin.H{"user_id": aliceID, "amount": 600}, http.StatusUnprocessableEntity)
+	c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": bobID, "amount": 5000}, http.StatusBadRequest)
+	c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": bobID, "amount": 25, "remarks": "lunch"}, http.StatusOK)
+	c.do(http.MethodGet, "/transactions/"+aliceID, nil, http.StatusOK)
+	c.do(http.MethodGet, "/fees/preview?operation=withdraw&amount=10", nil, http.StatusOK)
+	c.do(http.MethodGet, "/limits", nil, http.StatusOK)
+	c.do(http.MethodGet, "/interest/accrued", nil, http.StatusOK)
+	c.do(http.MethodGet, "/statements", nil, http.StatusOK)
+	c.do(http.MethodGet, "/statements?format=csv", nil, http.StatusOK)
+
+	sub := resultOf(c.do(http.MethodPost, "/webhooks", gin.H{"url": "https://partner.example/hook", "event_types": []string{"FundsDeposited"}}, http.StatusOK))
+	subID := sub["subscription"].(map[string]interface{})["subscription_id"].(string)
+	c.do(http.MethodGet, "/webhooks", nil, http.StatusOK)
+	c.do(http.MethodGet, "/webhooks/"+subID+"/deliveries", nil, http.StatusOK)
+	c.do(http.MethodGet, "/webhook-deliveries/"+uuid.NewString(), nil, http.StatusNotFound)
+	c.do(http.MethodPost, "/webhook-deliveries/"+uuid.NewString()+"/redeliver", nil, http.StatusNotFound)
+	c.do(http.MethodDelete, "/webhooks/"+subID, nil, http.StatusOK)
+
+	c.do(http.MethodPost, "/graphql", gin.H{"query": "{ me { firstName balance } }"}, http.StatusOK)
+
+	c.do(http.MethodPut, "/pin", gin.H{"old_pin": "000000", "new_pin": "654321"}, http.StatusBadRequest)
+	c.do(http.MethodPut, "/pin", gin.H{"old_pin": "123456", "new_pin": "654321"}, http.StatusOK)
+	c.do(http.MethodPut, "/profile", gin.H{"first_name": "Alicia", "account_tier": "PREMIUM", "balance": 999999}, http.StatusBadRequest)
+	if profile := c.do(http.MethodPut, "/profile", gin.H{"first_name": "Alicia", "phone_number": "0811"}, http.StatusOK)["result"].(map[string]interface{}); profile["account_tier"] != domain.AccountTierRegular {
+		t.Fatalf("expected stored account tier in profile response, got %v", profile)
+	}
+	c.do(http.MethodPut, "/deactivate", nil, http.StatusOK)
+	c.do(http.MethodPut, "/activate", nil, http.StatusOK)
+}
+
+func TestContractHealth(t *testing.T) {
+	c := setupContract(t)
+
+	c.do(http.MethodGet, "/healthz", nil, http.StatusOK)
+	if resp := c.do(http.MethodGet, "/readyz", nil, http.StatusOK); resp["status"] != "UP" {
+		t.Fatalf("expected ready, got %v", resp)
+	}
+	report := c.do(http.MethodGet, "/health", nil, http.StatusOK)
+	if checks, _ := report["checks"].([]interface{}); len(checks) != 2 {
+		t.Fatalf("expected database and migrations checks, got %v", report)
+	}
+
+	c.health.Register("workers", ports.HealthCheckFunc(func(context.Context) error {
+		return errors.New("not running: outbox relay")
+	}))
+	if resp := c.do(http.MethodGet, "/readyz", nil, http.StatusServiceUnavailable); resp["status"] != "DOWN" {
+		t.Fatalf("expected not ready, got %v", resp)
+	}
+	report = c.do(http.MethodGet, "/health", nil, http.StatusServiceUnavailable)
+	checks, _ := report["checks"].([]interface{})
+	if failed, _ := checks[2].(map[string]interface{}); failed["status"] != "DOWN" || failed["error"] != "not running: outbox relay" {
+		t.Fatalf("unexpected workers check: %v", checks)
+	}
+	c.do(http.MethodGet, "/healthz", nil, http.StatusOK)
+}
+
+func TestContractMetrics(t *testing.T) {
+	c := setupContract(t)
+	c.do(http.MethodGet, "/healthz", nil, http.StatusOK)
+	c.do(http.MethodGet, "/no-such-route", nil, http.StatusNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	for _, want := range []string{
+		`hexago_http_requests_total{method="GET",route="/healthz",status="200"} 1`,
+		`hexago_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`hexago_http_request_duration_seconds_count{method="GET",route="/healthz"} 1`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
+
+func TestContractTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	c := setupContract(t)
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"phone_number":"0800","pin":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("traceparent"); !strings.Contains(got, traceID) {
+		t.Fatalf("expected response traceparent to continue trace %s, got %q", traceID, got)
+	}
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	server, ok := spans["POST /login"]
+	if !ok || server.SpanContext().TraceID().String() != traceID {
+		t.Fatalf("expected server span in trace %s, got %v", traceID, spans)
+	}
+	login, ok := spans["UserService.Login"]
+	if !ok || login.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatalf("expected UserService.Login as child of the server span, got %v", spans)
+	}
+}
+
+func TestContractRequestID(t *testing.T) {
+	c := setupContract(t)
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"reuses client id", "client-req-42", true},
+		{"generates missing id", "", false},
+		{"replaces malformed id", "bad id\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			if tt.incoming != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			c.router.ServeHTTP(w, req)
+			got := w.Header().Get(middleware.RequestIDHeader)
+			if tt.keep && got != tt.incoming {
+				t.Fatalf("expected request id %q, got %q", tt.incoming, got)
+			}
+			if !tt.keep {
+				if _, err := uuid.Parse(got); err != nil {
+					t.Fatalf("expected generated UUID request id, got %q", got)
+				}
+			}
+		})
+	}
+}
+
+func TestContractAudit(t *testing.T) {
+	c := setupContract(t)
+	alice := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	aliceID := alice["UserID"].(string)
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": aliceID, "amount": 1000}, http.StatusOK)
+
+	// Token user biasa tidak berlaku untuk admin API.
+	c.do(http.MethodGet, "/admin/audit", nil, http.StatusUnauthorized)
+	c.token = contractAdminToken
+	c.do(http.MethodGet, "/admin/audit?limit=500", nil, http.StatusBadRequest)
+
+	page := resultOf(c.do(http.MethodGet, "/admin/audit?target_id="+aliceID, nil, http.StatusOK))
+	entries, _ := page["entries"].([]interface{})
+	if len(entries) != 2 {
+		t.Fatalf("expected LOGIN and DEPOSIT entries, got %v", page)
+	}
+	deposit := entries[0].(map[string]interface{})
+	if deposit["action"] != domain.AuditDeposit || deposit["actor_type"] != domain.ActorUser || deposit["actor_id"] != aliceID {
+		t.Fatalf("unexpected deposit entry: %v", deposit)
+	}
+	if deposit["request_id"] == "" || deposit["ip_address"] == "" {
+		t.Fatalf("expected request origin in entry: %v", deposit)
+	}
+
+	page = resultOf(c.do(http.MethodGet, "/admin/audit?action=login&limit=1", nil, http.StatusOK))
+	if entries, _ := page["entries"].([]interface{}); len(entries) != 1 || page["next_before"] != nil {
+		t.Fatalf("expected single LOGIN entry, got %v", page)
+	}
+
+	verification := resultOf(c.do(http.MethodGet, "/admin/audit/verify", nil, http.StatusOK))
+	if verification["valid"] != true || verification["entries_checked"] != float64(2) {
+		t.Fatalf("unexpected verification: %v", verification)
+	}
+}
+
+func TestContractFraudReview(t *testing.T) {
+	c := setupContract(t)
+	var ids []string
+	for _, phone := range []string{"0811", "0822", "0833"} {
+		user := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "User", "phone_number": phone, "pin": "123456"}, http.StatusOK))
+		ids = append(ids, user["UserID"].(string))
+	}
+	aliceID, bobID, carolID := ids[0], ids[1], ids[2]
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": aliceID, "amount": 1000}, http.StatusOK)
+
+	held := c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": bobID, "amount": 400}, http.StatusAccepted)
+	if held["status"] != "PENDING_REVIEW" || resultOf(held)["status"] != domain.FraudReviewPending {
+		t.Fatalf("expected pending review, got %v", held)
+	}
+	reviewID := resultOf(held)["review_id"].(string)
+	if resp := c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": bobID, "amount": 900}, http.StatusForbidden); resp["code"] != "FRAUD_BLOCKED" {
+		t.Fatalf("expected FRAUD_BLOCKED, got %v", resp)
+	}
+	rejected := resultOf(c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": carolID, "amount": 300}, http.StatusAccepted))
+
+	c.do(http.MethodGet, "/admin/fraud/reviews", nil, http.StatusUnauthorized)
+	c.token = contractAdminToken
+	c.do(http.MethodGet, "/admin/fraud/reviews?status=unknown", nil, http.StatusBadRequest)
+	if reviews, _ := c.do(http.MethodGet, "/admin/fraud/reviews", nil, http.StatusOK)["result"].([]interface{}); len(reviews) != 2 {
+		t.Fatalf("expected 2 pending reviews, got %v", reviews)
+	}
+	if reviews, _ := c.do(http.MethodGet, "/admin/fraud/reviews?status=BLOCKED", nil, http.StatusOK)["result"].([]interface{}); len(reviews) != 1 {
+		t.Fatalf("expected 1 blocked review, got %v", reviews)
+	}
+	c.do(http.MethodGet, "/admin/fraud/reviews/"+reviewID, nil, http.StatusOK)
+	c.do(http.MethodGet, "/admin/fraud/reviews/"+uuid.NewString(), nil, http.StatusNotFound)
+
+	approved := resultOf(c.do(http.MethodPost, "/admin/fraud/reviews/"+reviewID+"/approve", gin.H{"note": "confirmed with customer"}, http.StatusOK))
+	if approved["status"] != domain.FraudReviewApproved || approved["transaction_id"] == nil || approved["reviewed_by"] != contractOperator {
+		t.Fatalf("unexpected approved review: %v", approved)
+	}
+	c.do(http.MethodPost, "/admin/fraud/reviews/"+reviewID+"/approve", gin.H{}, http.StatusConflict)
+	c.token = "other-admin-token"
+	if reviewed := resultOf(c.do(http.MethodPost, "/admin/fraud/reviews/"+rejected["review_id"].(string)+"/reject", gin.H{"note": "mule account"}, http.StatusOK)); reviewed["reviewed_by"] != "bob.ops" {
+		t.Fatalf("expected the second operator as reviewer, got %v", reviewed)
+	}
+	c.do(http.MethodPost, "/admin/fraud/reviews/"+uuid.NewString()+"/reject", gin.H{}, http.StatusNotFound)
+}
+
+func TestContractScreening(t *testing.T) {
+	c := setupContract(t)
+	var ids []string
+	for _, user := range []gin.H{
+		{"first_name": "Alice", "phone_number": "0811", "pin": "123456"},
+		{"first_name": "Viktor", "last_name": "Bout", "phone_number": "0822", "pin": "123456"},
+		{"first_name": "Victor", "last_name": "Bout", "phone_number": "0833", "pin": "123456"},
+	} {
+		ids = append(ids, resultOf(c.do(http.MethodPost, "/register", user, http.StatusOK))["UserID"].(string))
+	}
+	aliceID, viktorID, victorID := ids[0], ids[1], ids[2]
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": aliceID, "amount": 1000}, http.StatusOK)
+	if resp := c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": viktorID, "amount": 100}, http.StatusForbidden); resp["code"] != "COUNTERPARTY_UNDER_REVIEW" {
+		t.Fatalf("expected COUNTERPARTY_UNDER_REVIEW, got %v", resp)
+	}
+
+	c.token = contractAdminToken
+	c.do(http.MethodGet, "/admin/screening/hits?status=unknown", nil, http.StatusBadRequest)
+	hits, _ := c.do(http.MethodGet, "/admin/screening/hits", nil, http.StatusOK)["result"].([]interface{})
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 pending hits, got %v", hits)
+	}
+	hitIDs := make(map[string]string)
+	for _, hit := range hits {
+		hit := hit.(map[string]interface{})
+		if hit["trigger"] != domain.ScreeningTriggerRegister || hit["entry_id"] != "WL-1" {
+			t.Fatalf("unexpected hit: %v", hit)
+		}
+		hitIDs[hit["user_id"].(string)] = hit["hit_id"].(string)
+	}
+	c.do(http.MethodGet, "/admin/screening/hits/"+hitIDs[viktorID], nil, http.StatusOK)
+	c.do(http.MethodGet, "/admin/screening/hits/"+uuid.NewString(), nil, http.StatusNotFound)
+
+	confirmed := resultOf(c.do(http.MethodPost, "/admin/screening/hits/"+hitIDs[viktorID]+"/confirm", gin.H{"note": "matches passport"}, http.StatusOK))
+	if confirmed["status"] != domain.ScreeningHitConfirmed || confirmed["review_note"] != "matches passport" {
+		t.Fatalf("unexpected confirmed hit: %v", confirmed)
+	}
+	c.do(http.MethodPost, "/admin/screening/hits/"+hitIDs[viktorID]+"/clear", gin.H{}, http.StatusConflict)
+	c.do(http.MethodPost, "/admin/screening/hits/"+hitIDs[victorID]+"/clear", gin.H{"note": "different person"}, http.StatusOK)
+	c.do(http.MethodPost, "/admin/screening/hits/"+uuid.NewString()+"/confirm", gin.H{}, http.StatusNotFound)
+
+	c.token = tokens["access_token"].(string)
+	if resp := c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": viktorID, "amount": 100}, http.StatusForbidden); resp["code"] != "ACCOUNT_BLOCKED" {
+		t.Fatalf("expected ACCOUNT_BLOCKED, got %v", resp)
+	}
+	if resp := c.do(http.MethodPost, "/deposit", gin.H{"user_id": viktorID, "amount": 100}, http.StatusForbidden); resp["code"] != "ACCOUNT_BLOCKED" {
+		t.Fatalf("expected ACCOUNT_BLOCKED on deposit, got %v", resp)
+	}
+	c.do(http.MethodPost, "/transfer", gin.H{"from_id": aliceID, "to_id": victorID, "amount": 100}, http.StatusOK)
+	c.do(http.MethodPost, "/login", gin.H{"phone_number": "0822", "pin": "123456"}, http.StatusUnauthorized)
+}
+
+// kycForm membuat body multipart pengajuan KYC; files berisi nama field dan
+// isi file.
+func kycForm(t *testing.T, fields map[string]string, files map[string][]byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatalf("failed to write field: %v", err)
+		}
+	}
+	for name, content := range files {
+		part, err := writer.CreateFormFile(name, name+".png")
+		if err != nil {
+			t.Fatalf("failed to create file part: %v", err)
+		}
+		part.Write(content)
+	}
+	writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func TestContractKYC(t *testing.T) {
+	c := setupContract(t)
+	user := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	userID := user["UserID"].(string)
+	if user["kyc_level"] != domain.KYCLevelUnverified {
+		t.Fatalf("expected new user to be UNVERIFIED, got %v", user["kyc_level"])
+	}
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	userToken := tokens["access_token"].(string)
+	c.token = userToken
+	if resp := c.do(http.MethodPost, "/deposit", gin.H{"user_id": userID, "amount": 20000}, http.StatusForbidden); resp["code"] != "BALANCE_CAP_EXCEEDED" {
+		t.Fatalf("expected BALANCE_CAP_EXCEEDED, got %v", resp)
+	}
+	status := resultOf(c.do(http.MethodGet, "/kyc", nil, http.StatusOK))
+	if policy, _ := status["policy"].(map[string]interface{}); status["level"] != domain.KYCLevelUnverified || policy["max_balance"] != float64(10000) {
+		t.Fatalf("unexpected kyc status: %v", status)
+	}
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+	fields := map[string]string{"level": domain.KYCLevelBasic, "full_name": "Alice Smith", "id_number": "3171000000000001", "date_of_birth": "1990-01-31"}
+	submit := func(files map[string][]byte, wantStatus int) map[string]interface{} {
+		body, contentType := kycForm(t, fields, files)
+		req := httptest.NewRequest(http.MethodPost, "/kyc/submissions", body)
+		req.Header.Set("Content-Type", contentType)
+		var resp map[string]interface{}
+		_ = json.Unmarshal(c.serve(req, wantStatus).Body.Bytes(), &resp)
+		return resp
+	}
+	submit(nil, http.StatusBadRequest)
+	submit(map[string][]byte{"id_card": []byte("not an image")}, http.StatusBadRequest)
+	submission := resultOf(submit(map[string][]byte{"id_card": png}, http.StatusOK))
+	if submission["status"] != domain.KYCSubmissionPending {
+		t.Fatalf("unexpected submission: %v", submission)
+	}
+	submit(map[string][]byte{"id_card": png}, http.StatusConflict)
+	submissionID := submission["submission_id"].(string)
+	documentID := submission["documents"].([]interface{})[0].(map[string]interface{})["document_id"].(string)
+
+	c.token = contractAdminToken
+	if submissions, _ := c.do(http.MethodGet, "/admin/kyc/submissions", nil, http.StatusOK)["result"].([]interface{}); len(submissions) != 1 {
+		t.Fatalf("expected 1 pending submission, got %v", submissions)
+	}
+	c.do(http.MethodGet, "/admin/kyc/submissions/"+submissionID, nil, http.StatusOK)
+	c.do(http.MethodGet, "/admin/kyc/submissions/"+uuid.NewString(), nil, http.StatusNotFound)
+	document := c.serve(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/kyc/submissions/%s/documents/%s", submissionID, documentID), nil), http.StatusOK)
+	if document.Header().Get("Content-Type") != "image/png" || !bytes.Equal(document.Body.Bytes(), png) {
+		t.Fatalf("unexpected document: %s %q", document.Header().Get("Content-Type"), document.Body.Bytes())
+	}
+	c.serve(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/admin/kyc/submissions/%s/documents/%s", submissionID, uuid.NewString()), nil), http.StatusNotFound)
+
+	approved := resultOf(c.do(http.MethodPost, "/admin/kyc/submissions/"+submissionID+"/approve", gin.H{"note": "documents match"}, http.StatusOK))
+	if approved["status"] != domain.KYCSubmissionApproved || approved["review_note"] != "documents match" {
+		t.Fatalf("unexpected approved submission: %v", approved)
+	}
+	c.do(http.MethodPost, "/admin/kyc/submissions/"+submissionID+"/reject", gin.H{}, http.StatusConflict)
+
+	c.token = userToken
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": userID, "amount": 20000}, http.StatusOK)
+	if profile := resultOf(c.do(http.MethodGet, "/profile", nil, http.StatusOK)); profile["kyc_level"] != domain.KYCLevelBasic {
+		t.Fatalf("expected BASIC level after approval, got %v", profile["kyc_level"])
+	}
+}
+
+func TestContractNotifications(t *testing.T) {
+	c := setupContract(t)
+	user := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	userID := uuid.MustParse(user["UserID"].(string))
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	c.token = tokens["access_token"].(string)
+
+	if preference := resultOf(c.do(http.MethodGet, "/notifications/preferences", nil, http.StatusOK)); preference["language"] != "id" || preference["sms"] != true || preference["email"] != false {
+		t.Fatalf("unexpected default preference: %v", preference)
+	}
+	c.do(http.MethodPut, "/notifications/preferences", gin.H{"language": "en", "sms": true, "email": true}, http.StatusBadRequest)
+	c.do(http.MethodPut, "/notifications/preferences", gin.H{"language": "fr", "sms": true, "email": false}, http.StatusBadRequest)
+	c.do(http.MethodPut, "/notifications/preferences", gin.H{"language": "en", "sms": false, "email": true, "email_address": "alice@example.com"}, http.StatusOK)
+	if preference := resultOf(c.do(http.MethodGet, "/notifications/preferences", nil, http.StatusOK)); preference["language"] != "en" || preference["sms"] != false || preference["email_address"] != "alice@example.com" {
+		t.Fatalf("unexpected saved preference: %v", preference)
+	}
+
+	event := domain.OutboxEvent{EventID: 1, AggregateType: domain.AggregateUser, AggregateID: userID, EventType: domain.EventPinChanged, OccurredAt: time.Now()}
+	if err := c.notifications.HandleEvent(context.Background(), event); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if delivered, err := c.notifications.DeliverDue(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("expected 1 delivered notification, got %d (%v)", delivered, err)
+	}
+	if len(c.messages.sent) != 1 || c.messages.sent[0].Channel != domain.ChannelEmail || c.messages.sent[0].Recipient != "alice@example.com" {
+		t.Fatalf("unexpected messages: %+v", c.messages.sent)
+	}
+	notifications := c.do(http.MethodGet, "/notifications?limit=10", nil, http.StatusOK)["result"].([]interface{})
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 notification, got %v", notifications)
+	}
+	if notification := notifications[0].(map[string]interface{}); notification["status"] != domain.DeliverySucceeded || notification["subject"] != "Your PIN was changed" {
+		t.Fatalf("unexpected notification: %v", notification)
+	}
+	c.do(http.MethodGet, "/notifications?limit=0", nil, http.StatusBadRequest)
+}
+
+func TestContractFrozenAccount(t *testing.T) {
+	c := setupContract(t)
+	aliceID := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))["UserID"].(string)
+	bobID := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Bob", "phone_number": "0822", "pin": "123456"}, http.StatusOK))["UserID"].(string)
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodPost, "/deposit", gin.H{"user_id": aliceID, "amount": 1000}, http.StatusOK)
+	c.db.Model(&domain.User{}).Where("user_id = ?", aliceID).Update("is_frozen", true)
+
+	for _, request := range []struct {
+		path string
+		body gin.H
+	}{
+		{"/deposit", gin.H{"user_id": aliceID, "amount": 100}},
+		{"/withdraw", gin.H{"user_id": aliceID, "amount": 100}},
+		{"/transfer", gin.H{"from_id": aliceID, "to_id": bobID, "amount": 100}},
+		{"/transfer", gin.H{"from_id": bobID, "to_id": aliceID, "amount": 100}},
+	} {
+		if resp := c.do(http.MethodPost, request.path, request.body, http.StatusForbidden); resp["code"] != "ACCOUNT_FROZEN" {
+			t.Fatalf("%s: expected ACCOUNT_FROZEN, got %v", request.path, resp)
+		}
+	}
+	c.do(http.MethodPut, "/activate", nil, http.StatusOK)
+	if resp := c.do(http.MethodPost, "/withdraw", gin.H{"user_id": aliceID, "amount": 100}, http.StatusForbidden); resp["code"] != "ACCOUNT_FROZEN" {
+		t.Fatalf("expected activation to keep the freeze, got %v", resp)
+	}
+
+	c.token = contractAdminToken
+	c.do(http.MethodPost, "/admin/users/"+uuid.NewString()+"/unfreeze", nil, http.StatusNotFound)
+	if user := resultOf(c.do(http.MethodPost, "/admin/users/"+aliceID+"/unfreeze", nil, http.StatusOK)); user["is_frozen"] != false {
+		t.Fatalf("expected unfrozen user, got %v", user)
+	}
+	c.token = tokens["access_token"].(string)
+	c.do(http.MethodPost, "/withdraw", gin.H{"user_id": aliceID, "amount": 100}, http.StatusOK)
+}
+
+func TestContractRefreshRejectsBlockedUser(t *testing.T) {
+	c := setupContract(t)
+	alice := resultOf(c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK))
+	tokens := resultOf(c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "123456"}, http.StatusOK))
+
+	// Screening memblokir akun setelah login; sesi tidak boleh diperpanjang.
+	c.db.Model(&domain.User{}).Where("user_id = ?", alice["UserID"]).Update("is_blocked", true)
+	c.do(http.MethodPost, "/refresh", gin.H{"refresh_token": tokens["refresh_token"]}, http.StatusUnauthorized)
+	c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "000000"}, http.StatusUnauthorized)
+}
+
+func TestContractAdminDisabled(t *testing.T) {
+	r := gin.New()
+	r.GET("/admin/audit", middleware.AdminAuthMiddleware(nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodGet, "/admin/audit", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without configured admin token, got %d", w.Code)
+	}
+}
+
+func TestContractRateLimit(t *testing.T) {
+	c := setupContract(t)
+	c.do(http.MethodPost, "/register", gin.H{"first_name": "Alice", "phone_number": "0811", "pin": "123456"}, http.StatusOK)
+
+	for i := 0; i < 5; i++ {
+		c.do(http.MethodPost, "/login", gin.H{"phone_number": "0811", "pin": "000000"}, http.StatusUnauthorized)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"phone_number":"0811","pin":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after 5 logins, got %d: %s", w.Code, w.Body.String())
+	}
+	want := map[string]string{"RateLimit-Limit": "5", "RateLimit-Remaining": "0", "RateLimit-Policy": "5;w=60"}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("expected %s %q, got %q", header, value, got)
+		}
+	}
+	// Satu token login terisi setiap 12 detik; bucket penuh kembali dalam 60 detik.
+	if retry, _ := strconv.Atoi(w.Header().Get("Retry-After")); retry < 1 || retry > 12 {
+		t.Errorf("expected Retry-After between 1 and 12, got %q", w.Header().Get("Retry-After"))
+	}
+	if reset, _ := strconv.Atoi(w.Header().Get("RateLimit-Reset")); reset < 48 || reset > 60 {
+		t.Errorf("expected RateLimit-Reset between 48 and 60, got %q", w.Header().Get("RateLimit-Reset"))
+	}
+
+	// X-Forwarded-For dari klien yang bukan trusted proxy tidak mengganti IP-nya.
+	req = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"phone_number":"0811","pin":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	w = httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected spoofed X-Forwarded-For to stay limited, got %d", w.Code)
+	}
+
+	// Limit login per IP tidak memengaruhi endpoint lain.
+	c.do(http.MethodPost, "/register", gin.H{"first_name": "Bob", "phone_number": "0822", "pin": "123456"}, http.StatusOK)
+}
+
+func TestContractRejectsInvalidRequests(t *testing.T) {
+	c := setupContract(t)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"missing required field", http.MethodPost, "/register", gin.H{"first_name": "Alice"}},
+		{"negative amount", http.MethodPost, "/transfer", gin.H{"from_id": uuid.NewString(), "to_id": uuid.NewString(), "amount": -700}},
+		{"zero amount", http.MethodPost, "/withdraw", gin.H{"user_id": uuid.NewString(), "amount": 0}},
+		{"server-controlled field", http.MethodPost, "/register", gin.H{"phone_number": "0811", "pin": "123456", "kyc_level": "FULL", "balance": 5000000}},
+		{"wrong type", http.MethodPost, "/login", gin.H{"phone_number": 811, "pin": "123456"}},
+		{"malformed json", http.MethodPost, "/refresh", `{"refresh_token":`},
+		{"missing query parameter", http.MethodGet, "/fees/preview?operation=withdraw", nil},
+		{"unknown enum value", http.MethodGet, "/statements?format=xml", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.t = t
+			resp := c.do(tt.method, tt.path, tt.body, http.StatusBadRequest)
+			if msg, _ := resp["error"].(string); !strings.Contains(msg, "has an error") {
+				t.Fatalf("expected request to be rejected by the OpenAPI validator, got %v", resp)
+			}
+		})
+	}
+}
+
+// TestContractCoversRoutes memastikan setiap route di cmd/main.go terdokumentasi
+// di spec dan sebaliknya.
+func TestContractCoversRoutes(t *testing.T) {
+	source, err := os.ReadFile("../../../cmd/main.go")
+	if err != nil {
+		t.Fatalf("failed to read main.go: %v", err)
+	}
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+
+	routeParam := regexp.MustCompile(`:(\w+)`)
+	registered := map[string]bool{}
+	for _, m := range regexp.MustCompile(`\.(GET|POST|PUT|DELETE|PATCH)\("([^"]+)"`).FindAllStringSubmatch(string(source), -1) {
+		route := m[1] + " " + routeParam.ReplaceAllString(m[2], "{$1}")
+		registered[route] = true
+		path := spec.Paths.Find(routeParam.ReplaceAllString(m[2], "{$1}"))
+		if path == nil || path.GetOperation(m[1]) == nil {
+			t.Errorf("route %s is not documented in openapi.json", route)
+		}
+	}
+	if len(registered) == 0 {
+		t.Fatal("no routes found in main.go")
+	}
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			if route := fmt.Sprintf("%s %s", strings.ToUpper(method), path); !registered[route] {
+				t.Errorf("operation %s is documented but not registered in main.go", route)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+// Batas jumlah kasus per response pada /admin/screening/hits.
+const (
+	defaultScreeningHitLimit = 50
+	maxScreeningHitLimit     = 200
+)
+
+type ScreeningHandler struct {
+	screeningService *services.ScreeningService
+}
+
+func NewScreeningHandler(screeningService *services.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{screeningService: screeningService}
+}
+
+// ListHits handler untuk endpoint /admin/screening/hits. Query status
+// (default PENDING, ALL untuk semua) dan limit (default 50, maksimal 200).
+func (h *ScreeningHandler) ListHits(c *gin.Context) {
+	status := strings.ToUpper(c.DefaultQuery("status", domain.ScreeningHitPending))
+	switch status {
+	case "ALL":
+		status = ""
+	case domain.ScreeningHitPending, domain.ScreeningHitCleared, domain.ScreeningHitConfirmed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultScreeningHitLimit)))
+	if err != nil || limit < 1 || limit > maxScreeningHitLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	hits, err := h.screeningService.ListHits(c.Request.Context(), status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": hits})
+}
+
+// GetHit handler untuk endpoint /admin/screening/hits/:hit_id
+func (h *ScreeningHandler) GetHit(c *gin.Context) {
+	hitID, err := uuid.Parse(c.Param("hit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hit id"})
+		return
+	}
+	hit, err := h.screeningService.GetHit(c.Request.Context(), hitID)
+	if err != nil {
+		respondHitError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": hit})
+}
+
+// ClearHit handler untuk endpoint /admin/screening/hits/:hit_id/clear
+func (h *ScreeningHandler) ClearHit(c *gin.Context) {
+	h.decide(c, h.screeningService.ClearHit)
+}
+
+// ConfirmHit handler untuk endpoint /admin/screening/hits/:hit_id/confirm.
+// Akun user langsung diblokir.
+func (h *ScreeningHandler) ConfirmHit(c *gin.Context) {
+	h.decide(c, h.screeningService.ConfirmHit)
+}
+
+func (h *ScreeningHandler) decide(c *gin.Context, decide func(ctx context.Context, hitID uuid.UUID, note string) (*domain.ScreeningHit, error)) {
+	hitID, err := uuid.Parse(c.Param("hit_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hit id"})
+		return
+	}
+	var request struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	hit, err := decide(c.Request.Context(), hitID, request.Note)
+	if err != nil {
+		respondHitError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": hit})
+}
+
+func respondHitError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Screening hit not found"})
+	case errors.Is(err, services.ErrHitNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
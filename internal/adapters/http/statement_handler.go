@@ -0,0 +1,77 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"hexagonal-go/internal/adapters/statement"
+	"hexagonal-go/internal/core/services"
+)
+
+type StatementHandler struct {
+	statementService services.StatementService
+}
+
+func NewStatementHandler(statementService services.StatementService) *StatementHandler {
+	return &StatementHandler{statementService: statementService}
+}
+
+// GetStatement handler untuk endpoint /statements. Query from dan to berformat
+// YYYY-MM-DD (keduanya inklusif, default bulan berjalan), format salah satu
+// dari json, csv atau pdf.
+func (h *StatementHandler) GetStatement(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var err error
+	if v := c.Query("from"); v != "" {
+		if from, err = time.ParseInLocation("2006-01-02", v, now.Location()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.ParseInLocation("2006-01-02", v, now.Location()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+	}
+
+	st, err := h.statementService.Generate(c.Request.Context(), userID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%s-%s", from.Format("20060102"), to.Format("20060102"))
+	var buf bytes.Buffer
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": st})
+		return
+	case "csv":
+		if err := statement.WriteCSV(&buf, st); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+	case "pdf":
+		if err := statement.WritePDF(&buf, st); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+		c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+	}
+}
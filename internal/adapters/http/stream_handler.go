@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/services"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+type StreamHandler struct {
+	stream *services.TransactionStream
+}
+
+func NewStreamHandler(stream *services.TransactionStream) *StreamHandler {
+	return &StreamHandler{stream: stream}
+}
+
+// Transactions handler untuk endpoint /stream/transactions (Server-Sent Events).
+// Header Last-Event-ID berisi ID transaksi terakhir yang diterima klien;
+// transaksi setelahnya dikirim ulang sebelum stream live dimulai.
+func (h *StreamHandler) Transactions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	// Subscribe sebelum replay agar tidak ada transaksi yang terlewat di antaranya.
+	sub, unsubscribe := h.stream.Subscribe(userID)
+	defer unsubscribe()
+
+	var replay []domain.StreamMessage
+	var more bool
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		id, err := uuid.Parse(lastEventID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+		if replay, more, err = h.stream.Replay(c.Request.Context(), userID, id); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// Stream berumur panjang sehingga tidak boleh terkena WriteTimeout server.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	sent := make(map[uuid.UUID]bool, len(replay))
+	for _, msg := range replay {
+		if err := writeStreamMessage(c, msg); err != nil {
+			return
+		}
+		sent[msg.Transaction.TransactionID] = true
+	}
+	c.Writer.Flush()
+	// Replay dibatasi; klien menyambung ulang dengan Last-Event-ID terakhir
+	// untuk mengambil sisanya.
+	if more {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-sub.Closed:
+			return
+		case msg := <-sub.Messages:
+			if sent[msg.Transaction.TransactionID] {
+				delete(sent, msg.Transaction.TransactionID)
+				continue
+			}
+			if err := writeStreamMessage(c, msg); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeStreamMessage(c *gin.Context, msg domain.StreamMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Writer, "id: %s\nevent: transaction\ndata: %s\n\n", msg.Transaction.TransactionID, data)
+	return err
+}
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"hexagonal-go/internal/core/services"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type TransactionHandler struct {
@@ -30,9 +33,9 @@ func (h *TransactionHandler) Deposit(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	tx, err := h.transactionService.Deposit(userID, request.Amount, request.Remarks)
+	tx, err := h.transactionService.Deposit(c.Request.Context(), userID, request.Amount, request.Remarks)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondTransactionError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": tx})
@@ -53,9 +56,9 @@ func (h *TransactionHandler) Withdraw(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	tx, err := h.transactionService.Withdraw(userID, request.Amount, request.Remarks)
+	tx, err := h.transactionService.Withdraw(c.Request.Context(), userID, request.Amount, request.Remarks)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondTransactionError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": tx})
@@ -82,9 +85,9 @@ func (h *TransactionHandler) Transfer(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to_id"})
 		return
 	}
-	debitTx, creditTx, err := h.transactionService.Transfer(fromID, toID, request.Amount, request.Remarks)
+	debitTx, creditTx, err := h.transactionService.Transfer(c.Request.Context(), fromID, toID, request.Amount, request.Remarks)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondTransactionError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": gin.H{"debit": debitTx, "credit": creditTx}})
@@ -97,10 +100,86 @@ func (h *TransactionHandler) GetTransactions(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	txs, err := h.transactionService.GetTransactionsByUser(userID)
+	txs, err := h.transactionService.GetTransactionsByUser(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": txs})
 }
+
+// PreviewFee handler untuk endpoint /fees/preview
+func (h *TransactionHandler) PreviewFee(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid amount"})
+		return
+	}
+	quote, err := h.transactionService.PreviewFee(c.Request.Context(), userID, strings.ToUpper(c.Query("operation")), amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": quote})
+}
+
+// GetLimits handler untuk endpoint /limits
+func (h *TransactionHandler) GetLimits(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	limits, err := h.transactionService.RemainingLimits(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": limits})
+}
+
+// respondTransactionError memetakan error dari TransactionService ke response.
+// Pelanggaran limit dikembalikan sebagai 422 beserta detail limitnya.
+// Transaksi yang ditahan mesin fraud dijawab 202 PENDING_REVIEW beserta
+// kasusnya, sedangkan yang diblokir mesin fraud, screening daftar pantauan,
+// atau kebijakan level KYC dijawab 403.
+func respondTransactionError(c *gin.Context, err error) {
+	var limitErr *services.LimitExceededError
+	if errors.As(err, &limitErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "code": "LIMIT_EXCEEDED", "limit": limitErr})
+		return
+	}
+	var fraudErr *services.FraudDecisionError
+	if errors.As(err, &fraudErr) {
+		if errors.Is(err, services.ErrFraudBlocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "FRAUD_BLOCKED"})
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"status": "PENDING_REVIEW", "result": fraudErr.Review})
+		return
+	}
+	switch {
+	case errors.Is(err, services.ErrAccountBlocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "ACCOUNT_BLOCKED"})
+		return
+	case errors.Is(err, services.ErrAccountFrozen):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "ACCOUNT_FROZEN"})
+		return
+	case errors.Is(err, services.ErrCounterpartyUnderReview):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "COUNTERPARTY_UNDER_REVIEW"})
+		return
+	case errors.Is(err, services.ErrAccountUnderReview):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "ACCOUNT_UNDER_REVIEW"})
+		return
+	case errors.Is(err, services.ErrKYCRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "KYC_REQUIRED"})
+		return
+	case errors.Is(err, services.ErrBalanceCapExceeded):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "BALANCE_CAP_EXCEEDED"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
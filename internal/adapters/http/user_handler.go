@@ -1,31 +1,48 @@
 package http
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
 	"hexagonal-go/internal/core/services"
 	"hexagonal-go/internal/utils"
+	"log/slog"
 	"net/http"
 )
 
 type UserHandler struct {
 	userService services.UserService
+	tokens      *utils.TokenManager
 }
 
-func NewUserHandler(userService services.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService services.UserService, tokens *utils.TokenManager) *UserHandler {
+	return &UserHandler{userService: userService, tokens: tokens}
 }
 
 // Register handler untuk endpoint /register
 func (h *UserHandler) Register(c *gin.Context) {
-	var user domain.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	var request struct {
+		FirstName   string `json:"first_name"`
+		LastName    string `json:"last_name"`
+		PhoneNumber string `json:"phone_number"`
+		Address     string `json:"address"`
+		Pin         string `json:"pin"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
-	if err := h.userService.Register(&user); err != nil {
+	user := domain.User{
+		FirstName:   request.FirstName,
+		LastName:    request.LastName,
+		PhoneNumber: request.PhoneNumber,
+		Address:     request.Address,
+		Pin:         request.Pin,
+	}
+	if err := h.userService.Register(c.Request.Context(), &user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -48,20 +65,29 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.Login(request.PhoneNumber, request.Pin)
+	user, err := h.userService.Login(c.Request.Context(), request.PhoneNumber, request.Pin)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid phone number or pin"})
 		return
 	}
 
-	// Generate JWT dan refresh token menggunakan fungsi dari utils
-	token, err := utils.GenerateJWT(user.UserID.String()) // Konversi UUID ke string
+	// Catat perangkat login; kegagalan pencatatan tidak menggagalkan login.
+	deviceKey := c.GetHeader("X-Device-ID")
+	if deviceKey == "" {
+		deviceKey = c.Request.UserAgent()
+	}
+	if _, err := h.userService.RecordLogin(c.Request.Context(), user.UserID, deviceKey, c.Request.UserAgent(), c.ClientIP()); err != nil {
+		slog.WarnContext(c.Request.Context(), "failed to record login device", "error", err)
+	}
+
+	// Generate JWT dan refresh token menggunakan TokenManager
+	token, err := h.tokens.GenerateJWT(user.UserID.String()) // Konversi UUID ke string
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	refreshToken, err := utils.GenerateRefreshToken(user.UserID.String())
+	refreshToken, err := h.tokens.GenerateRefreshToken(user.UserID.String())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
 		return
@@ -92,7 +118,7 @@ func (h *UserHandler) Profile(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	user, err := h.userService.GetByID(id)
+	user, err := h.userService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -122,7 +148,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 	user.UserID = id
-	if err := h.userService.UpdateProfile(&user); err != nil {
+	if err := h.userService.UpdateProfile(c.Request.Context(), &user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -153,7 +179,7 @@ func (h *UserHandler) ChangePin(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
-	if err := h.userService.ChangePin(id, request.OldPin, request.NewPin); err != nil {
+	if err := h.userService.ChangePin(c.Request.Context(), id, request.OldPin, request.NewPin); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -176,7 +202,7 @@ func (h *UserHandler) Deactivate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	if err := h.userService.SetActive(id, false); err != nil {
+	if err := h.userService.SetActive(c.Request.Context(), id, false); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -199,13 +225,37 @@ func (h *UserHandler) Activate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
 		return
 	}
-	if err := h.userService.SetActive(id, true); err != nil {
+	if err := h.userService.SetActive(c.Request.Context(), id, true); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS"})
 }
 
+// Unfreeze handler untuk endpoint admin /admin/users/:user_id/unfreeze.
+// Mencairkan akun yang dibekukan rekonsiliasi.
+func (h *UserHandler) Unfreeze(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return
+	}
+	if err := h.userService.Unfreeze(c.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	user, err := h.userService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "SUCCESS", "result": user})
+}
+
 // RefreshToken handler untuk endpoint /refresh
 func (h *UserHandler) RefreshToken(c *gin.Context) {
 	var request struct {
@@ -217,22 +267,39 @@ func (h *UserHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	userID, err := utils.ValidateRefreshToken(request.RefreshToken)
+	userID, err := h.tokens.ValidateRefreshToken(request.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
 		return
 	}
 
 	// Opsional: revoke refresh token lama untuk menghindari reuse
-	utils.RevokeRefreshToken(request.RefreshToken)
+	h.tokens.RevokeRefreshToken(request.RefreshToken)
+
+	// Akun yang diblokir atau dinonaktifkan setelah login tidak boleh
+	// memperpanjang sesinya
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+	switch err := h.userService.ValidateSession(c.Request.Context(), id); {
+	case err == nil:
+	case errors.Is(err, services.ErrAccountBlocked), errors.Is(err, services.ErrUserInactive), errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
 
-	token, err := utils.GenerateJWT(userID)
+	token, err := h.tokens.GenerateJWT(userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	newRefresh, err := utils.GenerateRefreshToken(userID)
+	newRefresh, err := h.tokens.GenerateRefreshToken(userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
 		return
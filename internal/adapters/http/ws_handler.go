@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"hexagonal-go/internal/core/services"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingInterval   = 25 * time.Second
+	wsMaxMessageSize = 512
+
+	// wsCloseTokenExpired adalah close code aplikasi ketika access token
+	// kedaluwarsa; klien perlu refresh token lalu menyambung ulang.
+	wsCloseTokenExpired = 4001
+)
+
+type WebSocketHandler struct {
+	hub      *services.NotificationHub
+	upgrader websocket.Upgrader
+}
+
+func NewWebSocketHandler(hub *services.NotificationHub) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub:      hub,
+		upgrader: websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024},
+	}
+}
+
+// Connect handler untuk endpoint /ws. Notifikasi dikirim sebagai pesan teks
+// JSON. Server mengirim ping secara berkala dan menutup koneksi ketika klien
+// tidak membalas, antrean notifikasinya penuh, atau access token kedaluwarsa.
+func (h *WebSocketHandler) Connect(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	expiresAt := time.Now().Add(24 * time.Hour)
+	if v, exists := c.Get("tokenExpiresAt"); exists {
+		expiresAt = v.(time.Time)
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrader sudah menulis response error.
+		return
+	}
+	defer conn.Close()
+
+	sub, unsubscribe := h.hub.Subscribe(userID)
+	defer unsubscribe()
+
+	// Pesan dari klien tidak dipakai; pembacaan tetap diperlukan untuk
+	// memproses pong dan close frame.
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		conn.SetReadLimit(wsMaxMessageSize)
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	expiry := time.NewTimer(time.Until(expiresAt))
+	defer expiry.Stop()
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-readDone:
+			return
+		case <-sub.Closed:
+			if errors.Is(sub.Err(), services.ErrHubClosed) {
+				closeWebSocket(conn, readDone, websocket.CloseGoingAway, "server shutting down")
+				return
+			}
+			closeWebSocket(conn, readDone, websocket.CloseTryAgainLater, "notification queue overflow")
+			return
+		case <-expiry.C:
+			closeWebSocket(conn, readDone, wsCloseTokenExpired, "token expired")
+			return
+		case notification := <-sub.Messages:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(notification); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeWebSocket mengirim close frame lalu menunggu balasan close dari klien
+// sebelum koneksi ditutup.
+func closeWebSocket(conn *websocket.Conn, readDone <-chan struct{}, code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait)); err != nil {
+		return
+	}
+	select {
+	case <-readDone:
+	case <-time.After(time.Second):
+	}
+}
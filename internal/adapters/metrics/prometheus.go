@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"hexagonal-go/internal/core/ports"
+	"hexagonal-go/internal/core/services"
+)
+
+// namespace adalah prefix semua metrik aplikasi.
+const namespace = "hexago"
+
+// PrometheusMetrics mengimplementasikan ports.Metrics dengan counter Prometheus.
+type PrometheusMetrics struct {
+	transactions        *prometheus.CounterVec
+	amounts             *prometheus.CounterVec
+	insufficientBalance *prometheus.CounterVec
+	loginFailures       *prometheus.CounterVec
+}
+
+var _ ports.Metrics = (*PrometheusMetrics)(nil)
+
+// NewPrometheusMetrics membuat dan mendaftarkan metrik bisnis ke reg.
+func NewPrometheusMetrics(reg prometheus.Registerer) *PrometheusMetrics {
+	m := &PrometheusMetrics{
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_total",
+			Help:      "Jumlah percobaan pergerakan dana per operasi dan hasil.",
+		}, []string{"operation", "outcome"}),
+		amounts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_amount_total",
+			Help:      "Total nominal pergerakan dana yang berhasil per operasi.",
+		}, []string{"operation"}),
+		insufficientBalance: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "insufficient_balance_rejections_total",
+			Help:      "Jumlah transaksi yang ditolak karena saldo tidak cukup.",
+		}, []string{"operation"}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_failures_total",
+			Help:      "Jumlah login yang gagal per alasan.",
+		}, []string{"reason"}),
+	}
+	reg.MustRegister(m.transactions, m.amounts, m.insufficientBalance, m.loginFailures)
+	return m
+}
+
+func (m *PrometheusMetrics) RecordTransaction(operation, outcome string, amount float64) {
+	m.transactions.WithLabelValues(operation, outcome).Inc()
+	switch outcome {
+	case services.OutcomeSuccess:
+		m.amounts.WithLabelValues(operation).Add(amount)
+	case services.OutcomeInsufficientBalance:
+		m.insufficientBalance.WithLabelValues(operation).Inc()
+	}
+}
+
+func (m *PrometheusMetrics) RecordLoginFailure(reason string) {
+	m.loginFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/services"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewPrometheusMetrics(reg)
+
+	m.RecordTransaction("DEPOSIT", services.OutcomeSuccess, 50)
+	m.RecordTransaction("DEPOSIT", services.OutcomeSuccess, 25)
+	m.RecordTransaction("WITHDRAW", services.OutcomeInsufficientBalance, 500)
+	m.RecordLoginFailure(services.LoginFailureInvalidPin)
+
+	if got := testutil.ToFloat64(m.transactions.WithLabelValues("DEPOSIT", services.OutcomeSuccess)); got != 2 {
+		t.Errorf("expected 2 successful deposits, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.amounts.WithLabelValues("DEPOSIT")); got != 75 {
+		t.Errorf("expected deposit amount 75, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.amounts.WithLabelValues("WITHDRAW")); got != 0 {
+		t.Errorf("rejected withdrawals must not add to the amount, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.insufficientBalance.WithLabelValues("WITHDRAW")); got != 1 {
+		t.Errorf("expected 1 insufficient balance rejection, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.loginFailures.WithLabelValues(services.LoginFailureInvalidPin)); got != 1 {
+		t.Errorf("expected 1 login failure, got %v", got)
+	}
+}
+
+func TestInstrumentGORM(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	reg := prometheus.NewRegistry()
+	if err := InstrumentGORM(db, reg); err != nil {
+		t.Fatalf("failed to instrument: %v", err)
+	}
+
+	type item struct {
+		ID   uint
+		Name string
+	}
+	if err := db.AutoMigrate(&item{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db.Create(&item{Name: "a"})
+	var found item
+	db.First(&found)
+	db.First(&found, 42)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather: %v", err)
+	}
+	counts := map[string]uint64{}
+	pool := false
+	for _, family := range families {
+		switch family.GetName() {
+		case "hexago_db_query_duration_seconds":
+			for _, metric := range family.GetMetric() {
+				key := ""
+				for _, label := range metric.GetLabel() {
+					key += label.GetValue() + " "
+				}
+				counts[key] = metric.GetHistogram().GetSampleCount()
+			}
+		case "go_sql_open_connections":
+			pool = true
+		}
+	}
+	if counts["create ok "] != 1 || counts["query ok "] != 2 {
+		t.Fatalf("unexpected query counts: %v", counts)
+	}
+	if !pool {
+		t.Fatal("expected connection pool stats to be registered")
+	}
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// ConsoleNotifier mencetak pesan SMS dan email ke stdout alih-alih
+// mengirimkannya, untuk pengembangan lokal.
+type ConsoleNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewConsoleNotifier() *ConsoleNotifier {
+	return &ConsoleNotifier{w: os.Stdout}
+}
+
+func (n *ConsoleNotifier) Send(ctx context.Context, message domain.NotificationMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var err error
+	if message.Subject != "" {
+		_, err = fmt.Fprintf(n.w, "[%s to %s] %s: %s\n", message.Channel, message.Recipient, message.Subject, message.Body)
+	} else {
+		_, err = fmt.Fprintf(n.w, "[%s to %s] %s\n", message.Channel, message.Recipient, message.Body)
+	}
+	return err
+}
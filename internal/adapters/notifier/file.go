@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// FileNotifier menambahkan setiap pesan sebagai satu baris JSON ke sebuah
+// file, untuk pengembangan lokal dan pengujian end-to-end.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier membuat direktori file jika belum ada.
+func NewFileNotifier(path string) (*FileNotifier, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	return &FileNotifier{path: path}, nil
+}
+
+func (n *FileNotifier) Send(ctx context.Context, message domain.NotificationMessage) error {
+	line, err := json.Marshal(struct {
+		SentAt time.Time `json:"sent_at"`
+		domain.NotificationMessage
+	}{time.Now().UTC(), message})
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestFileNotifierAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "notifications.log")
+	n, err := NewFileNotifier(path)
+	if err != nil {
+		t.Fatalf("NewFileNotifier returned error: %v", err)
+	}
+	messages := []domain.NotificationMessage{
+		{Channel: domain.ChannelSMS, Recipient: "0811", Body: "PIN diubah"},
+		{Channel: domain.ChannelEmail, Recipient: "alice@example.com", Subject: "PIN changed", Body: "Your PIN was changed"},
+	}
+	for _, message := range messages {
+		if err := n.Send(context.Background(), message); err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer f.Close()
+	var got []domain.NotificationMessage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var message domain.NotificationMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, message)
+	}
+	if len(got) != 2 || got[0] != messages[0] || got[1] != messages[1] {
+		t.Fatalf("unexpected messages: %+v", got)
+	}
+}
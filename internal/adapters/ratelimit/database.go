@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+// DatabaseStore menyimpan bucket di tabel rate_limit_buckets sehingga semua
+// replika berbagi limit yang sama. Setiap Take mengunci baris bucket-nya.
+type DatabaseStore struct {
+	db *gorm.DB
+}
+
+func NewDatabaseStore(db *gorm.DB) *DatabaseStore {
+	return &DatabaseStore{db: db}
+}
+
+func (s *DatabaseStore) Take(ctx context.Context, key string, policy domain.RateLimitPolicy, now time.Time) (domain.RateLimitDecision, error) {
+	var decision domain.RateLimitDecision
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bucket baru dibuat penuh; replika yang kalah balapan memakai baris
+		// yang sudah ada.
+		bucket := domain.RateLimitBucket{BucketKey: key, Tokens: float64(policy.Limit), RefilledAt: now, ExpiresAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&bucket).Error; err != nil {
+			return err
+		}
+		query := tx
+		if tx.Dialector.Name() == "postgres" {
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		if err := query.Take(&bucket, "bucket_key = ?", key).Error; err != nil {
+			return err
+		}
+		decision = policy.Take(&bucket, now)
+		return tx.Save(&bucket).Error
+	})
+	return decision, err
+}
+
+func (s *DatabaseStore) Purge(ctx context.Context, now time.Time) error {
+	return s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.RateLimitBucket{}).Error
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// MemoryStore menyimpan bucket di memori proses. Limit hanya berlaku per
+// replika; pakai DatabaseStore jika aplikasi berjalan di beberapa replika.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*domain.RateLimitBucket
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*domain.RateLimitBucket)}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, policy domain.RateLimitPolicy, now time.Time) (domain.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &domain.RateLimitBucket{BucketKey: key}
+		s.buckets[key] = bucket
+	}
+	return policy.Take(bucket, now), nil
+}
+
+func (s *MemoryStore) Purge(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, bucket := range s.buckets {
+		if !bucket.ExpiresAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
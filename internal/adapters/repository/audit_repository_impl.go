@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// auditLockKey adalah kunci advisory lock PostgreSQL yang menserialkan
+// penulisan audit log sehingga rantai hash tidak bercabang.
+const auditLockKey = 4_845_470_110
+
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepositoryImpl(db *gorm.DB) *AuditRepositoryImpl {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (r *AuditRepositoryImpl) LastWithTx(ctx context.Context, dbTx *gorm.DB) (*domain.AuditEntry, error) {
+	tx := dbTx.WithContext(ctx)
+	// SQLite sudah menserialkan penulis; primary key sequence tetap menolak
+	// cabang jika dua transaksi membaca entri terakhir yang sama.
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditLockKey).Error; err != nil {
+			return nil, err
+		}
+	}
+	var entry domain.AuditEntry
+	err := tx.Order("sequence DESC").Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *AuditRepositoryImpl) CreateWithTx(ctx context.Context, dbTx *gorm.DB, entry *domain.AuditEntry) error {
+	return dbTx.WithContext(ctx).Create(entry).Error
+}
+
+func (r *AuditRepositoryImpl) Find(ctx context.Context, filter domain.AuditFilter, before int64, limit int) ([]domain.AuditEntry, error) {
+	query := r.db.WithContext(ctx).Model(&domain.AuditEntry{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+	if before > 0 {
+		query = query.Where("sequence < ?", before)
+	}
+	var entries []domain.AuditEntry
+	err := query.Order("sequence DESC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+func (r *AuditRepositoryImpl) FindAfter(ctx context.Context, after int64, limit int) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+	err := r.db.WithContext(ctx).Where("sequence > ?", after).Order("sequence ASC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+type DeviceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDeviceRepositoryImpl(db *gorm.DB) *DeviceRepositoryImpl {
+	return &DeviceRepositoryImpl{db: db}
+}
+
+func (r *DeviceRepositoryImpl) FindByFingerprint(ctx context.Context, userID uuid.UUID, fingerprint string) (*domain.UserDevice, error) {
+	var device domain.UserDevice
+	err := r.db.WithContext(ctx).Where("user_id = ? AND fingerprint = ?", userID, fingerprint).First(&device).Error
+	return &device, err
+}
+
+func (r *DeviceRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.UserDevice{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *DeviceRepositoryImpl) Create(ctx context.Context, device *domain.UserDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+
+func (r *DeviceRepositoryImpl) Touch(ctx context.Context, deviceID uuid.UUID, ipAddress string, seenAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.UserDevice{}).Where("device_id = ?", deviceID).
+		Updates(map[string]interface{}{"ip_address": ipAddress, "last_seen_at": seenAt}).Error
+}
+
+func (r *DeviceRepositoryImpl) WithTx(dbTx *gorm.DB) ports.DeviceRepository {
+	return &DeviceRepositoryImpl{db: dbTx}
+}
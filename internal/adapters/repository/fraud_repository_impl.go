@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type FraudRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFraudRepositoryImpl(db *gorm.DB) *FraudRepositoryImpl {
+	return &FraudRepositoryImpl{db: db}
+}
+
+func (r *FraudRepositoryImpl) CountDebitsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, categories []string, since time.Time) (int64, error) {
+	var count int64
+	err := dbTx.WithContext(ctx).Model(&domain.Transaction{}).
+		Where("user_id = ? AND category IN ? AND transaction_type = ? AND created_at >= ?", userID, categories, domain.TransactionTypeDebit, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *FraudRepositoryImpl) SumCreditsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (float64, error) {
+	var sum float64
+	err := dbTx.WithContext(ctx).Model(&domain.Transaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND category = ? AND transaction_type = ? AND created_at >= ?", userID, category, domain.TransactionTypeCredit, since).
+		Scan(&sum).Error
+	return sum, err
+}
+
+func (r *FraudRepositoryImpl) DebitAmountsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) ([]float64, error) {
+	var amounts []float64
+	err := dbTx.WithContext(ctx).Model(&domain.Transaction{}).
+		Where("user_id = ? AND category = ? AND transaction_type = ? AND created_at >= ?", userID, category, domain.TransactionTypeDebit, since).
+		Pluck("amount", &amounts).Error
+	return amounts, err
+}
+
+func (r *FraudRepositoryImpl) HasTransferredToWithTx(ctx context.Context, dbTx *gorm.DB, userID, counterpartyID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbTx.WithContext(ctx).Model(&domain.Transaction{}).
+		Where("user_id = ? AND counterparty_id = ? AND category = ? AND transaction_type = ?", userID, counterpartyID, domain.CategoryTransfer, domain.TransactionTypeDebit).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *FraudRepositoryImpl) CreateReviewWithTx(ctx context.Context, dbTx *gorm.DB, review *domain.FraudReview) error {
+	return dbTx.WithContext(ctx).Create(review).Error
+}
+
+func (r *FraudRepositoryImpl) FindReviewForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, reviewID uuid.UUID) (*domain.FraudReview, error) {
+	query := dbTx.WithContext(ctx)
+	if query.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var review domain.FraudReview
+	if err := query.First(&review, "review_id = ?", reviewID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *FraudRepositoryImpl) UpdateReviewWithTx(ctx context.Context, dbTx *gorm.DB, review *domain.FraudReview) error {
+	return dbTx.WithContext(ctx).Save(review).Error
+}
+
+func (r *FraudRepositoryImpl) FindReviewByID(ctx context.Context, reviewID uuid.UUID) (*domain.FraudReview, error) {
+	var review domain.FraudReview
+	if err := r.db.WithContext(ctx).First(&review, "review_id = ?", reviewID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *FraudRepositoryImpl) FindReviews(ctx context.Context, status string, limit int) ([]domain.FraudReview, error) {
+	query := r.db.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	reviews := []domain.FraudReview{}
+	err := query.Order("created_at DESC").Limit(limit).Find(&reviews).Error
+	return reviews, err
+}
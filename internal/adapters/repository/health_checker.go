@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseHealthChecker memeriksa bahwa database dapat dijangkau lewat pool
+// koneksi GORM.
+type DatabaseHealthChecker struct {
+	db *gorm.DB
+}
+
+func NewDatabaseHealthChecker(db *gorm.DB) *DatabaseHealthChecker {
+	return &DatabaseHealthChecker{db: db}
+}
+
+func (c *DatabaseHealthChecker) Check(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationHealthChecker memeriksa bahwa tabel dan kolom setiap model sudah
+// ada, yaitu skema database sesuai dengan versi aplikasi yang berjalan.
+type MigrationHealthChecker struct {
+	db     *gorm.DB
+	models []interface{}
+}
+
+func NewMigrationHealthChecker(db *gorm.DB, models ...interface{}) *MigrationHealthChecker {
+	return &MigrationHealthChecker{db: db, models: models}
+}
+
+func (c *MigrationHealthChecker) Check(ctx context.Context) error {
+	db := c.db.WithContext(ctx)
+	migrator := db.Migrator()
+	var missing []string
+	for _, model := range c.models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		if !migrator.HasTable(model) {
+			missing = append(missing, stmt.Schema.Table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				missing = append(missing, stmt.Schema.Table+"."+field.DBName)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("schema not migrated: missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
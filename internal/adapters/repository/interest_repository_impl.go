@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type InterestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInterestRepositoryImpl(db *gorm.DB) *InterestRepositoryImpl {
+	return &InterestRepositoryImpl{db: db}
+}
+
+func (r *InterestRepositoryImpl) CreateAccrual(ctx context.Context, accrual *domain.InterestAccrual) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(accrual)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *InterestRepositoryImpl) FindUnposted(ctx context.Context, from, to time.Time) ([]domain.InterestAccrual, error) {
+	var accruals []domain.InterestAccrual
+	err := r.db.WithContext(ctx).Where("posted_at IS NULL AND accrual_date >= ? AND accrual_date < ?", from, to).
+		Order("user_id, accrual_date").Find(&accruals).Error
+	return accruals, err
+}
+
+func (r *InterestRepositoryImpl) MarkPostedWithTx(ctx context.Context, dbTx *gorm.DB, accrualIDs []uuid.UUID, transactionID *uuid.UUID, postedAt time.Time) (bool, error) {
+	result := dbTx.WithContext(ctx).Model(&domain.InterestAccrual{}).Where("accrual_id IN ? AND posted_at IS NULL", accrualIDs).
+		Updates(map[string]interface{}{"posted_at": postedAt, "transaction_id": transactionID})
+	return result.RowsAffected == int64(len(accrualIDs)), result.Error
+}
+
+func (r *InterestRepositoryImpl) AccruedTotal(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).Model(&domain.InterestAccrual{}).Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND posted_at IS NULL", userID).Scan(&total).Error
+	return total, err
+}
+
+func (r *InterestRepositoryImpl) LastAccrualDate(ctx context.Context) (*time.Time, error) {
+	var accrual domain.InterestAccrual
+	err := r.db.WithContext(ctx).Order("accrual_date DESC").First(&accrual).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &accrual.AccrualDate, nil
+}
+
+func (r *InterestRepositoryImpl) AdvanceCheckpoint(ctx context.Context, userID uuid.UUID, day time.Time) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"accrued_through", "updated_at"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "interest_checkpoints.accrued_through < excluded.accrued_through"}}},
+	}).Create(&domain.InterestCheckpoint{UserID: userID, AccruedThrough: day}).Error
+}
+
+func (r *InterestRepositoryImpl) Checkpoints(ctx context.Context) (map[uuid.UUID]time.Time, error) {
+	var checkpoints []domain.InterestCheckpoint
+	if err := r.db.WithContext(ctx).Find(&checkpoints).Error; err != nil {
+		return nil, err
+	}
+	days := make(map[uuid.UUID]time.Time, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		days[checkpoint.UserID] = checkpoint.AccruedThrough
+	}
+	return days, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AdvisoryJobLock adalah ports.JobLock berbasis advisory lock Postgres.
+// Lock dipegang oleh satu koneksi selama fn berjalan dan otomatis lepas jika
+// koneksi tersebut putus. Di database selain Postgres fn langsung dijalankan
+// karena database tersebut hanya dipakai oleh satu instance.
+type AdvisoryJobLock struct {
+	db *gorm.DB
+}
+
+func NewAdvisoryJobLock(db *gorm.DB) *AdvisoryJobLock {
+	return &AdvisoryJobLock{db: db}
+}
+
+func (l *AdvisoryJobLock) TryRun(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	if l.db.Dialector.Name() != "postgres" {
+		return true, fn(ctx)
+	}
+	var acquired bool
+	err := l.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(hashtext(?))", name).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		// unlock tetap dijalankan walau ctx dibatalkan agar koneksi tidak
+		// kembali ke pool dengan lock yang masih dipegang
+		defer conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(hashtext(?))", name)
+		return fn(ctx)
+	})
+	return acquired, err
+}
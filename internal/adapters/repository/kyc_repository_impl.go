@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type KYCRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewKYCRepositoryImpl(db *gorm.DB) *KYCRepositoryImpl {
+	return &KYCRepositoryImpl{db: db}
+}
+
+func (r *KYCRepositoryImpl) CreateSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, submission *domain.KYCSubmission) error {
+	return dbTx.WithContext(ctx).Create(submission).Error
+}
+
+func (r *KYCRepositoryImpl) HasPendingSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbTx.WithContext(ctx).Model(&domain.KYCSubmission{}).
+		Where("user_id = ? AND status = ?", userID, domain.KYCSubmissionPending).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *KYCRepositoryImpl) FindSubmissionForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, submissionID uuid.UUID) (*domain.KYCSubmission, error) {
+	query := dbTx.WithContext(ctx)
+	if query.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var submission domain.KYCSubmission
+	if err := query.First(&submission, "submission_id = ?", submissionID).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *KYCRepositoryImpl) UpdateSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, submission *domain.KYCSubmission) error {
+	return dbTx.WithContext(ctx).Omit(clause.Associations).Save(submission).Error
+}
+
+func (r *KYCRepositoryImpl) FindSubmissionByID(ctx context.Context, submissionID uuid.UUID) (*domain.KYCSubmission, error) {
+	var submission domain.KYCSubmission
+	if err := r.db.WithContext(ctx).Preload("Documents").First(&submission, "submission_id = ?", submissionID).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *KYCRepositoryImpl) FindSubmissions(ctx context.Context, status string, limit int) ([]domain.KYCSubmission, error) {
+	query := r.db.WithContext(ctx).Preload("Documents")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	submissions := []domain.KYCSubmission{}
+	err := query.Order("created_at ASC").Limit(limit).Find(&submissions).Error
+	return submissions, err
+}
+
+func (r *KYCRepositoryImpl) FindSubmissionsByUser(ctx context.Context, userID uuid.UUID) ([]domain.KYCSubmission, error) {
+	submissions := []domain.KYCSubmission{}
+	err := r.db.WithContext(ctx).Preload("Documents").Where("user_id = ?", userID).
+		Order("created_at DESC").Find(&submissions).Error
+	return submissions, err
+}
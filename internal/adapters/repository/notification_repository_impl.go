@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepositoryImpl(db *gorm.DB) *NotificationRepositoryImpl {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (r *NotificationRepositoryImpl) FindPreference(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreference, error) {
+	var preference domain.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	return &preference, err
+}
+
+func (r *NotificationRepositoryImpl) SavePreference(ctx context.Context, preference *domain.NotificationPreference) error {
+	return r.db.WithContext(ctx).Save(preference).Error
+}
+
+func (r *NotificationRepositoryImpl) CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error
+}
+
+// ClaimDueDeliveries mengunci baris dengan FOR UPDATE SKIP LOCKED di
+// PostgreSQL, sehingga baris yang sedang diklaim replika lain dilewati.
+func (r *NotificationRepositoryImpl) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.NotificationDelivery, error) {
+	var deliveries []domain.NotificationDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx
+		if tx.Dialector.Name() == "postgres" {
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+			Order("next_attempt_at ASC").Limit(limit).Find(&deliveries).Error; err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].DeliveryID
+			deliveries[i].NextAttemptAt = now.Add(lease)
+		}
+		return tx.Model(&domain.NotificationDelivery{}).Where("delivery_id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+func (r *NotificationRepositoryImpl) FindDeliveriesByUser(ctx context.Context, userID uuid.UUID, limit int) ([]domain.NotificationDelivery, error) {
+	var deliveries []domain.NotificationDelivery
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *NotificationRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	return r.db.WithContext(ctx).Save(delivery).Error
+}
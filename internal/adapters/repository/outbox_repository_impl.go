@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type OutboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepositoryImpl(db *gorm.DB) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+func (r *OutboxRepositoryImpl) CreateWithTx(ctx context.Context, dbTx *gorm.DB, event *domain.OutboxEvent) error {
+	return dbTx.WithContext(ctx).Create(event).Error
+}
+
+func (r *OutboxRepositoryImpl) FindUnpublished(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := r.db.WithContext(ctx).Where("published_at IS NULL").Order("event_id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *OutboxRepositoryImpl) MarkPublished(ctx context.Context, eventID uint64, publishedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.OutboxEvent{}).Where("event_id = ?", eventID).
+		Updates(map[string]interface{}{"published_at": publishedAt, "attempts": gorm.Expr("attempts + 1"), "last_error": ""}).Error
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(ctx context.Context, eventID uint64, reason string) error {
+	return r.db.WithContext(ctx).Model(&domain.OutboxEvent{}).Where("event_id = ?", eventID).
+		Updates(map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": reason}).Error
+}
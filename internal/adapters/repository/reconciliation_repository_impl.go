@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+type ReconciliationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepositoryImpl(db *gorm.DB) *ReconciliationRepositoryImpl {
+	return &ReconciliationRepositoryImpl{db: db}
+}
+
+func (r *ReconciliationRepositoryImpl) FindUsers(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.WithContext(ctx).Order("created_at ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *ReconciliationRepositoryImpl) FindUserTransactions(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *ReconciliationRepositoryImpl) FindOrphanedTransactions(ctx context.Context) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.WithContext(ctx).Where("NOT EXISTS (SELECT 1 FROM users WHERE users.user_id = transactions.user_id)").
+		Order("created_at ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *ReconciliationRepositoryImpl) WithTx(dbTx *gorm.DB) ports.ReconciliationRepository {
+	return &ReconciliationRepositoryImpl{db: dbTx}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type ScreeningRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewScreeningRepositoryImpl(db *gorm.DB) *ScreeningRepositoryImpl {
+	return &ScreeningRepositoryImpl{db: db}
+}
+
+func (r *ScreeningRepositoryImpl) CreateHitWithTx(ctx context.Context, dbTx *gorm.DB, hit *domain.ScreeningHit) (bool, error) {
+	result := dbTx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(hit)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ScreeningRepositoryImpl) HasPendingHitWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, minScore float64) (bool, error) {
+	var count int64
+	err := dbTx.WithContext(ctx).Model(&domain.ScreeningHit{}).
+		Where("user_id = ? AND status = ? AND score >= ?", userID, domain.ScreeningHitPending, minScore).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ScreeningRepositoryImpl) FindHitForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, hitID uuid.UUID) (*domain.ScreeningHit, error) {
+	query := dbTx.WithContext(ctx)
+	if query.Dialector.Name() == "postgres" {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var hit domain.ScreeningHit
+	if err := query.First(&hit, "hit_id = ?", hitID).Error; err != nil {
+		return nil, err
+	}
+	return &hit, nil
+}
+
+func (r *ScreeningRepositoryImpl) UpdateHitWithTx(ctx context.Context, dbTx *gorm.DB, hit *domain.ScreeningHit) error {
+	return dbTx.WithContext(ctx).Save(hit).Error
+}
+
+func (r *ScreeningRepositoryImpl) FindHitByID(ctx context.Context, hitID uuid.UUID) (*domain.ScreeningHit, error) {
+	var hit domain.ScreeningHit
+	if err := r.db.WithContext(ctx).First(&hit, "hit_id = ?", hitID).Error; err != nil {
+		return nil, err
+	}
+	return &hit, nil
+}
+
+func (r *ScreeningRepositoryImpl) FindHits(ctx context.Context, status string, limit int) ([]domain.ScreeningHit, error) {
+	query := r.db.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	hits := []domain.ScreeningHit{}
+	err := query.Order("created_at DESC").Limit(limit).Find(&hits).Error
+	return hits, err
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
@@ -15,16 +18,73 @@ func NewTransactionRepositoryImpl(db *gorm.DB) *TransactionRepositoryImpl {
 	return &TransactionRepositoryImpl{db: db}
 }
 
-func (r *TransactionRepositoryImpl) Create(tx *domain.Transaction) error {
-	return r.db.Create(tx).Error
+func (r *TransactionRepositoryImpl) Create(ctx context.Context, tx *domain.Transaction) error {
+	return r.db.WithContext(ctx).Create(tx).Error
+}
+
+func (r *TransactionRepositoryImpl) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
+	return dbTx.WithContext(ctx).Create(tx).Error
+}
+
+func (r *TransactionRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	err := r.db.WithContext(ctx).Where("transaction_id = ?", id).First(&transaction).Error
+	return &transaction, err
 }
 
-func (r *TransactionRepositoryImpl) CreateWithTx(dbTx *gorm.DB, tx *domain.Transaction) error {
-	return dbTx.Create(tx).Error
+func (r *TransactionRepositoryImpl) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&transactions).Error
+	return transactions, err
 }
 
-func (r *TransactionRepositoryImpl) FindByUser(userID uuid.UUID) ([]domain.Transaction, error) {
+func (r *TransactionRepositoryImpl) FindByUserBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.Transaction, error) {
 	var transactions []domain.Transaction
-	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&transactions).Error
+	err := r.db.WithContext(ctx).Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).Order("created_at ASC, transaction_id ASC").Find(&transactions).Error
 	return transactions, err
 }
+
+func (r *TransactionRepositoryImpl) FindLastBefore(ctx context.Context, userID uuid.UUID, before time.Time) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	err := r.db.WithContext(ctx).Where("user_id = ? AND created_at < ?", userID, before).Order("created_at DESC").First(&transaction).Error
+	return &transaction, err
+}
+
+func (r *TransactionRepositoryImpl) FindPage(ctx context.Context, userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if filter.TransactionType != "" {
+		query = query.Where("transaction_type = ?", filter.TransactionType)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if after != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND transaction_id < ?)", after.CreatedAt, after.CreatedAt, after.TransactionID)
+	}
+	var transactions []domain.Transaction
+	err := query.Order("created_at DESC, transaction_id DESC").Limit(limit).Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *TransactionRepositoryImpl) FindAfter(ctx context.Context, userID uuid.UUID, after domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND (created_at > ? OR (created_at = ? AND transaction_id > ?))", userID, after.CreatedAt, after.CreatedAt, after.TransactionID).
+		Order("created_at ASC, transaction_id ASC").Limit(limit).Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *TransactionRepositoryImpl) UsageSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (domain.LimitUsage, error) {
+	var usage domain.LimitUsage
+	err := dbTx.WithContext(ctx).Model(&domain.Transaction{}).
+		Select("COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count").
+		Where("user_id = ? AND category = ? AND transaction_type = ? AND created_at >= ?", userID, category, domain.TransactionTypeDebit, since).
+		Scan(&usage).Error
+	return usage, err
+}
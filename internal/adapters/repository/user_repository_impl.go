@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
 )
 
 type UserRepositoryImpl struct {
@@ -20,30 +22,48 @@ func NewUserRepositoryImpl(db *gorm.DB) *UserRepositoryImpl {
 //	return &UserRepositoryImpl{db: db}
 //}
 
-func (r *UserRepositoryImpl) Create(user *domain.User) error {
-	return r.db.Create(user).Error
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
-func (r *UserRepositoryImpl) FindByPhoneNumber(phoneNumber string) (*domain.User, error) {
+func (r *UserRepositoryImpl) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.Where("phone_number = ?", phoneNumber).First(&user).Error
+	err := r.db.WithContext(ctx).Where("phone_number = ?", phoneNumber).First(&user).Error
 	return &user, err
 }
 
-func (r *UserRepositoryImpl) FindByID(id uuid.UUID) (*domain.User, error) {
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
-	err := r.db.Where("user_id = ?", id).First(&user).Error
+	err := r.db.WithContext(ctx).Where("user_id = ?", id).First(&user).Error
 	return &user, err
 }
 
-func (r *UserRepositoryImpl) Update(user *domain.User) error {
-	return r.db.Save(user).Error
+func (r *UserRepositoryImpl) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.WithContext(ctx).Where("user_id IN ?", ids).Find(&users).Error
+	return users, err
 }
 
-func (r *UserRepositoryImpl) UpdatePin(userID uuid.UUID, hashedPin string) error {
-	return r.db.Model(&domain.User{}).Where("user_id = ?", userID).Update("pin", hashedPin).Error
+// Update hanya menyimpan kolom profil. Saldo dan status akun diubah oleh
+// alur yang mengunci baris user, sehingga salinan lama tidak boleh menimpanya.
+func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
+	return r.db.WithContext(ctx).Model(user).
+		Select("first_name", "last_name", "phone_number", "address").
+		Updates(user).Error
 }
 
-func (r *UserRepositoryImpl) SetActive(userID uuid.UUID, active bool) error {
-	return r.db.Model(&domain.User{}).Where("user_id = ?", userID).Update("is_active", active).Error
+func (r *UserRepositoryImpl) UpdatePin(ctx context.Context, userID uuid.UUID, hashedPin string) error {
+	return r.db.WithContext(ctx).Model(&domain.User{}).Where("user_id = ?", userID).Update("pin", hashedPin).Error
+}
+
+func (r *UserRepositoryImpl) SetActive(ctx context.Context, userID uuid.UUID, active bool) error {
+	return r.db.WithContext(ctx).Model(&domain.User{}).Where("user_id = ?", userID).Update("is_active", active).Error
+}
+
+func (r *UserRepositoryImpl) SetFrozen(ctx context.Context, userID uuid.UUID, frozen bool) error {
+	return r.db.WithContext(ctx).Model(&domain.User{}).Where("user_id = ?", userID).Update("is_frozen", frozen).Error
+}
+
+func (r *UserRepositoryImpl) WithTx(dbTx *gorm.DB) ports.UserRepository {
+	return &UserRepositoryImpl{db: dbTx}
 }
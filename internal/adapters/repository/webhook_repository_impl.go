@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type WebhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepositoryImpl(db *gorm.DB) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{db: db}
+}
+
+func (r *WebhookRepositoryImpl) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(sub).Error
+}
+
+func (r *WebhookRepositoryImpl) FindSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("subscription_id = ?", id).First(&sub).Error
+	return &sub, err
+}
+
+func (r *WebhookRepositoryImpl) FindSubscriptionsByOwner(ctx context.Context, ownerID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	var subs []domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("owner_id = ?", ownerID).Order("created_at ASC").Find(&subs).Error
+	return subs, err
+}
+
+func (r *WebhookRepositoryImpl) FindActiveSubscriptionsByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.WebhookSubscription, error) {
+	var subs []domain.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("owner_id IN ? AND is_active = ?", ownerIDs, true).Find(&subs).Error
+	return subs, err
+}
+
+func (r *WebhookRepositoryImpl) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("subscription_id = ?", id).Delete(&domain.WebhookSubscription{}).Error
+}
+
+func (r *WebhookRepositoryImpl) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) FindDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("delivery_id = ?", id).First(&delivery).Error
+	return &delivery, err
+}
+
+func (r *WebhookRepositoryImpl) FindDeliveriesBySubscription(ctx context.Context, subscriptionID uuid.UUID) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID).Order("created_at DESC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries mengunci baris dengan FOR UPDATE SKIP LOCKED di
+// PostgreSQL, sehingga baris yang sedang diklaim replika lain dilewati.
+func (r *WebhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx
+		if tx.Dialector.Name() == "postgres" {
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+			Order("next_attempt_at ASC").Limit(limit).Find(&deliveries).Error; err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].DeliveryID
+			deliveries[i].NextAttemptAt = now.Add(lease)
+		}
+		return tx.Model(&domain.WebhookDelivery{}).Where("delivery_id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return deliveries, err
+}
+
+func (r *WebhookRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Save(delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error {
+	return r.db.WithContext(ctx).Create(attempt).Error
+}
+
+func (r *WebhookRepositoryImpl) FindAttempts(ctx context.Context, deliveryID uuid.UUID) ([]domain.WebhookAttempt, error) {
+	var attempts []domain.WebhookAttempt
+	err := r.db.WithContext(ctx).Where("delivery_id = ?", deliveryID).Order("attempted_at ASC").Find(&attempts).Error
+	return attempts, err
+}
@@ -0,0 +1,61 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// WriteCSV menulis rekening koran dalam format CSV. Saldo awal, total dan
+// saldo akhir ditulis sebagai baris tersendiri di awal dan akhir tabel.
+// Remarks ditulis pengirim transfer, sehingga setiap sel di-escape agar tidak
+// dijalankan sebagai formula oleh aplikasi spreadsheet.
+func WriteCSV(w io.Writer, st *domain.Statement) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{
+		{"date", "transaction_id", "type", "category", "remarks", "amount", "running_balance"},
+		{formatDate(st.From), "", "", "", "Opening balance", "", formatAmount(st.OpeningBalance)},
+	}
+	for _, line := range st.Lines {
+		rows = append(rows, []string{
+			line.Date.Format("2006-01-02 15:04:05"),
+			line.TransactionID.String(),
+			line.TransactionType,
+			line.Category,
+			line.Remarks,
+			formatAmount(line.Amount),
+			formatAmount(line.RunningBalance),
+		})
+	}
+	lastDay := formatDate(periodEnd(st))
+	rows = append(rows,
+		[]string{lastDay, "", "", "", "Total credits", formatAmount(st.TotalCredits), ""},
+		[]string{lastDay, "", "", "", "Total debits", formatAmount(st.TotalDebits), ""},
+		[]string{lastDay, "", "", "", "Closing balance", "", formatAmount(st.ClosingBalance)},
+	)
+	for _, row := range rows {
+		for i := range row {
+			row[i] = escapeFormula(row[i])
+		}
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// escapeFormula memberi awalan ' pada sel yang diawali karakter pemicu
+// formula spreadsheet (CSV injection).
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
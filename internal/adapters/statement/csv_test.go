@@ -0,0 +1,114 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func testStatement() *domain.Statement {
+	return &domain.Statement{
+		UserID:         uuid.MustParse("6f1c2a3b-4d5e-4f60-8a7b-9c0d1e2f3a4b"),
+		From:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 1000,
+		ClosingBalance: 1245.5,
+		TotalCredits:   500.5,
+		TotalDebits:    255,
+		Lines: []domain.StatementLine{
+			{
+				TransactionID:   uuid.MustParse("11111111-1111-4111-8111-111111111111"),
+				Date:            time.Date(2026, 1, 5, 9, 30, 0, 0, time.UTC),
+				TransactionType: domain.TransactionTypeCredit,
+				Category:        domain.CategoryDeposit,
+				Remarks:         "salary",
+				Amount:          500.5,
+				RunningBalance:  1500.5,
+			},
+			{
+				TransactionID:   uuid.MustParse("22222222-2222-4222-8222-222222222222"),
+				Date:            time.Date(2026, 1, 20, 14, 0, 0, 0, time.UTC),
+				TransactionType: domain.TransactionTypeDebit,
+				Category:        domain.CategoryWithdraw,
+				Remarks:         "rent, january",
+				Amount:          255,
+				RunningBalance:  1245.5,
+			},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testStatement()); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv: %v", err)
+	}
+
+	expected := [][]string{
+		{"date", "transaction_id", "type", "category", "remarks", "amount", "running_balance"},
+		{"2026-01-01", "", "", "", "Opening balance", "", "1000.00"},
+		{"2026-01-05 09:30:00", "11111111-1111-4111-8111-111111111111", "CREDIT", "DEPOSIT", "salary", "500.50", "1500.50"},
+		{"2026-01-20 14:00:00", "22222222-2222-4222-8222-222222222222", "DEBIT", "WITHDRAW", "rent, january", "255.00", "1245.50"},
+		{"2026-01-31", "", "", "", "Total credits", "500.50", ""},
+		{"2026-01-31", "", "", "", "Total debits", "255.00", ""},
+		{"2026-01-31", "", "", "", "Closing balance", "", "1245.50"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("unexpected csv rows:\n got %q\nwant %q", rows, expected)
+	}
+}
+
+func TestWriteCSVEmptyPeriod(t *testing.T) {
+	st := &domain.Statement{
+		From:           time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 75,
+		ClosingBalance: 75,
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, st); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv: %v", err)
+	}
+	if len(rows) != 5 || rows[1][6] != "75.00" || rows[4][0] != "2026-03-01" || rows[4][6] != "75.00" {
+		t.Fatalf("unexpected csv rows for an empty period: %q", rows)
+	}
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	st := testStatement()
+	for i, remarks := range []string{"=HYPERLINK(\"https://evil.example\",\"refund\")", "@SUM(A1)"} {
+		st.Lines[i].Remarks = remarks
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, st); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv: %v", err)
+	}
+	if rows[2][4] != "'=HYPERLINK(\"https://evil.example\",\"refund\")" || rows[3][4] != "'@SUM(A1)" {
+		t.Fatalf("expected formulas to be escaped, got %q and %q", rows[2][4], rows[3][4])
+	}
+	for _, value := range []string{"+1", "-1", "\tx", "\rx"} {
+		if got := escapeFormula(value); got != "'"+value {
+			t.Errorf("escapeFormula(%q) = %q", value, got)
+		}
+	}
+	if got := escapeFormula("salary"); got != "salary" {
+		t.Errorf("escapeFormula(%q) = %q", "salary", got)
+	}
+}
@@ -0,0 +1,123 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+const (
+	pdfLinesPerPage = 60
+	pdfLineWidth    = 95
+)
+
+// WritePDF menulis rekening koran sebagai dokumen PDF sederhana (A4, font
+// Courier) tanpa dependensi eksternal.
+func WritePDF(w io.Writer, st *domain.Statement) error {
+	lines := statementText(st)
+	var pages [][]string
+	for len(lines) > 0 {
+		n := min(pdfLinesPerPage, len(lines))
+		pages = append(pages, lines[:n])
+		lines = lines[n:]
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		var content strings.Builder
+		content.WriteString("BT /F1 9 Tf 12 TL 40 800 Td\n")
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDF(line))
+		}
+		fmt.Fprintf(&content, "ET\nBT /F1 8 Tf 40 30 Td (Page %d of %d) Tj ET", i+1, len(pages))
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func statementText(st *domain.Statement) []string {
+	lines := []string{
+		"ACCOUNT STATEMENT",
+		"",
+		"User ID : " + st.UserID.String(),
+		fmt.Sprintf("Period  : %s - %s", formatDate(st.From), formatDate(periodEnd(st))),
+		"",
+		fmt.Sprintf("%-16s %-6s %-8s %14s %14s  %s", "Date", "Type", "Category", "Amount", "Balance", "Remarks"),
+		strings.Repeat("-", pdfLineWidth),
+		fmt.Sprintf("%-16s %-6s %-8s %14s %14s  %s", formatDate(st.From), "", "", "", formatAmount(st.OpeningBalance), "Opening balance"),
+	}
+	for _, line := range st.Lines {
+		row := fmt.Sprintf("%-16s %-6s %-8s %14s %14s  %s",
+			line.Date.Format("2006-01-02 15:04"),
+			line.TransactionType,
+			line.Category,
+			formatAmount(line.Amount),
+			formatAmount(line.RunningBalance),
+			line.Remarks)
+		if len(row) > pdfLineWidth {
+			row = row[:pdfLineWidth]
+		}
+		lines = append(lines, row)
+	}
+	lines = append(lines,
+		strings.Repeat("-", pdfLineWidth),
+		fmt.Sprintf("Total credits   : %s", formatAmount(st.TotalCredits)),
+		fmt.Sprintf("Total debits    : %s", formatAmount(st.TotalDebits)),
+		fmt.Sprintf("Closing balance : %s", formatAmount(st.ClosingBalance)),
+	)
+	return lines
+}
+
+// escapePDF meng-escape karakter khusus string PDF dan mengganti karakter non-ASCII.
+func escapePDF(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// periodEnd mengembalikan tanggal terakhir yang tercakup, karena To bersifat eksklusif.
+func periodEnd(st *domain.Statement) time.Time {
+	return st.To.Add(-time.Nanosecond)
+}
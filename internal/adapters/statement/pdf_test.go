@@ -0,0 +1,102 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestWritePDF(t *testing.T) {
+	st := testStatement()
+	st.Lines[1].Remarks = "rent (january)"
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, st); err != nil {
+		t.Fatalf("WritePDF returned error: %v", err)
+	}
+	doc := buf.String()
+
+	if !strings.HasPrefix(doc, "%PDF-1.4\n") || !strings.HasSuffix(doc, "%%EOF\n") {
+		t.Fatalf("missing pdf header or trailer")
+	}
+	for _, want := range []string{
+		"(ACCOUNT STATEMENT) Tj",
+		"(User ID : 6f1c2a3b-4d5e-4f60-8a7b-9c0d1e2f3a4b) Tj",
+		"(Period  : 2026-01-01 - 2026-01-31) Tj",
+		"Opening balance) Tj",
+		"1000.00  Opening balance",
+		"2026-01-05 09:30 CREDIT DEPOSIT          500.50        1500.50  salary",
+		`rent \(january\)) Tj`,
+		"(Total credits   : 500.50) Tj",
+		"(Total debits    : 255.00) Tj",
+		"(Closing balance : 1245.50) Tj",
+		"(Page 1 of 1) Tj",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected pdf to contain %q", want)
+		}
+	}
+	checkXref(t, doc)
+}
+
+func TestWritePDFPaginates(t *testing.T) {
+	st := testStatement()
+	st.Lines = nil
+	for i := 0; i < 2*pdfLinesPerPage; i++ {
+		st.Lines = append(st.Lines, domain.StatementLine{
+			TransactionID:   uuid.New(),
+			Date:            st.From.Add(time.Duration(i) * time.Hour),
+			TransactionType: domain.TransactionTypeCredit,
+			Category:        domain.CategoryDeposit,
+			Remarks:         strings.Repeat("x", 2*pdfLineWidth),
+			Amount:          1,
+			RunningBalance:  st.OpeningBalance + float64(i+1),
+		})
+	}
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, st); err != nil {
+		t.Fatalf("WritePDF returned error: %v", err)
+	}
+	doc := buf.String()
+
+	// 8 baris header, 120 transaksi, dan 4 baris penutup menjadi 3 halaman
+	if !strings.Contains(doc, "/Count 3 >>") || !strings.Contains(doc, "(Page 3 of 3) Tj") {
+		t.Fatalf("expected 3 pages")
+	}
+	for _, match := range regexp.MustCompile(`\((.*)\) Tj T\*`).FindAllStringSubmatch(doc, -1) {
+		if len(match[1]) > pdfLineWidth {
+			t.Fatalf("expected lines to be truncated to %d characters, got %d", pdfLineWidth, len(match[1]))
+		}
+	}
+	checkXref(t, doc)
+}
+
+// checkXref memastikan startxref dan setiap offset di tabel xref menunjuk ke
+// awal objek yang benar.
+func checkXref(t *testing.T, doc string) {
+	t.Helper()
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(doc)
+	if match == nil {
+		t.Fatalf("missing startxref")
+	}
+	xref, _ := strconv.Atoi(match[1])
+	if !strings.HasPrefix(doc[xref:], "xref\n") {
+		t.Fatalf("startxref does not point to the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(doc[xref:], -1)
+	if len(entries) == 0 {
+		t.Fatalf("empty xref table")
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(doc[offset:], want) {
+			t.Fatalf("xref entry %d does not point to %q", i+1, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey menyimpan span query di gorm.Statement.
+const spanKey = "tracing:span"
+
+var tracer = otel.Tracer("hexagonal-go/internal/adapters/tracing")
+
+// InstrumentGORM memasang callback yang membuat span untuk setiap query GORM
+// sebagai anak dari span di context statement (db.WithContext). SQL dicatat
+// dengan placeholder, tanpa nilai parameter.
+func InstrumentGORM(db *gorm.DB) error {
+	start := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			_, span := tracer.Start(tx.Statement.Context, "gorm."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attribute.String("db.system", tx.Dialector.Name()), attribute.String("db.operation", operation)))
+			tx.InstanceSet(spanKey, span)
+		}
+	}
+	end := func(tx *gorm.DB) {
+		v, ok := tx.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		if tx.Statement.Table != "" {
+			span.SetAttributes(attribute.String("db.sql.table", tx.Statement.Table))
+		}
+		span.SetAttributes(
+			attribute.String("db.statement", tx.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+		)
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(codes.Error, tx.Error.Error())
+		}
+		span.End()
+	}
+
+	cb := db.Callback()
+	register := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range register {
+		if err := r.before("tracing:before_"+r.operation, start(r.operation)); err != nil {
+			return err
+		}
+		if err := r.after("tracing:after_"+r.operation, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestInstrumentGORM(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	if err := InstrumentGORM(db); err != nil {
+		t.Fatalf("failed to instrument: %v", err)
+	}
+
+	type item struct {
+		ID   uint
+		Name string
+	}
+	if err := db.AutoMigrate(&item{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	db.WithContext(ctx).Create(&item{Name: "a"})
+	var found item
+	db.WithContext(ctx).First(&found, 42)
+	db.WithContext(ctx).Exec("INSERT INTO missing_table (id) VALUES (1)")
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+			spans[span.Name()] = span
+		}
+	}
+	for _, name := range []string{"gorm.create", "gorm.query", "gorm.raw"} {
+		if _, ok := spans[name]; !ok {
+			t.Fatalf("expected child span %s, got %v", name, spans)
+		}
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans["gorm.create"].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["db.system"].AsString() != "sqlite" || attrs["db.sql.table"].AsString() != "items" || attrs["db.rows_affected"].AsInt64() != 1 {
+		t.Errorf("unexpected create span attributes: %v", attrs)
+	}
+	if status := spans["gorm.query"].Status().Code; status == codes.Error {
+		t.Error("record not found must not mark the span as failed")
+	}
+	if status := spans["gorm.raw"].Status().Code; status != codes.Error {
+		t.Errorf("expected failed raw statement to mark span as error, got %v", status)
+	}
+}
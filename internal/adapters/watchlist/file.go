@@ -0,0 +1,153 @@
+package watchlist
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// FileSource memuat daftar pantauan dari file ekspor lokal. File dibaca ulang
+// setiap Load dipanggil sehingga ekspor baru cukup ditimpa di tempat.
+type FileSource struct {
+	sources []domain.WatchlistSource
+}
+
+func NewFileSource(sources []domain.WatchlistSource) *FileSource {
+	return &FileSource{sources: sources}
+}
+
+func (s *FileSource) Load(ctx context.Context) ([]domain.WatchlistEntry, error) {
+	var entries []domain.WatchlistEntry
+	for _, source := range s.sources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		loaded, err := loadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist %q: %w", source.Name, err)
+		}
+		entries = append(entries, loaded...)
+	}
+	return entries, nil
+}
+
+func loadFile(source domain.WatchlistSource) ([]domain.WatchlistEntry, error) {
+	f, err := os.Open(source.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []domain.WatchlistEntry
+	switch source.Format {
+	case domain.WatchlistFormatCSV:
+		entries, err = ReadCSV(f)
+	case domain.WatchlistFormatSDNXML:
+		entries, err = ReadSDNXML(f)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", source.Format)
+	}
+	for i := range entries {
+		entries[i].List = source.Name
+	}
+	return entries, err
+}
+
+// ReadCSV membaca daftar pantauan CSV dengan baris header. Kolom id wajib,
+// beserta name atau first_name dan last_name. Kolom aliases (dipisahkan ";")
+// dan program opsional; kolom lain diabaikan.
+func ReadCSV(r io.Reader) ([]domain.WatchlistEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	_, hasName := columns["name"]
+	_, hasLast := columns["last_name"]
+	if _, ok := columns["id"]; !ok || (!hasName && !hasLast) {
+		return nil, errors.New("header must contain id and name or first_name/last_name")
+	}
+
+	var entries []domain.WatchlistEntry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := domain.WatchlistEntry{EntryID: field("id"), Name: field("name"), Program: field("program")}
+		if entry.Name == "" {
+			entry.Name = strings.TrimSpace(field("first_name") + " " + field("last_name"))
+		}
+		if entry.EntryID == "" || entry.Name == "" {
+			return nil, fmt.Errorf("line %d: id and name are required", line)
+		}
+		for _, alias := range strings.Split(field("aliases"), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		entries = append(entries, entry)
+	}
+}
+
+type sdnName struct {
+	FirstName string `xml:"firstName"`
+	LastName  string `xml:"lastName"`
+}
+
+func (n sdnName) full() string {
+	return strings.TrimSpace(n.FirstName + " " + n.LastName)
+}
+
+type sdnList struct {
+	Entries []struct {
+		UID string `xml:"uid"`
+		sdnName
+		Programs []string  `xml:"programList>program"`
+		Akas     []sdnName `xml:"akaList>aka"`
+	} `xml:"sdnEntry"`
+}
+
+// ReadSDNXML membaca daftar pantauan dalam format XML SDN (sdnList/sdnEntry)
+// seperti ekspor daftar sanksi OFAC. Nama alias diambil dari akaList.
+func ReadSDNXML(r io.Reader) ([]domain.WatchlistEntry, error) {
+	var list sdnList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, err
+	}
+	entries := make([]domain.WatchlistEntry, 0, len(list.Entries))
+	for _, e := range list.Entries {
+		entry := domain.WatchlistEntry{EntryID: strings.TrimSpace(e.UID), Name: e.full(), Program: strings.Join(e.Programs, ";")}
+		if entry.EntryID == "" || entry.Name == "" {
+			return nil, fmt.Errorf("sdnEntry %q: uid and name are required", entry.EntryID)
+		}
+		for _, aka := range e.Akas {
+			if name := aka.full(); name != "" {
+				entry.Aliases = append(entry.Aliases, name)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
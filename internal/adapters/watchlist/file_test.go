@@ -0,0 +1,46 @@
+package watchlist
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestFileSourceLoadsExamples(t *testing.T) {
+	source := NewFileSource([]domain.WatchlistSource{
+		{Name: "internal", Path: "../../../watchlist.example.csv", Format: domain.WatchlistFormatCSV},
+		{Name: "ofac", Path: "../../../watchlist.example.xml", Format: domain.WatchlistFormatSDNXML},
+	})
+	entries, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", entries)
+	}
+	want := domain.WatchlistEntry{List: "internal", EntryID: "WL-001", Name: "Viktor Bout", Aliases: []string{"Victor Butt", "Viktor Budanov"}, Program: "ARMS"}
+	if !reflect.DeepEqual(entries[0], want) {
+		t.Fatalf("unexpected csv entry: %+v", entries[0])
+	}
+	want = domain.WatchlistEntry{List: "ofac", EntryID: "7001", Name: "Ivan Petrovsky", Aliases: []string{"Ivan Petrovski"}, Program: "SDGT"}
+	if !reflect.DeepEqual(entries[2], want) {
+		t.Fatalf("unexpected sdn entry: %+v", entries[2])
+	}
+	if entries[3].Name != "Example Trading LLC" {
+		t.Fatalf("expected entity name from lastName, got %+v", entries[3])
+	}
+}
+
+func TestReadCSVRejectsInvalidInput(t *testing.T) {
+	for name, input := range map[string]string{
+		"missing name column": "id,program\n1,ARMS\n",
+		"empty name":          "id,name\n1,\n",
+	} {
+		if _, err := ReadCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
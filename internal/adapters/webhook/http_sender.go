@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ErrPrivateTarget dikembalikan saat alamat tujuan webhook berada di jaringan
+// internal (loopback, private, link-local, dan sejenisnya).
+var ErrPrivateTarget = errors.New("webhook target resolves to a private address")
+
+// sharedAddressSpace adalah blok CGNAT (RFC 6598) yang tidak tercakup
+// netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// HTTPSender mengirim webhook menggunakan HTTP POST dengan body JSON.
+type HTTPSender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+// HTTPSenderOption mengatur HTTPSender.
+type HTTPSenderOption func(*HTTPSender)
+
+// WithPrivateTargets mengizinkan pengiriman ke alamat internal. Hanya untuk
+// pengembangan lokal dan pengujian.
+func WithPrivateTargets() HTTPSenderOption {
+	return func(s *HTTPSender) { s.allowPrivate = true }
+}
+
+// NewHTTPSender membuat sender yang menolak alamat internal saat dial, setelah
+// DNS di-resolve, sehingga hostname publik yang mengarah ke jaringan internal
+// dan redirect ke alamat internal juga ditolak.
+func NewHTTPSender(timeout time.Duration, opts ...HTTPSenderOption) *HTTPSender {
+	s := &HTTPSender{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !s.allowPrivate {
+		dialer.Control = rejectPrivateAddress
+		// Proxy dari environment akan melewati pemeriksaan alamat tujuan
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	s.client = &http.Client{Timeout: timeout, Transport: transport}
+	return s
+}
+
+// rejectPrivateAddress dipanggil untuk setiap koneksi dengan alamat IP yang
+// sudah di-resolve.
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrPrivateTarget, addr)
+	}
+	return nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	// Sertakan traceparent agar penerima dapat menyambungkan trace-nya
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPSenderRejectsPrivateTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if _, err := NewHTTPSender(time.Second).Send(context.Background(), server.URL, nil, []byte("{}")); !errors.Is(err, ErrPrivateTarget) {
+		t.Fatalf("expected ErrPrivateTarget for %s, got %v", server.URL, err)
+	}
+	status, err := NewHTTPSender(time.Second, WithPrivateTargets()).Send(context.Background(), server.URL, nil, []byte("{}"))
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("expected 204 with private targets allowed, got %d (%v)", status, err)
+	}
+}
+
+func TestRejectPrivateAddress(t *testing.T) {
+	for address, private := range map[string]bool{
+		"127.0.0.1:80":         true,
+		"10.1.2.3:443":         true,
+		"172.16.0.1:443":       true,
+		"192.168.1.10:443":     true,
+		"169.254.169.254:80":   true,
+		"100.64.0.1:443":       true,
+		"0.0.0.0:80":           true,
+		"[::1]:443":            true,
+		"[fe80::1]:443":        true,
+		"[fd00::1]:443":        true,
+		"[::ffff:10.0.0.1]:80": true,
+		"93.184.216.34:443":    false,
+		"[2606:4700::1]:443":   false,
+	} {
+		if err := rejectPrivateAddress("tcp", address, nil); (err != nil) != private {
+			t.Errorf("%s: expected private=%v, got %v", address, private, err)
+		}
+	}
+}
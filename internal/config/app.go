@@ -0,0 +1,437 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/logging"
+)
+
+// Config adalah seluruh konfigurasi aplikasi. Nilai dibaca berurutan dari
+// default, file YAML/TOML, environment (termasuk .env), lalu flag; sumber yang
+// lebih akhir menimpa sumber sebelumnya.
+//
+// Setiap field daun memiliki tag `key` (nama di file, digabung dengan section
+// induknya, mis. "auth.jwt_secret") dan `env` (nama variabel environment).
+// Nama flag diturunkan dari key dengan "_" diganti "-", mis. -auth.jwt-secret.
+type Config struct {
+	Server         ServerConfig         `key:"server"`
+	Database       DatabaseConfig       `key:"database"`
+	Auth           AuthConfig           `key:"auth"`
+	GRPC           GRPCConfig           `key:"grpc"`
+	Events         EventsConfig         `key:"events"`
+	Reconciliation ReconciliationConfig `key:"reconciliation"`
+	Policies       PolicyConfig         `key:"policies"`
+	Tracing        TracingConfig        `key:"tracing"`
+	Log            LogConfig            `key:"log"`
+	RateLimit      RateLimitConfig      `key:"rate_limit"`
+	Storage        StorageConfig        `key:"storage"`
+	Notifications  NotificationConfig   `key:"notifications"`
+}
+
+// ServerConfig mengatur server HTTP. TLS aktif jika TLSCertFile dan
+// TLSKeyFile diisi; sertifikat yang sama dipakai server gRPC.
+type ServerConfig struct {
+	Addr              string        `key:"addr" env:"HTTP_ADDR"`
+	ReadHeaderTimeout time.Duration `key:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `key:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `key:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `key:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownTimeout adalah batas waktu menguras request dan job yang sedang
+	// berjalan setelah SIGTERM.
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	TLSCertFile     string        `key:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile      string        `key:"tls_key_file" env:"TLS_KEY_FILE"`
+	// TrustedProxies adalah daftar IP atau CIDR reverse proxy, dipisah koma,
+	// yang header X-Forwarded-For-nya dipercaya. Kosong berarti IP klien
+	// selalu diambil dari alamat koneksi.
+	TrustedProxies string `key:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+// TrustedProxyList mengembalikan TrustedProxies sebagai daftar; nil jika kosong.
+func (c ServerConfig) TrustedProxyList() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(c.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// TLSEnabled melaporkan apakah server memakai TLS.
+func (c ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// AuthConfig mengatur penerbitan token dan hash PIN.
+type AuthConfig struct {
+	JWTSecret       Secret        `key:"jwt_secret" env:"JWT_SECRET"`
+	AccessTokenTTL  time.Duration `key:"access_token_ttl" env:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `key:"refresh_token_ttl" env:"REFRESH_TOKEN_TTL"`
+	BcryptCost      int           `key:"bcrypt_cost" env:"BCRYPT_COST"`
+	// AdminTokens adalah bearer token per operator untuk endpoint /admin
+	// dengan format "nama:token,nama:token"; kosong berarti API admin
+	// nonaktif. Nama operator dicatat di audit log.
+	AdminTokens Secret `key:"admin_tokens" env:"ADMIN_API_TOKENS"`
+}
+
+// AdminOperators mengurai AdminTokens menjadi peta nama operator ke token.
+func (c AuthConfig) AdminOperators() (map[string]string, error) {
+	operators := map[string]string{}
+	if c.AdminTokens == "" {
+		return operators, nil
+	}
+	tokens := map[string]bool{}
+	for _, entry := range strings.Split(c.AdminTokens.Value(), ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || name == "" || token == "" {
+			return nil, errors.New("auth.admin_tokens (ADMIN_API_TOKENS) must be a list of name:token pairs")
+		}
+		if _, dup := operators[name]; dup || tokens[token] {
+			return nil, fmt.Errorf("auth.admin_tokens (ADMIN_API_TOKENS) repeats operator %q or its token", name)
+		}
+		operators[name], tokens[token] = token, true
+	}
+	return operators, nil
+}
+
+// PolicyConfig menunjuk file JSON aturan biaya, limit, dan bunga. File yang
+// tidak diset berarti fiturnya tidak aktif.
+type PolicyConfig struct {
+	FeeRulesFile        string `key:"fee_rules_file" env:"FEE_RULES_FILE"`
+	FeeRevenueAccountID string `key:"fee_revenue_account_id" env:"FEE_REVENUE_ACCOUNT_ID"`
+	LimitPoliciesFile   string `key:"limit_policies_file" env:"LIMIT_POLICIES_FILE"`
+	InterestConfigFile  string `key:"interest_config_file" env:"INTEREST_CONFIG_FILE"`
+	FraudRulesFile      string `key:"fraud_rules_file" env:"FRAUD_RULES_FILE"`
+	ScreeningConfigFile string `key:"screening_config_file" env:"SCREENING_CONFIG_FILE"`
+	KYCPoliciesFile     string `key:"kyc_policies_file" env:"KYC_POLICIES_FILE"`
+}
+
+// Secret adalah string rahasia yang tidak pernah tampil utuh saat dicetak
+// dengan fmt maupun di-encode ke JSON.
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+func (s Secret) GoString() string { return strconv.Quote(s.String()) }
+
+func (s Secret) MarshalJSON() ([]byte, error) { return json.Marshal(s.String()) }
+
+// Value mengembalikan nilai rahasia yang sebenarnya.
+func (s Secret) Value() string { return string(s) }
+
+// Default mengembalikan konfigurasi bawaan sebelum sumber lain diterapkan.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{Port: "5432", SSLMode: "disable", ConnectAttempts: 5, ConnectBackoff: time.Second},
+		Auth: AuthConfig{
+			AccessTokenTTL:  24 * time.Hour,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+			BcryptCost:      bcrypt.DefaultCost,
+		},
+		GRPC:   GRPCConfig{Addr: ":9090"},
+		Events: EventsConfig{RelayInterval: time.Second, Broadcaster: "memory"},
+		Tracing: TracingConfig{
+			Exporter:     TracingExporterNone,
+			ServiceName:  "hexagonal-go",
+			OTLPEndpoint: "localhost:4317",
+			SampleRatio:  1,
+		},
+		Log:       LogConfig{Level: "info", Format: logging.FormatJSON},
+		RateLimit: RateLimitConfig{Store: RateLimitStoreMemory},
+		Storage:   StorageConfig{DocumentsDir: "data/documents"},
+		Notifications: NotificationConfig{
+			Notifier:         NotifierConsole,
+			File:             "data/notifications.log",
+			DefaultLanguage:  domain.LanguageIndonesian,
+			LargeWithdrawal:  1000000,
+			DeliveryInterval: 5 * time.Second,
+		},
+	}
+}
+
+// Load membaca konfigurasi dari semua sumber. Path file diambil dari flag
+// -config atau CONFIG_FILE; ekstensi .yaml/.yml dan .toml didukung. args
+// adalah argumen command line tanpa nama program, boleh nil. Load tidak
+// memvalidasi hasilnya; panggil Validate untuk itu.
+func Load(args []string) (*Config, error) {
+	_ = godotenv.Load()
+	cfg := Default()
+	fields := leaves(&cfg)
+
+	// Flag di-parse lebih dulu untuk mendapatkan -config, tetapi nilainya baru
+	// diterapkan setelah file dan environment.
+	fs := flag.NewFlagSet("hexagonal-go", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+	var flagValues []func() error
+	for _, f := range fields {
+		fs.Func(f.flagName(), fmt.Sprintf("%s (env %s)", f.key, f.env), func(raw string) error {
+			flagValues = append(flagValues, func() error { return f.set(raw) })
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if *configFile != "" {
+		values, err := readConfigFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config file: %w", err)
+		}
+		if err := applyFileValues(fields, values); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", *configFile, err)
+		}
+	}
+	for _, f := range fields {
+		if v := os.Getenv(f.env); v != "" {
+			if err := f.set(v); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", f.env, err)
+			}
+		}
+	}
+	for _, apply := range flagValues {
+		if err := apply(); err != nil {
+			return nil, err
+		}
+	}
+
+	// GRPC_ADDR=off menonaktifkan server gRPC.
+	if cfg.GRPC.Addr == "off" {
+		cfg.GRPC.Addr = ""
+	}
+	return &cfg, nil
+}
+
+// Validate memeriksa seluruh konfigurasi dan mengembalikan semua kesalahan
+// sekaligus agar mudah diperbaiki saat startup.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr (HTTP_ADDR) is required"))
+	}
+	if c.Server.ReadHeaderTimeout < 0 || c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		errs = append(errs, errors.New("server timeouts must not be negative"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout (SHUTDOWN_TIMEOUT) must be positive"))
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		errs = append(errs, errors.New("server.tls_cert_file (TLS_CERT_FILE) and server.tls_key_file (TLS_KEY_FILE) must be set together"))
+	}
+	for _, proxy := range c.Server.TrustedProxyList() {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("invalid server.trusted_proxies (TRUSTED_PROXIES) entry %q", proxy))
+			}
+		}
+	}
+	if err := c.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Auth.JWTSecret == "" {
+		errs = append(errs, errors.New("auth.jwt_secret (JWT_SECRET) is required"))
+	}
+	if c.Auth.AccessTokenTTL <= 0 || c.Auth.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("auth token TTLs must be positive"))
+	}
+	if c.Auth.BcryptCost < bcrypt.MinCost || c.Auth.BcryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("auth.bcrypt_cost (BCRYPT_COST) must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+	if _, err := c.Auth.AdminOperators(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Events.RelayInterval <= 0 {
+		errs = append(errs, errors.New("events.relay_interval (OUTBOX_RELAY_INTERVAL) must be positive"))
+	}
+	if c.Events.Broadcaster != "memory" && c.Events.Broadcaster != "postgres" {
+		errs = append(errs, fmt.Errorf("invalid events.broadcaster (STREAM_BROADCASTER) %q", c.Events.Broadcaster))
+	}
+	if c.Reconciliation.Interval < 0 {
+		errs = append(errs, errors.New("reconciliation.interval (RECONCILIATION_INTERVAL) must not be negative"))
+	}
+	if c.Policies.FeeRulesFile != "" {
+		if _, err := uuid.Parse(c.Policies.FeeRevenueAccountID); err != nil {
+			errs = append(errs, fmt.Errorf("invalid policies.fee_revenue_account_id (FEE_REVENUE_ACCOUNT_ID): %w", err))
+		}
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Log.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.RateLimit.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Storage.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Notifications.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// field adalah satu nilai konfigurasi daun beserta nama-namanya.
+type field struct {
+	key   string
+	env   string
+	value reflect.Value
+}
+
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.key, "_", "-")
+}
+
+func (f field) set(raw string) error {
+	switch f.value.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(d))
+		return nil
+	}
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		f.value.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(n))
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		f.value.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported config type %s", f.value.Type())
+	}
+	return nil
+}
+
+// leaves mengumpulkan field daun cfg secara rekursif mengikuti tag key.
+func leaves(cfg *Config) []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			key := sf.Tag.Get("key")
+			if key == "" {
+				continue
+			}
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Duration(0)) {
+				walk(v.Field(i), key)
+				continue
+			}
+			fields = append(fields, field{key: key, env: sf.Tag.Get("env"), value: v.Field(i)})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return fields
+}
+
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", filepath.Ext(path))
+	}
+	return values, err
+}
+
+// applyFileValues menerapkan nilai file ke field yang cocok. Key yang tidak
+// dikenal ditolak agar salah ketik tidak diam-diam diabaikan.
+func applyFileValues(fields []field, values map[string]interface{}) error {
+	flat := map[string]string{}
+	flatten(values, "", flat)
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("unknown key %q", key)
+		}
+		if err := f.set(flat[key]); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func flatten(values map[string]interface{}, prefix string, out map[string]string) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flatten(nested, key, out)
+			continue
+		}
+		out[key] = fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,324 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  addr: ":7000"
+auth:
+  jwt_secret: from-file
+  access_token_ttl: 15m
+  bcrypt_cost: 12
+events:
+  stdout: true
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("ACCESS_TOKEN_TTL", "30m")
+	t.Setenv("GRPC_ADDR", "off")
+
+	cfg, err := Load([]string{"-auth.access-token-ttl", "1h", "-server.addr", ":7001"})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Server.Addr != ":7001" || cfg.Auth.AccessTokenTTL != time.Hour {
+		t.Fatalf("flags should override env and file: %+v", cfg)
+	}
+	if cfg.Auth.JWTSecret.Value() != "from-file" || cfg.Auth.BcryptCost != 12 || !cfg.Events.Stdout {
+		t.Fatalf("file values not applied: %+v", cfg)
+	}
+	if cfg.Auth.RefreshTokenTTL != 7*24*time.Hour || cfg.Events.Broadcaster != "memory" {
+		t.Fatalf("defaults not kept: %+v", cfg)
+	}
+	if cfg.GRPC.Addr != "" {
+		t.Fatalf("expected GRPC_ADDR=off to disable grpc, got %q", cfg.GRPC.Addr)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[database]
+host = "db"
+port = "6543"
+
+[reconciliation]
+interval = "24h"
+freeze = true
+`)
+	cfg, err := Load([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Database.Host != "db" || cfg.Database.Port != "6543" || cfg.Database.SSLMode != "disable" {
+		t.Fatalf("unexpected database config: %+v", cfg.Database)
+	}
+	if cfg.Reconciliation.Interval != 24*time.Hour || !cfg.Reconciliation.Freeze {
+		t.Fatalf("unexpected reconciliation config: %+v", cfg.Reconciliation)
+	}
+}
+
+func TestLoadRejectsInvalidInput(t *testing.T) {
+	unknown := writeFile(t, "config.yaml", "auth:\n  jwt_secrte: typo\n")
+	if _, err := Load([]string{"-config", unknown}); err == nil || !strings.Contains(err.Error(), "auth.jwt_secrte") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+
+	t.Setenv("BCRYPT_COST", "high")
+	if _, err := Load(nil); err == nil || !strings.Contains(err.Error(), "BCRYPT_COST") {
+		t.Fatalf("expected BCRYPT_COST error, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "JWT_SECRET") || !strings.Contains(err.Error(), "DB_HOST") {
+		t.Fatalf("expected missing JWT secret and database errors, got %v", err)
+	}
+
+	cfg.Auth.JWTSecret = "secret"
+	cfg.Database.Host, cfg.Database.User, cfg.Database.Name = "localhost", "app", "bank"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Server.TrustedProxies = "10.0.0.0/8, 192.168.1.1"
+	if err := cfg.Validate(); err != nil || len(cfg.Server.TrustedProxyList()) != 2 {
+		t.Fatalf("expected valid trusted proxies, got %v", err)
+	}
+	cfg.Server.TrustedProxies = "10.0.0.0/8,proxy.local"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "TRUSTED_PROXIES") {
+		t.Fatalf("expected trusted proxies error, got %v", err)
+	}
+	cfg.Server.TrustedProxies = ""
+
+	cfg.Policies.FeeRulesFile = "fees.json"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "FEE_REVENUE_ACCOUNT_ID") {
+		t.Fatalf("expected fee revenue account error, got %v", err)
+	}
+
+	cfg.Policies.FeeRulesFile = ""
+	cfg.Log = LogConfig{Level: "verbose", Format: "xml"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "LOG_LEVEL") || !strings.Contains(err.Error(), "LOG_FORMAT") {
+		t.Fatalf("expected log level and format errors, got %v", err)
+	}
+
+	cfg.Log = Default().Log
+	cfg.Notifications = NotificationConfig{Notifier: NotifierFile, DefaultLanguage: "fr", DeliveryInterval: time.Second}
+	err = cfg.Validate()
+	for _, want := range []string{"NOTIFIER_FILE", "NOTIFICATION_DEFAULT_LANGUAGE"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestAdminOperators(t *testing.T) {
+	auth := AuthConfig{AdminTokens: "alice:token-a, bob:token-b"}
+	operators, err := auth.AdminOperators()
+	if err != nil || len(operators) != 2 || operators["alice"] != "token-a" || operators["bob"] != "token-b" {
+		t.Fatalf("unexpected operators %v (%v)", operators, err)
+	}
+	for _, tokens := range []Secret{"token-without-name", "alice:", "alice:a,alice:b", "alice:same,bob:same"} {
+		if _, err := (AuthConfig{AdminTokens: tokens}).AdminOperators(); err == nil || !strings.Contains(err.Error(), "ADMIN_API_TOKENS") {
+			t.Errorf("expected ADMIN_API_TOKENS error for %q, got %v", tokens.Value(), err)
+		}
+	}
+}
+
+func TestSecretRedaction(t *testing.T) {
+	cfg := Default()
+	cfg.Auth.JWTSecret = "super-secret"
+	cfg.Database.Password = "db-password"
+
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	for _, out := range []string{fmt.Sprintf("%v", cfg), fmt.Sprintf("%+v", cfg), fmt.Sprintf("%#v", cfg), string(body)} {
+		if strings.Contains(out, "super-secret") || strings.Contains(out, "db-password") {
+			t.Fatalf("secret leaked: %s", out)
+		}
+		if !strings.Contains(out, "[REDACTED]") {
+			t.Fatalf("expected redaction marker: %s", out)
+		}
+	}
+}
+
+func TestConnectRetryBackoff(t *testing.T) {
+	var sleeps []time.Duration
+	calls := 0
+	db, err := retry(4, 10*time.Second, func(d time.Duration) { sleeps = append(sleeps, d) }, func() (*gorm.DB, error) {
+		calls++
+		if calls < 4 {
+			return nil, errors.New("connection refused")
+		}
+		return &gorm.DB{}, nil
+	})
+	if err != nil || db == nil {
+		t.Fatalf("expected connection after retries, got %v", err)
+	}
+	if want := []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second}; !reflect.DeepEqual(sleeps, want) {
+		t.Fatalf("unexpected backoff: %v", sleeps)
+	}
+
+	calls = 0
+	if _, err := retry(2, time.Millisecond, func(time.Duration) {}, func() (*gorm.DB, error) {
+		calls++
+		return nil, errors.New("connection refused")
+	}); err == nil || calls != 2 {
+		t.Fatalf("expected error after 2 attempts, got %v after %d calls", err, calls)
+	}
+}
+
+func TestTracingConfig(t *testing.T) {
+	t.Setenv("TRACING_EXPORTER", "stdout")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !cfg.Tracing.Enabled() || cfg.Tracing.SampleRatio != 0.25 || cfg.Tracing.ServiceName != "hexagonal-go" {
+		t.Fatalf("tracing config not applied: %+v", cfg.Tracing)
+	}
+
+	tp, err := NewTracerProvider(context.Background(), cfg.Tracing)
+	if err != nil {
+		t.Fatalf("NewTracerProvider returned error: %v", err)
+	}
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+
+	for _, invalid := range []TracingConfig{{Exporter: "jaeger"}, {Exporter: TracingExporterOTLP, SampleRatio: 1.5}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", invalid)
+		}
+	}
+	if Default().Tracing.Enabled() {
+		t.Fatal("tracing must be disabled by default")
+	}
+}
+
+func TestLoadRateLimitPolicies(t *testing.T) {
+	policies, err := LoadRateLimitPolicies(Default().RateLimit)
+	if err != nil || !reflect.DeepEqual(policies, DefaultRateLimitPolicies()) {
+		t.Fatalf("expected default policies, got %v, %v", policies, err)
+	}
+	if policies, err := LoadRateLimitPolicies(RateLimitConfig{Store: RateLimitStoreOff}); err != nil || policies != nil {
+		t.Fatalf("expected no policies when rate limiting is off, got %v, %v", policies, err)
+	}
+
+	path := writeFile(t, "rate_limits.json", `[{"name":"login","routes":["POST /login"],"key":"ip","limit":3,"period_seconds":60}]`)
+	policies, err = LoadRateLimitPolicies(RateLimitConfig{Store: RateLimitStoreDatabase, PoliciesFile: path})
+	if err != nil || len(policies) != 1 || policies[0].Limit != 3 {
+		t.Fatalf("expected policies from file, got %v, %v", policies, err)
+	}
+
+	invalid := writeFile(t, "invalid.json", `[{"name":"a","routes":["/login"],"key":"device","limit":0,"period_seconds":60},{"name":"a","routes":["POST /x"],"key":"ip","limit":1,"period_seconds":1}]`)
+	_, err = LoadRateLimitPolicies(RateLimitConfig{Store: RateLimitStoreMemory, PoliciesFile: invalid})
+	for _, want := range []string{"unique", "key must be", "must be positive", `route "/login"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+	if err := (RateLimitConfig{Store: "redis"}).Validate(); err == nil || !strings.Contains(err.Error(), "RATE_LIMIT_STORE") {
+		t.Fatalf("expected store error, got %v", err)
+	}
+}
+
+func TestLoadFraudConfig(t *testing.T) {
+	if fraud, err := LoadFraudConfig(PolicyConfig{}); err != nil || fraud != nil {
+		t.Fatalf("expected fraud screening disabled without file, got %v, %v", fraud, err)
+	}
+
+	fraud, err := LoadFraudConfig(PolicyConfig{FraudRulesFile: "../../fraud_rules.example.json"})
+	if err != nil || fraud.ReviewScore != 50 || fraud.UnusualHour == nil || fraud.UnusualHour.Timezone != "Asia/Jakarta" {
+		t.Fatalf("expected example fraud rules, got %+v, %v", fraud, err)
+	}
+
+	invalid := writeFile(t, "fraud.json", `{"review_score":50,"block_score":10,"velocity":{"score":1},"unusual_hour":{"start_hour":2,"end_hour":2,"timezone":"Mars/Olympus"}}`)
+	_, err = LoadFraudConfig(PolicyConfig{FraudRulesFile: invalid})
+	for _, want := range []string{"block_score", "velocity", "different hours", "Mars/Olympus"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadScreeningConfig(t *testing.T) {
+	if screening, err := LoadScreeningConfig(PolicyConfig{}); err != nil || screening != nil {
+		t.Fatalf("expected screening disabled without file, got %v, %v", screening, err)
+	}
+
+	screening, err := LoadScreeningConfig(PolicyConfig{ScreeningConfigFile: "../../screening_config.example.json"})
+	if err != nil || len(screening.Lists) != 2 || screening.Lists[0].Path != filepath.Join("..", "..", "watchlist.example.csv") {
+		t.Fatalf("expected example screening config with resolved paths, got %+v, %v", screening, err)
+	}
+
+	invalid := writeFile(t, "screening.json", `{"match_threshold":0.9,"block_threshold":0.8,"lists":[{"name":"ofac","path":"","format":"pdf"}]}`)
+	_, err = LoadScreeningConfig(PolicyConfig{ScreeningConfigFile: invalid})
+	for _, want := range []string{"thresholds", "path must not be empty", "format must be"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadKYCPolicies(t *testing.T) {
+	if policies, err := LoadKYCPolicies(PolicyConfig{}); err != nil || policies != nil {
+		t.Fatalf("expected kyc policies disabled without file, got %v, %v", policies, err)
+	}
+
+	policies, err := LoadKYCPolicies(PolicyConfig{KYCPoliciesFile: "../../kyc_policies.example.json"})
+	if err != nil || len(policies) != 3 {
+		t.Fatalf("expected example kyc policies, got %+v, %v", policies, err)
+	}
+
+	invalid := writeFile(t, "kyc.json", `[{"level":"GOLD"},{"level":"BASIC","max_balance":-1},{"level":"BASIC"}]`)
+	_, err = LoadKYCPolicies(PolicyConfig{KYCPoliciesFile: invalid})
+	for _, want := range []string{"level must be one of", "must not be negative", "duplicate level BASIC"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadFeeRules(t *testing.T) {
+	revenueID := uuid.NewString()
+	rules, id, err := LoadFeeRules(PolicyConfig{FeeRulesFile: "../../fee_rules.example.json", FeeRevenueAccountID: revenueID})
+	if err != nil || len(rules) != 2 || id.String() != revenueID {
+		t.Fatalf("expected example fee rules, got %v, %v, %v", rules, id, err)
+	}
+
+	invalid := writeFile(t, "fees.json", `[{"operation":"DEPOSIT","type":"FLAT","flat":1},{"operation":"WITHDRAW","type":"FIXED"},{"operation":"TRANSFER","type":"PERCENTAGE","percentage":-1,"waived_tiers":["GOLD"]}]`)
+	_, _, err = LoadFeeRules(PolicyConfig{FeeRulesFile: invalid, FeeRevenueAccountID: revenueID})
+	for _, want := range []string{"operation must be", `unknown type "FIXED"`, "must not be negative", `unknown waived tier "GOLD"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
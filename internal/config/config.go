@@ -1,30 +1,65 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"log/slog"
+	"time"
 
-	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 	"hexagonal-go/internal/core/domain"
 )
 
-func ConnectDB() (*gorm.DB, error) {
-	_ = godotenv.Load()
+// DatabaseConfig berisi parameter koneksi PostgreSQL. Koneksi awal dicoba
+// hingga ConnectAttempts kali dengan jeda ConnectBackoff yang berlipat dua.
+type DatabaseConfig struct {
+	Host            string        `key:"host" env:"DB_HOST"`
+	Port            string        `key:"port" env:"DB_PORT"`
+	User            string        `key:"user" env:"DB_USER"`
+	Password        Secret        `key:"password" env:"DB_PASSWORD"`
+	Name            string        `key:"name" env:"DB_NAME"`
+	SSLMode         string        `key:"sslmode" env:"DB_SSLMODE"`
+	ConnectAttempts int           `key:"connect_attempts" env:"DB_CONNECT_ATTEMPTS"`
+	ConnectBackoff  time.Duration `key:"connect_backoff" env:"DB_CONNECT_BACKOFF"`
+}
+
+// maxConnectBackoff membatasi jeda antarpercobaan koneksi.
+const maxConnectBackoff = 30 * time.Second
+
+// gormLogger meneruskan query lambat dan error GORM ke slog. SQL dicatat
+// dengan placeholder agar nomor telepon dan data nasabah lain tidak tercatat.
+var gormLogger = gormlogger.New(slogWriter{}, gormlogger.Config{
+	SlowThreshold:             200 * time.Millisecond,
+	LogLevel:                  gormlogger.Warn,
+	IgnoreRecordNotFoundError: true,
+	ParameterizedQueries:      true,
+})
+
+type slogWriter struct{}
+
+func (slogWriter) Printf(format string, args ...interface{}) {
+	slog.Warn(fmt.Sprintf(format, args...), "component", "gorm")
+}
 
-	host := os.Getenv("DB_HOST")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	name := os.Getenv("DB_NAME")
-	port := os.Getenv("DB_PORT")
-	sslmode := os.Getenv("DB_SSLMODE")
-	if sslmode == "" {
-		sslmode = "disable"
+// Validate memastikan parameter koneksi wajib terisi.
+func (c DatabaseConfig) Validate() error {
+	if c.Host == "" || c.User == "" || c.Name == "" {
+		return errors.New("database.host (DB_HOST), database.user (DB_USER) and database.name (DB_NAME) are required")
+	}
+	if c.ConnectAttempts < 1 {
+		return errors.New("database.connect_attempts (DB_CONNECT_ATTEMPTS) must be at least 1")
 	}
+	return nil
+}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s", host, user, password, name, port, sslmode)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func ConnectDB(cfg DatabaseConfig) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Host, cfg.User, cfg.Password.Value(), cfg.Name, cfg.Port, cfg.SSLMode)
+	db, err := retry(cfg.ConnectAttempts, cfg.ConnectBackoff, time.Sleep, func() (*gorm.DB, error) {
+		return gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormLogger})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
@@ -34,10 +69,77 @@ func ConnectDB() (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to create uuid-ossp extension: %w", err)
 	}
 
+	// Nasabah lama dinaikkan ke BASIC saat kolom kyc_level pertama kali dibuat
+	if err := db.Exec(kycGrandfatherSQL).Error; err != nil {
+		return nil, fmt.Errorf("failed to grandfather kyc levels: %w", err)
+	}
+
 	// Auto migrate tabel
-	if err := db.AutoMigrate(&domain.User{}, &domain.Transaction{}); err != nil {
+	if err := db.AutoMigrate(Models()...); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Audit log hanya boleh ditambah, juga bagi klien SQL di luar aplikasi
+	if err := db.Exec(auditAppendOnlySQL).Error; err != nil {
+		return nil, fmt.Errorf("failed to protect audit log: %w", err)
+	}
+
 	return db, nil
 }
+
+// kycGrandfatherSQL menambahkan kolom kyc_level sebelum AutoMigrate jika
+// tabel users sudah ada tanpa kolom itu. Nasabah yang terdaftar sebelum KYC
+// diperkenalkan mendapat level BASIC agar tetap bisa menarik dan mentransfer
+// dana; user baru tetap mulai dari UNVERIFIED sesuai default kolom.
+const kycGrandfatherSQL = `
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'users')
+		AND NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'kyc_level') THEN
+		ALTER TABLE users ADD COLUMN kyc_level text NOT NULL DEFAULT 'BASIC';
+		ALTER TABLE users ALTER COLUMN kyc_level SET DEFAULT 'UNVERIFIED';
+	END IF;
+END $$;`
+
+// auditAppendOnlySQL memasang trigger yang menolak UPDATE, DELETE, dan
+// TRUNCATE pada audit_entries. Rantai hash tetap mendeteksi perubahan oleh
+// pihak yang mampu melepas trigger ini.
+const auditAppendOnlySQL = `
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_entries_no_modify ON audit_entries;
+CREATE TRIGGER audit_entries_no_modify BEFORE UPDATE OR DELETE ON audit_entries
+	FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+DROP TRIGGER IF EXISTS audit_entries_no_truncate ON audit_entries;
+CREATE TRIGGER audit_entries_no_truncate BEFORE TRUNCATE ON audit_entries
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_entries_append_only();`
+
+// Models mengembalikan model yang dimigrasikan ConnectDB, juga dipakai
+// pemeriksaan kesehatan untuk memastikan skema sudah mutakhir.
+func Models() []interface{} {
+	return []interface{}{&domain.User{}, &domain.Transaction{}, &domain.InterestAccrual{}, &domain.InterestCheckpoint{}, &domain.OutboxEvent{},
+		&domain.WebhookSubscription{}, &domain.WebhookDelivery{}, &domain.WebhookAttempt{}, &domain.UserDevice{},
+		&domain.AuditEntry{}, &domain.RateLimitBucket{}, &domain.FraudReview{}, &domain.ScreeningHit{}, &domain.KYCSubmission{}, &domain.KYCDocument{},
+		&domain.NotificationPreference{}, &domain.NotificationDelivery{}}
+}
+
+// retry memanggil open hingga berhasil atau percobaan habis, dengan jeda
+// exponential backoff yang dibatasi maxConnectBackoff.
+func retry(attempts int, backoff time.Duration, sleep func(time.Duration), open func() (*gorm.DB, error)) (*gorm.DB, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		var db *gorm.DB
+		if db, err = open(); err == nil {
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, err
+		}
+		slog.Warn("database not ready", "attempt", attempt, "max_attempts", attempts, "error", err, "retry_in", backoff.String())
+		sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// EventsConfig mengatur relay outbox dan publisher event.
+type EventsConfig struct {
+	RelayInterval time.Duration `key:"relay_interval" env:"OUTBOX_RELAY_INTERVAL"`
+	Stdout        bool          `key:"stdout" env:"EVENTS_STDOUT"`
+	// Broadcaster adalah adapter penyebaran stream real-time: "memory" atau "postgres".
+	Broadcaster string `key:"broadcaster" env:"STREAM_BROADCASTER"`
+	// WebhookPrivateTargets mengizinkan webhook ke alamat internal; hanya
+	// untuk pengembangan lokal.
+	WebhookPrivateTargets bool `key:"webhook_private_targets" env:"WEBHOOK_ALLOW_PRIVATE_TARGETS"`
+}
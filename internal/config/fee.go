@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadFeeRules membaca aturan biaya dari file JSON policies.fee_rules_file
+// beserta akun pendapatan biayanya. Jika file tidak diset, tidak ada biaya
+// yang dikenakan.
+func LoadFeeRules(cfg PolicyConfig) ([]domain.FeeRule, uuid.UUID, error) {
+	path := cfg.FeeRulesFile
+	if path == "" {
+		return nil, uuid.Nil, nil
+	}
+
+	var rules []domain.FeeRule
+	if err := readJSONFile(path, &rules); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("failed to load fee rules: %w", err)
+	}
+	if err := validateFeeRules(rules); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("invalid fee rules: %w", err)
+	}
+
+	revenueAccountID, err := uuid.Parse(cfg.FeeRevenueAccountID)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("invalid FEE_REVENUE_ACCOUNT_ID: %w", err)
+	}
+	return rules, revenueAccountID, nil
+}
+
+func validateFeeRules(rules []domain.FeeRule) error {
+	var errs []error
+	operations := make(map[string]bool)
+	for i, rule := range rules {
+		if rule.Operation != domain.CategoryWithdraw && rule.Operation != domain.CategoryTransfer {
+			errs = append(errs, fmt.Errorf("rule %d: operation must be %q or %q", i, domain.CategoryWithdraw, domain.CategoryTransfer))
+		} else if operations[rule.Operation] {
+			errs = append(errs, fmt.Errorf("rule %q: duplicate operation", rule.Operation))
+		}
+		operations[rule.Operation] = true
+		switch rule.Type {
+		case domain.FeeTypeFlat, domain.FeeTypePercentage:
+		case domain.FeeTypeTiered:
+			if len(rule.Tiers) == 0 {
+				errs = append(errs, fmt.Errorf("rule %q: tiers must not be empty", rule.Operation))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("rule %q: unknown type %q", rule.Operation, rule.Type))
+		}
+		if rule.Flat < 0 || rule.Percentage < 0 || rule.Min < 0 || rule.Max < 0 {
+			errs = append(errs, fmt.Errorf("rule %q: flat, percentage, min and max must not be negative", rule.Operation))
+		}
+		if rule.Max > 0 && rule.Min > rule.Max {
+			errs = append(errs, fmt.Errorf("rule %q: min must not exceed max", rule.Operation))
+		}
+		for j, tier := range rule.Tiers {
+			if tier.UpTo < 0 || tier.Flat < 0 || tier.Percentage < 0 {
+				errs = append(errs, fmt.Errorf("rule %q: tier %d must not have negative values", rule.Operation, j))
+			}
+		}
+		for _, tier := range rule.WaivedTiers {
+			if tier != domain.AccountTierRegular && tier != domain.AccountTierPremium {
+				errs = append(errs, fmt.Errorf("rule %q: unknown waived tier %q", rule.Operation, tier))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
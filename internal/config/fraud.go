@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadFraudConfig membaca aturan fraud dari file JSON
+// policies.fraud_rules_file. Jika tidak diset, nil dikembalikan dan
+// penarikan maupun transfer tidak diperiksa mesin fraud.
+func LoadFraudConfig(cfg PolicyConfig) (*domain.FraudConfig, error) {
+	path := cfg.FraudRulesFile
+	if path == "" {
+		return nil, nil
+	}
+
+	var fraud domain.FraudConfig
+	if err := readJSONFile(path, &fraud); err != nil {
+		return nil, fmt.Errorf("failed to load fraud rules: %w", err)
+	}
+	if err := validateFraudConfig(fraud); err != nil {
+		return nil, fmt.Errorf("invalid fraud rules: %w", err)
+	}
+	return &fraud, nil
+}
+
+func validateFraudConfig(c domain.FraudConfig) error {
+	var errs []error
+	if c.ReviewScore <= 0 || c.BlockScore < c.ReviewScore {
+		errs = append(errs, errors.New("review_score must be positive and block_score at least review_score"))
+	}
+	if r := c.Velocity; r != nil && (r.WindowMinutes <= 0 || r.MaxCount <= 0) {
+		errs = append(errs, errors.New("velocity: window_minutes and max_count must be positive"))
+	}
+	if r := c.NewPayee; r != nil && r.MinAmount < 0 {
+		errs = append(errs, errors.New("new_payee: min_amount must not be negative"))
+	}
+	if r := c.RapidCashOut; r != nil && (r.WindowMinutes <= 0 || r.Ratio <= 0) {
+		errs = append(errs, errors.New("rapid_cash_out: window_minutes and ratio must be positive"))
+	}
+	if r := c.UnusualHour; r != nil {
+		if r.StartHour < 0 || r.StartHour > 23 || r.EndHour < 0 || r.EndHour > 23 || r.StartHour == r.EndHour {
+			errs = append(errs, errors.New("unusual_hour: start_hour and end_hour must be different hours between 0 and 23"))
+		}
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			errs = append(errs, fmt.Errorf("unusual_hour: %w", err))
+		}
+	}
+	if r := c.AmountDeviation; r != nil && (r.LookbackDays <= 0 || r.MinSamples < 2 || r.Multiplier <= 0) {
+		errs = append(errs, errors.New("amount_deviation: lookback_days and multiplier must be positive and min_samples at least 2"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,7 @@
+package config
+
+// GRPCConfig mengatur server gRPC. Addr kosong berarti server tidak dijalankan;
+// nilai "off" pada sumber konfigurasi apa pun menghasilkan Addr kosong.
+type GRPCConfig struct {
+	Addr string `key:"addr" env:"GRPC_ADDR"`
+}
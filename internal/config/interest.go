@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadInterestConfig membaca suku bunga per tier dari file JSON
+// policies.interest_config_file. Jika tidak diset, tidak ada bunga yang dihitung.
+func LoadInterestConfig(policies PolicyConfig) (domain.InterestConfig, error) {
+	cfg := domain.InterestConfig{DayCount: domain.DayCountActual365}
+	path := policies.InterestConfigFile
+	if path == "" {
+		return cfg, nil
+	}
+
+	if err := readJSONFile(path, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to load interest config: %w", err)
+	}
+	if cfg.DayCount != domain.DayCountActual365 && cfg.DayCount != domain.DayCountActual360 {
+		return cfg, fmt.Errorf("unsupported day count convention %q", cfg.DayCount)
+	}
+	return cfg, nil
+}
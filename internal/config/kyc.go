@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadKYCPolicies membaca kemampuan akun per level KYC dari file JSON
+// policies.kyc_policies_file. Jika tidak diset, nil dikembalikan dan semua
+// level diperlakukan sama.
+func LoadKYCPolicies(cfg PolicyConfig) ([]domain.KYCPolicy, error) {
+	path := cfg.KYCPoliciesFile
+	if path == "" {
+		return nil, nil
+	}
+
+	var policies []domain.KYCPolicy
+	if err := readJSONFile(path, &policies); err != nil {
+		return nil, fmt.Errorf("failed to load kyc policies: %w", err)
+	}
+	if err := validateKYCPolicies(policies); err != nil {
+		return nil, fmt.Errorf("invalid kyc policies: %w", err)
+	}
+	return policies, nil
+}
+
+func validateKYCPolicies(policies []domain.KYCPolicy) error {
+	var errs []error
+	seen := make(map[string]bool)
+	for i, policy := range policies {
+		if !slices.Contains(domain.KYCLevels, policy.Level) {
+			errs = append(errs, fmt.Errorf("policy %d: level must be one of %v", i, domain.KYCLevels))
+		} else if seen[policy.Level] {
+			errs = append(errs, fmt.Errorf("policy %d: duplicate level %s", i, policy.Level))
+		}
+		seen[policy.Level] = true
+		if policy.MaxBalance < 0 {
+			errs = append(errs, fmt.Errorf("policy %d: max_balance must not be negative", i))
+		}
+	}
+	return errors.Join(errs...)
+}
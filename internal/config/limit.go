@@ -0,0 +1,22 @@
+package config
+
+import (
+	"fmt"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadLimitPolicies membaca kebijakan limit dari file JSON
+// policies.limit_policies_file. Jika tidak diset, tidak ada limit yang diberlakukan.
+func LoadLimitPolicies(cfg PolicyConfig) ([]domain.LimitPolicy, error) {
+	path := cfg.LimitPoliciesFile
+	if path == "" {
+		return nil, nil
+	}
+
+	var policies []domain.LimitPolicy
+	if err := readJSONFile(path, &policies); err != nil {
+		return nil, fmt.Errorf("failed to load limit policies: %w", err)
+	}
+	return policies, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"hexagonal-go/internal/logging"
+)
+
+// LogConfig mengatur log aplikasi. Format "json" (default) untuk produksi,
+// "text" untuk dibaca langsung saat pengembangan lokal.
+type LogConfig struct {
+	Level  string `key:"level" env:"LOG_LEVEL"`
+	Format string `key:"format" env:"LOG_FORMAT"`
+}
+
+func (c LogConfig) Validate() error {
+	var errs []error
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("invalid log.level (LOG_LEVEL) %q", c.Level))
+	}
+	if c.Format != logging.FormatJSON && c.Format != logging.FormatText {
+		errs = append(errs, fmt.Errorf("invalid log.format (LOG_FORMAT) %q", c.Format))
+	}
+	return errors.Join(errs...)
+}
+
+// NewLogger membuat logger sesuai cfg yang menulis ke w. Level yang tidak
+// valid jatuh ke info; panggil Validate untuk menolaknya lebih awal.
+func NewLogger(w io.Writer, cfg LogConfig) *slog.Logger {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(cfg.Level))
+	return logging.New(w, cfg.Format, level)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// Adapter pengirim notifikasi SMS dan email yang didukung.
+const (
+	NotifierConsole = "console"
+	NotifierFile    = "file"
+	NotifierOff     = "off"
+)
+
+// NotificationConfig mengatur notifikasi SMS dan email. Notifier "console"
+// mencetak pesan ke stdout, "file" menambahkannya sebagai JSON lines ke File,
+// dan "off" mematikan notifikasi. LargeWithdrawal adalah nominal penarikan
+// minimal yang dikirimi notifikasi.
+type NotificationConfig struct {
+	Notifier         string        `key:"notifier" env:"NOTIFIER"`
+	File             string        `key:"file" env:"NOTIFIER_FILE"`
+	DefaultLanguage  string        `key:"default_language" env:"NOTIFICATION_DEFAULT_LANGUAGE"`
+	LargeWithdrawal  float64       `key:"large_withdrawal" env:"NOTIFICATION_LARGE_WITHDRAWAL"`
+	DeliveryInterval time.Duration `key:"delivery_interval" env:"NOTIFICATION_DELIVERY_INTERVAL"`
+}
+
+func (c NotificationConfig) Validate() error {
+	var errs []error
+	switch c.Notifier {
+	case NotifierConsole, NotifierOff:
+	case NotifierFile:
+		if c.File == "" {
+			errs = append(errs, errors.New("notifications.file (NOTIFIER_FILE) is required for the file notifier"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid notifications.notifier (NOTIFIER) %q", c.Notifier))
+	}
+	if !slices.Contains(domain.NotificationLanguages, c.DefaultLanguage) {
+		errs = append(errs, fmt.Errorf("notifications.default_language (NOTIFICATION_DEFAULT_LANGUAGE) must be one of %v", domain.NotificationLanguages))
+	}
+	if c.LargeWithdrawal < 0 {
+		errs = append(errs, errors.New("notifications.large_withdrawal (NOTIFICATION_LARGE_WITHDRAWAL) must not be negative"))
+	}
+	if c.DeliveryInterval <= 0 {
+		errs = append(errs, errors.New("notifications.delivery_interval (NOTIFICATION_DELIVERY_INTERVAL) must be positive"))
+	}
+	return errors.Join(errs...)
+}
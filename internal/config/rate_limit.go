@@ -0,0 +1,92 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// Store rate limiter yang didukung.
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStoreDatabase = "database"
+	RateLimitStoreOff      = "off"
+)
+
+// RateLimitConfig mengatur rate limiter HTTP. Store "memory" membatasi per
+// replika, "database" berbagi bucket antarreplika lewat tabel
+// rate_limit_buckets, dan "off" mematikannya. PoliciesFile kosong berarti
+// DefaultRateLimitPolicies.
+type RateLimitConfig struct {
+	Store        string `key:"store" env:"RATE_LIMIT_STORE"`
+	PoliciesFile string `key:"policies_file" env:"RATE_LIMIT_POLICIES_FILE"`
+}
+
+func (c RateLimitConfig) Validate() error {
+	switch c.Store {
+	case RateLimitStoreMemory, RateLimitStoreDatabase, RateLimitStoreOff:
+		return nil
+	}
+	return fmt.Errorf("invalid rate_limit.store (RATE_LIMIT_STORE) %q", c.Store)
+}
+
+// DefaultRateLimitPolicies melindungi login dan registrasi dari brute force
+// per IP, serta endpoint pergerakan dana per user dan per IP.
+func DefaultRateLimitPolicies() []domain.RateLimitPolicy {
+	money := []string{"POST /deposit", "POST /withdraw", "POST /transfer"}
+	return []domain.RateLimitPolicy{
+		{Name: "login", Routes: []string{"POST /login"}, Key: domain.RateLimitByIP, Limit: 5, PeriodSeconds: 60},
+		{Name: "register", Routes: []string{"POST /register"}, Key: domain.RateLimitByIP, Limit: 10, PeriodSeconds: 3600},
+		{Name: "refresh", Routes: []string{"POST /refresh"}, Key: domain.RateLimitByIP, Limit: 30, PeriodSeconds: 60},
+		{Name: "money-user", Routes: money, Key: domain.RateLimitByUser, Limit: 20, PeriodSeconds: 60},
+		{Name: "money-ip", Routes: money, Key: domain.RateLimitByIP, Limit: 60, PeriodSeconds: 60},
+	}
+}
+
+// LoadRateLimitPolicies membaca kebijakan rate limit dari file JSON
+// rate_limit.policies_file, atau DefaultRateLimitPolicies jika tidak diset.
+// Store "off" menghasilkan nil sehingga tidak ada route yang dibatasi.
+func LoadRateLimitPolicies(cfg RateLimitConfig) ([]domain.RateLimitPolicy, error) {
+	if cfg.Store == RateLimitStoreOff {
+		return nil, nil
+	}
+	policies := DefaultRateLimitPolicies()
+	if cfg.PoliciesFile != "" {
+		policies = nil
+		if err := readJSONFile(cfg.PoliciesFile, &policies); err != nil {
+			return nil, fmt.Errorf("failed to load rate limit policies: %w", err)
+		}
+	}
+	if err := validateRateLimitPolicies(policies); err != nil {
+		return nil, fmt.Errorf("invalid rate limit policies: %w", err)
+	}
+	return policies, nil
+}
+
+func validateRateLimitPolicies(policies []domain.RateLimitPolicy) error {
+	var errs []error
+	names := make(map[string]bool)
+	for i, p := range policies {
+		if p.Name == "" || names[p.Name] {
+			errs = append(errs, fmt.Errorf("policy %d: name must be unique and not empty", i))
+		}
+		names[p.Name] = true
+		if p.Key != domain.RateLimitByIP && p.Key != domain.RateLimitByUser {
+			errs = append(errs, fmt.Errorf("policy %q: key must be %q or %q", p.Name, domain.RateLimitByIP, domain.RateLimitByUser))
+		}
+		if p.Limit <= 0 || p.PeriodSeconds <= 0 {
+			errs = append(errs, fmt.Errorf("policy %q: limit and period_seconds must be positive", p.Name))
+		}
+		if len(p.Routes) == 0 {
+			errs = append(errs, fmt.Errorf("policy %q: routes must not be empty", p.Name))
+		}
+		for _, route := range p.Routes {
+			if method, path, ok := strings.Cut(route, " "); !ok || method == "" || !strings.HasPrefix(path, "/") {
+				errs = append(errs, fmt.Errorf("policy %q: route %q must look like \"POST /transfer\"", p.Name, route))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+// ReconciliationConfig mengatur job rekonsiliasi terjadwal. Interval 0 berarti
+// job tidak dijalankan.
+type ReconciliationConfig struct {
+	Interval time.Duration `key:"interval" env:"RECONCILIATION_INTERVAL"`
+	Freeze   bool          `key:"freeze" env:"RECONCILIATION_FREEZE"`
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// LoadScreeningConfig membaca konfigurasi screening daftar pantauan dari file
+// JSON policies.screening_config_file. Path daftar yang relatif dihitung dari
+// direktori file tersebut. Jika tidak diset, nil dikembalikan dan registrasi
+// maupun transfer tidak disaring.
+func LoadScreeningConfig(cfg PolicyConfig) (*domain.ScreeningConfig, error) {
+	path := cfg.ScreeningConfigFile
+	if path == "" {
+		return nil, nil
+	}
+
+	var screening domain.ScreeningConfig
+	if err := readJSONFile(path, &screening); err != nil {
+		return nil, fmt.Errorf("failed to load screening config: %w", err)
+	}
+	if err := validateScreeningConfig(screening); err != nil {
+		return nil, fmt.Errorf("invalid screening config: %w", err)
+	}
+	for i, list := range screening.Lists {
+		if !filepath.IsAbs(list.Path) {
+			screening.Lists[i].Path = filepath.Join(filepath.Dir(path), list.Path)
+		}
+	}
+	return &screening, nil
+}
+
+func validateScreeningConfig(c domain.ScreeningConfig) error {
+	var errs []error
+	if c.MatchThreshold <= 0 || c.MatchThreshold > 1 || c.BlockThreshold < c.MatchThreshold || c.BlockThreshold > 1 {
+		errs = append(errs, errors.New("thresholds must satisfy 0 < match_threshold <= block_threshold <= 1"))
+	}
+	if c.ReloadMinutes < 0 {
+		errs = append(errs, errors.New("reload_minutes must not be negative"))
+	}
+	if len(c.Lists) == 0 {
+		errs = append(errs, errors.New("lists must not be empty"))
+	}
+	names := make(map[string]bool)
+	for i, list := range c.Lists {
+		if list.Name == "" || names[list.Name] {
+			errs = append(errs, fmt.Errorf("list %d: name must be unique and not empty", i))
+		}
+		names[list.Name] = true
+		if list.Path == "" {
+			errs = append(errs, fmt.Errorf("list %q: path must not be empty", list.Name))
+		}
+		if list.Format != domain.WatchlistFormatCSV && list.Format != domain.WatchlistFormatSDNXML {
+			errs = append(errs, fmt.Errorf("list %q: format must be %q or %q", list.Name, domain.WatchlistFormatCSV, domain.WatchlistFormatSDNXML))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,16 @@
+package config
+
+import "errors"
+
+// StorageConfig mengatur penyimpanan file seperti dokumen KYC. DocumentsDir
+// adalah direktori BlobStore lokal dan dibuat saat startup jika belum ada.
+type StorageConfig struct {
+	DocumentsDir string `key:"documents_dir" env:"DOCUMENTS_DIR"`
+}
+
+func (c StorageConfig) Validate() error {
+	if c.DocumentsDir == "" {
+		return errors.New("storage.documents_dir (DOCUMENTS_DIR) is required")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporter tracing yang didukung.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig mengatur OpenTelemetry tracing. Exporter "none" mematikan
+// tracing, "stdout" mencetak span sebagai JSON ke stdout, dan "otlp"
+// mengirimnya lewat OTLP/gRPC ke OTLPEndpoint.
+type TracingConfig struct {
+	Exporter     string `key:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName  string `key:"service_name" env:"OTEL_SERVICE_NAME"`
+	OTLPEndpoint string `key:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure bool   `key:"otlp_insecure" env:"OTEL_EXPORTER_OTLP_INSECURE"`
+	// SampleRatio adalah porsi trace baru yang dicatat (0 sampai 1). Trace
+	// yang diteruskan dari upstream mengikuti keputusan sampling upstream.
+	SampleRatio float64 `key:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Enabled melaporkan apakah span perlu diekspor.
+func (c TracingConfig) Enabled() bool {
+	return c.Exporter != "" && c.Exporter != TracingExporterNone
+}
+
+func (c TracingConfig) Validate() error {
+	switch c.Exporter {
+	case "", TracingExporterNone, TracingExporterStdout, TracingExporterOTLP:
+	default:
+		return fmt.Errorf("invalid tracing.exporter (TRACING_EXPORTER) %q", c.Exporter)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio (TRACING_SAMPLE_RATIO) must be between 0 and 1")
+	}
+	return nil
+}
+
+// NewTracerProvider membuat TracerProvider dengan exporter sesuai cfg. Panggil
+// Shutdown saat aplikasi berhenti agar span yang tersisa terkirim.
+func NewTracerProvider(ctx context.Context, cfg TracingConfig) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case TracingExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("tracing exporter %q has no span exporter", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	), nil
+}
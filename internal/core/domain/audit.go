@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Aksi yang dicatat di audit log.
+const (
+	AuditLogin              = "LOGIN"
+	AuditLoginFailed        = "LOGIN_FAILED"
+	AuditPinChanged         = "PIN_CHANGED"
+	AuditPinReset           = "PIN_RESET"
+	AuditAccountActivated   = "ACCOUNT_ACTIVATED"
+	AuditAccountDeactivated = "ACCOUNT_DEACTIVATED"
+	AuditAccountFrozen      = "ACCOUNT_FROZEN"
+	AuditAccountUnfrozen    = "ACCOUNT_UNFROZEN"
+	AuditProfileUpdated     = "PROFILE_UPDATED"
+	AuditDeposit            = "DEPOSIT"
+	AuditWithdraw           = "WITHDRAW"
+	AuditTransfer           = "TRANSFER"
+	AuditAdjustment         = "ADJUSTMENT"
+	AuditInterestPosted     = "INTEREST_POSTED"
+	AuditTransactionHeld    = "TRANSACTION_HELD"
+	AuditTransactionBlocked = "TRANSACTION_BLOCKED"
+	AuditReviewApproved     = "FRAUD_REVIEW_APPROVED"
+	AuditReviewRejected     = "FRAUD_REVIEW_REJECTED"
+	AuditScreeningHit       = "SCREENING_HIT"
+	AuditScreeningCleared   = "SCREENING_HIT_CLEARED"
+	AuditScreeningConfirmed = "SCREENING_HIT_CONFIRMED"
+	AuditKYCSubmitted       = "KYC_SUBMITTED"
+	AuditKYCApproved        = "KYC_APPROVED"
+	AuditKYCRejected        = "KYC_REJECTED"
+)
+
+// Jenis pelaku aksi di audit log.
+const (
+	ActorUser      = "USER"
+	ActorAdmin     = "ADMIN"
+	ActorSystem    = "SYSTEM"
+	ActorAnonymous = "ANONYMOUS"
+)
+
+// AuditGenesisHash adalah PrevHash entri pertama.
+const AuditGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// AuditActor adalah pelaku sebuah aksi beserta asal request-nya. Name diisi
+// untuk admin, mis. nama operator hexctl.
+type AuditActor struct {
+	Type      string     `json:"type"`
+	ID        *uuid.UUID `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	IPAddress string     `json:"ip_address,omitempty"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	RequestID string     `json:"request_id,omitempty"`
+}
+
+// AuditChange adalah nilai sebuah field sebelum dan sesudah aksi.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntry adalah satu baris audit log yang hanya boleh ditambahkan. Setiap
+// entri menyimpan hash entri sebelumnya, sehingga mengubah, menyisipkan, atau
+// menghapus entri di tengah rantai terdeteksi oleh verifikasi. Changes dan
+// Metadata berisi JSON.
+type AuditEntry struct {
+	Sequence   int64      `gorm:"primaryKey;autoIncrement:false" json:"sequence"`
+	OccurredAt time.Time  `gorm:"not null;index" json:"occurred_at"`
+	ActorType  string     `gorm:"not null" json:"actor_type"`
+	ActorID    *uuid.UUID `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+	ActorName  string     `json:"actor_name,omitempty"`
+	Action     string     `gorm:"not null;index" json:"action"`
+	TargetID   *uuid.UUID `gorm:"type:uuid;index" json:"target_id,omitempty"`
+	Changes    string     `gorm:"type:text" json:"changes,omitempty"`
+	Metadata   string     `gorm:"type:text" json:"metadata,omitempty"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	RequestID  string     `json:"request_id,omitempty"`
+	PrevHash   string     `gorm:"not null" json:"prev_hash"`
+	Hash       string     `gorm:"not null;uniqueIndex" json:"hash"`
+}
+
+// ComputeHash menghitung SHA-256 dari PrevHash dan seluruh isi entri selain
+// Hash. Waktu dinormalkan ke UTC agar hasilnya tidak bergantung zona waktu
+// database.
+func (e AuditEntry) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		Sequence   int64      `json:"sequence"`
+		PrevHash   string     `json:"prev_hash"`
+		OccurredAt string     `json:"occurred_at"`
+		ActorType  string     `json:"actor_type"`
+		ActorID    *uuid.UUID `json:"actor_id"`
+		ActorName  string     `json:"actor_name"`
+		Action     string     `json:"action"`
+		TargetID   *uuid.UUID `json:"target_id"`
+		Changes    string     `json:"changes"`
+		Metadata   string     `json:"metadata"`
+		IPAddress  string     `json:"ip_address"`
+		UserAgent  string     `json:"user_agent"`
+		RequestID  string     `json:"request_id"`
+	}{e.Sequence, e.PrevHash, e.OccurredAt.UTC().Format(time.RFC3339Nano), e.ActorType, e.ActorID, e.ActorName,
+		e.Action, e.TargetID, e.Changes, e.Metadata, e.IPAddress, e.UserAgent, e.RequestID})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter membatasi hasil query audit log. Field kosong tidak memfilter.
+type AuditFilter struct {
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	Action   string
+	From     *time.Time
+	To       *time.Time
+}
+
+// AuditVerification adalah hasil pemeriksaan rantai hash audit log. Hash
+// entri terakhir dapat dicatat di luar sistem sebagai jangkar, karena
+// penghapusan entri paling akhir tidak memutus rantai.
+type AuditVerification struct {
+	Valid          bool   `json:"valid"`
+	EntriesChecked int64  `json:"entries_checked"`
+	LastSequence   int64  `json:"last_sequence"`
+	LastHash       string `json:"last_hash,omitempty"`
+	BrokenAt       *int64 `json:"broken_at,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDevice adalah perangkat yang pernah dipakai login oleh user.
+// Fingerprint adalah hash dari identitas perangkat sehingga nilai aslinya
+// tidak disimpan.
+type UserDevice struct {
+	DeviceID    uuid.UUID `gorm:"primaryKey;type:uuid" json:"device_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_user_device" json:"user_id"`
+	Fingerprint string    `gorm:"not null;uniqueIndex:idx_user_device" json:"-"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	FirstSeenAt time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"not null" json:"last_seen_at"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Jenis domain event yang ditulis ke outbox.
+const (
+	EventUserRegistered     = "UserRegistered"
+	EventFundsDeposited     = "FundsDeposited"
+	EventFundsWithdrawn     = "FundsWithdrawn"
+	EventTransferCompleted  = "TransferCompleted"
+	EventPinChanged         = "PinChanged"
+	EventAccountDeactivated = "AccountDeactivated"
+	EventNewDeviceLogin     = "NewDeviceLogin"
+	EventFundsAdjusted      = "FundsAdjusted"
+)
+
+const AggregateUser = "user"
+
+// OutboxEvent adalah domain event yang disimpan dalam transaksi database yang
+// sama dengan perubahan datanya, lalu dipublikasikan oleh relay. EventID yang
+// auto increment menjaga urutan event per aggregate.
+type OutboxEvent struct {
+	EventID       uint64     `gorm:"primaryKey;autoIncrement" json:"event_id"`
+	AggregateType string     `gorm:"not null" json:"aggregate_type"`
+	AggregateID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"aggregate_id"`
+	EventType     string     `gorm:"not null" json:"event_type"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"`
+	OccurredAt    time.Time  `gorm:"autoCreateTime" json:"occurred_at"`
+	PublishedAt   *time.Time `gorm:"index" json:"published_at"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+type UserRegisteredPayload struct {
+	UserID    uuid.UUID `json:"user_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
+
+// FundsMovedPayload dipakai oleh FundsDeposited, FundsWithdrawn dan FundsAdjusted. Balance
+// adalah saldo akhir user setelah transaksi, termasuk biaya. Fee adalah entri
+// DEBIT biaya, nil jika tidak ada biaya.
+type FundsMovedPayload struct {
+	UserID      uuid.UUID    `json:"user_id"`
+	Transaction Transaction  `json:"transaction"`
+	Fee         *Transaction `json:"fee,omitempty"`
+	Balance     float64      `json:"balance"`
+}
+
+// TransferCompletedPayload memuat kedua sisi transfer. Fee adalah entri DEBIT
+// biaya pengirim, nil jika tidak ada biaya.
+type TransferCompletedPayload struct {
+	FromUserID  uuid.UUID    `json:"from_user_id"`
+	ToUserID    uuid.UUID    `json:"to_user_id"`
+	Amount      float64      `json:"amount"`
+	Debit       Transaction  `json:"debit"`
+	Credit      Transaction  `json:"credit"`
+	Fee         *Transaction `json:"fee,omitempty"`
+	FromBalance float64      `json:"from_balance"`
+	ToBalance   float64      `json:"to_balance"`
+}
+
+// AccountEventPayload dipakai oleh event akun tanpa data tambahan
+// (PinChanged, AccountDeactivated).
+type AccountEventPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// NewDeviceLoginPayload dipakai ketika user login dari perangkat yang belum
+// pernah dipakai sebelumnya.
+type NewDeviceLoginPayload struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeviceID  uuid.UUID `json:"device_id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+}
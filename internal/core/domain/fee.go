@@ -0,0 +1,37 @@
+package domain
+
+const (
+	FeeTypeFlat       = "FLAT"
+	FeeTypePercentage = "PERCENTAGE"
+	FeeTypeTiered     = "TIERED"
+)
+
+// FeeTier adalah satu tingkat pada aturan biaya bertingkat. UpTo bernilai 0
+// berarti tanpa batas atas.
+type FeeTier struct {
+	UpTo       float64 `json:"up_to"`
+	Flat       float64 `json:"flat"`
+	Percentage float64 `json:"percentage"`
+}
+
+// FeeRule mendefinisikan biaya untuk satu jenis operasi (WITHDRAW atau TRANSFER).
+// Percentage dinyatakan dalam persen, Min dan Max bernilai 0 berarti tanpa batas.
+type FeeRule struct {
+	Operation   string    `json:"operation"`
+	Type        string    `json:"type"`
+	Flat        float64   `json:"flat"`
+	Percentage  float64   `json:"percentage"`
+	Tiers       []FeeTier `json:"tiers"`
+	Min         float64   `json:"min"`
+	Max         float64   `json:"max"`
+	WaivedTiers []string  `json:"waived_tiers"`
+}
+
+// FeeQuote adalah hasil perhitungan biaya sebelum transaksi dikonfirmasi.
+type FeeQuote struct {
+	Operation string  `json:"operation"`
+	Amount    float64 `json:"amount"`
+	Fee       float64 `json:"fee"`
+	Total     float64 `json:"total"`
+	Waived    bool    `json:"waived"`
+}
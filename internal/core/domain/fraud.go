@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keputusan mesin fraud untuk sebuah penarikan atau transfer.
+const (
+	FraudDecisionAllow  = "ALLOW"
+	FraudDecisionReview = "REVIEW"
+	FraudDecisionBlock  = "BLOCK"
+)
+
+// Nama aturan fraud.
+const (
+	FraudRuleVelocity        = "velocity"
+	FraudRuleNewPayee        = "new_payee"
+	FraudRuleRapidCashOut    = "rapid_cash_out"
+	FraudRuleUnusualHour     = "unusual_hour"
+	FraudRuleAmountDeviation = "amount_deviation"
+)
+
+// Status kasus fraud. PENDING menunggu analis; BLOCKED ditolak langsung oleh
+// mesin fraud dan hanya disimpan sebagai catatan.
+const (
+	FraudReviewPending  = "PENDING"
+	FraudReviewApproved = "APPROVED"
+	FraudReviewRejected = "REJECTED"
+	FraudReviewBlocked  = "BLOCKED"
+)
+
+// FraudConfig adalah konfigurasi mesin fraud. Skor setiap aturan yang
+// terpicu dijumlahkan; total >= BlockScore memblokir transaksi dan total >=
+// ReviewScore menahannya untuk ditinjau analis. Aturan bernilai nil tidak
+// aktif.
+type FraudConfig struct {
+	ReviewScore     int                  `json:"review_score"`
+	BlockScore      int                  `json:"block_score"`
+	Velocity        *VelocityRule        `json:"velocity"`
+	NewPayee        *NewPayeeRule        `json:"new_payee"`
+	RapidCashOut    *RapidCashOutRule    `json:"rapid_cash_out"`
+	UnusualHour     *UnusualHourRule     `json:"unusual_hour"`
+	AmountDeviation *AmountDeviationRule `json:"amount_deviation"`
+}
+
+// VelocityRule terpicu jika user sudah melakukan MaxCount penarikan dan
+// transfer dalam WindowMinutes terakhir.
+type VelocityRule struct {
+	Score         int `json:"score"`
+	WindowMinutes int `json:"window_minutes"`
+	MaxCount      int `json:"max_count"`
+}
+
+// NewPayeeRule terpicu pada transfer pertama ke penerima yang belum pernah
+// ditransfer user dengan nominal minimal MinAmount.
+type NewPayeeRule struct {
+	Score     int     `json:"score"`
+	MinAmount float64 `json:"min_amount"`
+}
+
+// RapidCashOutRule terpicu jika dana yang keluar minimal Ratio dari deposit
+// yang masuk dalam WindowMinutes terakhir, pola khas rekening penampung.
+type RapidCashOutRule struct {
+	Score         int     `json:"score"`
+	WindowMinutes int     `json:"window_minutes"`
+	Ratio         float64 `json:"ratio"`
+}
+
+// UnusualHourRule terpicu untuk nominal minimal MinAmount pada jam
+// [StartHour, EndHour) di zona waktu Timezone (default UTC). Rentang boleh
+// melewati tengah malam, mis. 23 sampai 5.
+type UnusualHourRule struct {
+	Score     int     `json:"score"`
+	StartHour int     `json:"start_hour"`
+	EndHour   int     `json:"end_hour"`
+	Timezone  string  `json:"timezone"`
+	MinAmount float64 `json:"min_amount"`
+}
+
+// AmountDeviationRule terpicu jika nominal melebihi rata-rata transaksi
+// sejenis user dalam LookbackDays terakhir ditambah Multiplier kali simpangan
+// bakunya. Aturan dilewati jika riwayat kurang dari MinSamples.
+type AmountDeviationRule struct {
+	Score        int     `json:"score"`
+	LookbackDays int     `json:"lookback_days"`
+	MinSamples   int     `json:"min_samples"`
+	Multiplier   float64 `json:"multiplier"`
+}
+
+// FraudCheck adalah transaksi yang dinilai mesin fraud sebelum dibukukan.
+type FraudCheck struct {
+	Operation      string
+	UserID         uuid.UUID
+	CounterpartyID *uuid.UUID
+	Amount         float64
+	At             time.Time
+}
+
+// FraudRuleHit adalah satu aturan yang terpicu beserta alasannya.
+type FraudRuleHit struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// FraudAssessment adalah hasil penilaian sebuah transaksi.
+type FraudAssessment struct {
+	Decision string         `json:"decision"`
+	Score    int            `json:"score"`
+	Hits     []FraudRuleHit `json:"hits"`
+}
+
+// FraudReview adalah penarikan atau transfer yang ditahan (PENDING) atau
+// diblokir (BLOCKED) oleh mesin fraud. Hits berisi JSON []FraudRuleHit.
+// Dana belum dipindahkan sampai analis menyetujuinya; saat itu saldo dan
+// limit diperiksa ulang dan TransactionID diisi dengan transaksi DEBIT-nya.
+type FraudReview struct {
+	ReviewID       uuid.UUID  `gorm:"type:uuid;primaryKey" json:"review_id"`
+	Operation      string     `gorm:"not null" json:"operation"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CounterpartyID *uuid.UUID `gorm:"type:uuid" json:"counterparty_id,omitempty"`
+	Amount         float64    `gorm:"not null" json:"amount"`
+	Remarks        string     `json:"remarks"`
+	Score          int        `gorm:"not null" json:"score"`
+	Decision       string     `gorm:"not null" json:"decision"`
+	Hits           string     `gorm:"type:text" json:"hits"`
+	Status         string     `gorm:"not null;index" json:"status"`
+	ReviewedBy     string     `json:"reviewed_by,omitempty"`
+	ReviewNote     string     `json:"review_note,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid" json:"transaction_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
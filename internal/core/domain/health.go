@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	HealthStatusUp   = "UP"
+	HealthStatusDown = "DOWN"
+)
+
+// HealthCheckResult adalah hasil satu pemeriksaan dependensi.
+type HealthCheckResult struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport adalah hasil seluruh pemeriksaan. Status UP hanya jika semua
+// pemeriksaan UP.
+type HealthReport struct {
+	Status    string              `json:"status"`
+	CheckedAt time.Time           `json:"checked_at"`
+	Checks    []HealthCheckResult `json:"checks"`
+}
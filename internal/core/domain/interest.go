@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Konvensi hitungan hari untuk perhitungan bunga harian.
+const (
+	DayCountActual365 = "ACT/365"
+	DayCountActual360 = "ACT/360"
+)
+
+// InterestRate adalah suku bunga tahunan (dalam persen) untuk satu tier akun.
+type InterestRate struct {
+	AccountTier string  `json:"account_tier"`
+	AnnualRate  float64 `json:"annual_rate"`
+}
+
+type InterestConfig struct {
+	DayCount string         `json:"day_count"`
+	Rates    []InterestRate `json:"rates"`
+}
+
+// InterestAccrual mencatat bunga satu hari untuk satu user. Kombinasi UserID dan
+// AccrualDate unik sehingga accrual harian aman dijalankan ulang.
+type InterestAccrual struct {
+	AccrualID     uuid.UUID  `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()" json:"accrual_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_interest_accruals_user_date" json:"user_id"`
+	AccrualDate   time.Time  `gorm:"not null;uniqueIndex:idx_interest_accruals_user_date" json:"accrual_date"`
+	Balance       float64    `gorm:"not null" json:"balance"`
+	AnnualRate    float64    `gorm:"not null" json:"annual_rate"`
+	Amount        float64    `gorm:"not null" json:"amount"`
+	PostedAt      *time.Time `json:"posted_at"`
+	TransactionID *uuid.UUID `gorm:"type:uuid" json:"transaction_id"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// InterestCheckpoint mencatat hari terakhir yang sudah diproses accrual harian
+// untuk satu user, termasuk hari tanpa bunga karena saldo nol atau akun tidak
+// aktif, sehingga catch-up dapat dilanjutkan per user.
+type InterestCheckpoint struct {
+	UserID         uuid.UUID `gorm:"primaryKey;type:uuid" json:"user_id"`
+	AccruedThrough time.Time `gorm:"not null" json:"accrued_through"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
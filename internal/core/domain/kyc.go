@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Level KYC user, dari yang terendah. User baru selalu UNVERIFIED.
+const (
+	KYCLevelUnverified = "UNVERIFIED"
+	KYCLevelBasic      = "BASIC"
+	KYCLevelFull       = "FULL"
+)
+
+// KYCLevels berisi level KYC berurutan dari yang terendah.
+var KYCLevels = []string{KYCLevelUnverified, KYCLevelBasic, KYCLevelFull}
+
+// KYCLevelRank mengembalikan urutan level KYC; level kosong atau tidak dikenal
+// dianggap UNVERIFIED.
+func KYCLevelRank(level string) int {
+	for i, l := range KYCLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 0
+}
+
+// Status pengajuan KYC.
+const (
+	KYCSubmissionPending  = "PENDING"
+	KYCSubmissionApproved = "APPROVED"
+	KYCSubmissionRejected = "REJECTED"
+)
+
+// Jenis dokumen KYC.
+const (
+	KYCDocumentIDCard         = "ID_CARD"
+	KYCDocumentSelfie         = "SELFIE"
+	KYCDocumentProofOfAddress = "PROOF_OF_ADDRESS"
+)
+
+// MaxKYCDocumentSize adalah ukuran maksimal satu dokumen KYC dalam byte.
+const MaxKYCDocumentSize = 5 << 20
+
+// KYCPolicy menentukan kemampuan akun pada satu level KYC. MaxBalance 0
+// berarti saldo tidak dibatasi. Level yang tidak memiliki kebijakan tidak
+// dibatasi sama sekali.
+type KYCPolicy struct {
+	Level       string  `json:"level"`
+	Withdraw    bool    `json:"withdraw"`
+	TransferOut bool    `json:"transfer_out"`
+	MaxBalance  float64 `json:"max_balance"`
+}
+
+// KYCSubmission adalah pengajuan kenaikan level KYC beserta data identitas
+// dan dokumennya, yang diputuskan oleh reviewer.
+type KYCSubmission struct {
+	SubmissionID uuid.UUID     `gorm:"type:uuid;primaryKey" json:"submission_id"`
+	UserID       uuid.UUID     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Level        string        `gorm:"not null" json:"level"`
+	FullName     string        `gorm:"not null" json:"full_name"`
+	IDNumber     string        `gorm:"not null" json:"id_number"`
+	DateOfBirth  string        `gorm:"not null" json:"date_of_birth"`
+	Status       string        `gorm:"not null;index" json:"status"`
+	ReviewedBy   string        `json:"reviewed_by,omitempty"`
+	ReviewNote   string        `json:"review_note,omitempty"`
+	ReviewedAt   *time.Time    `json:"reviewed_at,omitempty"`
+	Documents    []KYCDocument `gorm:"foreignKey:SubmissionID" json:"documents"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// KYCDocument adalah metadata dokumen KYC; isinya disimpan di BlobStore
+// dengan key BlobKey.
+type KYCDocument struct {
+	DocumentID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"document_id"`
+	SubmissionID uuid.UUID `gorm:"type:uuid;not null;index" json:"submission_id"`
+	Kind         string    `gorm:"not null" json:"kind"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `gorm:"not null" json:"content_type"`
+	Size         int64     `gorm:"not null" json:"size"`
+	BlobKey      string    `gorm:"not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// KYCUpload adalah dokumen yang diunggah bersama pengajuan KYC.
+type KYCUpload struct {
+	Kind     string
+	FileName string
+	Content  io.Reader
+}
+
+// KYCApplication adalah data yang diajukan user untuk naik ke Level.
+// DateOfBirth berformat YYYY-MM-DD.
+type KYCApplication struct {
+	Level       string
+	FullName    string
+	IDNumber    string
+	DateOfBirth string
+	Documents   []KYCUpload
+}
+
+// KYCStatus adalah level KYC user, kemampuan akunnya, dan riwayat
+// pengajuannya. Policy kosong berarti level tersebut tidak dibatasi.
+type KYCStatus struct {
+	Level       string          `json:"level"`
+	Policy      *KYCPolicy      `json:"policy,omitempty"`
+	Submissions []KYCSubmission `json:"submissions"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+// LimitPolicy membatasi nominal dan frekuensi satu jenis operasi (WITHDRAW atau
+// TRANSFER) untuk satu tier akun. Nilai 0 berarti tanpa batas.
+type LimitPolicy struct {
+	AccountTier    string  `json:"account_tier"`
+	Operation      string  `json:"operation"`
+	PerTransaction float64 `json:"per_transaction"`
+	DailyAmount    float64 `json:"daily_amount"`
+	MonthlyAmount  float64 `json:"monthly_amount"`
+	DailyCount     int64   `json:"daily_count"`
+	MonthlyCount   int64   `json:"monthly_count"`
+}
+
+// LimitUsage adalah akumulasi pemakaian sebuah operasi dalam satu periode.
+type LimitUsage struct {
+	Amount float64
+	Count  int64
+}
+
+// RemainingLimit menampilkan sisa limit pengguna untuk satu operasi. Field
+// bernilai nil berarti operasi tersebut tidak dibatasi.
+type RemainingLimit struct {
+	Operation      string   `json:"operation"`
+	PerTransaction *float64 `json:"per_transaction"`
+	DailyAmount    *float64 `json:"daily_amount"`
+	MonthlyAmount  *float64 `json:"monthly_amount"`
+	DailyCount     *int64   `json:"daily_count"`
+	MonthlyCount   *int64   `json:"monthly_count"`
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Jenis notifikasi yang dikirim ke aplikasi mobile melalui WebSocket. SMS dan
+// email dikirim terpisah melalui NotificationDelivery.
+const (
+	NotificationBalanceUpdate    = "balance_update"
+	NotificationIncomingTransfer = "incoming_transfer"
+	NotificationSecurityNotice   = "security_notice"
+)
+
+// Notification adalah pesan real-time untuk UserID. Data berisi detail sesuai
+// Type, misalnya BalanceUpdate atau SecurityNotice.
+type Notification struct {
+	UserID    uuid.UUID   `json:"-"`
+	Type      string      `json:"type"`
+	EventID   uint64      `json:"event_id"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type BalanceUpdate struct {
+	Balance     float64     `json:"balance"`
+	Transaction Transaction `json:"transaction"`
+}
+
+type IncomingTransfer struct {
+	FromUserID uuid.UUID `json:"from_user_id"`
+	Amount     float64   `json:"amount"`
+	Remarks    string    `json:"remarks"`
+}
+
+// SecurityNotice memberi tahu user tentang aktivitas sensitif pada akunnya.
+// Event berisi jenis domain event asal, misalnya PinChanged.
+type SecurityNotice struct {
+	Event     string `json:"event"`
+	Message   string `json:"message"`
+	UserAgent string `json:"user_agent,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// Kanal notifikasi di luar aplikasi, masing-masing dikirim melalui
+// ports.Notifier sendiri.
+const (
+	ChannelSMS   = "SMS"
+	ChannelEmail = "EMAIL"
+)
+
+// Bahasa template notifikasi.
+const (
+	LanguageIndonesian = "id"
+	LanguageEnglish    = "en"
+)
+
+var NotificationLanguages = []string{LanguageIndonesian, LanguageEnglish}
+
+// Template pesan SMS dan email.
+const (
+	TemplateTransferSent     = "transfer_sent"
+	TemplateTransferReceived = "transfer_received"
+	TemplateLargeWithdrawal  = "large_withdrawal"
+	TemplatePinChanged       = "pin_changed"
+	TemplateNewDeviceLogin   = "new_device_login"
+)
+
+// NotificationPreference adalah pilihan kanal dan bahasa notifikasi user.
+// Email hanya dikirim jika EmailAddress diisi.
+type NotificationPreference struct {
+	UserID       uuid.UUID `gorm:"primaryKey;type:uuid" json:"-"`
+	Language     string    `gorm:"not null" json:"language"`
+	SMS          bool      `gorm:"not null" json:"sms"`
+	Email        bool      `gorm:"not null" json:"email"`
+	EmailAddress string    `gorm:"not null;default:''" json:"email_address"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// NotificationMessage adalah pesan yang sudah dirender untuk satu kanal.
+// Subject kosong untuk SMS.
+type NotificationMessage struct {
+	Channel   string `json:"channel"`
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body"`
+}
+
+// NotificationDelivery adalah pesan SMS atau email di antrean pengiriman.
+// Kombinasi EventID, UserID, Template dan Channel unik agar event yang
+// dipublikasikan ulang tidak mengirim pesan dua kali. Status memakai status
+// pengiriman yang sama dengan webhook.
+type NotificationDelivery struct {
+	DeliveryID    uuid.UUID  `gorm:"primaryKey;type:uuid" json:"delivery_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_notification_deliveries_event" json:"user_id"`
+	EventID       uint64     `gorm:"not null;uniqueIndex:idx_notification_deliveries_event" json:"event_id"`
+	Template      string     `gorm:"not null;uniqueIndex:idx_notification_deliveries_event" json:"template"`
+	Channel       string     `gorm:"not null;uniqueIndex:idx_notification_deliveries_event" json:"channel"`
+	Recipient     string     `gorm:"not null" json:"recipient"`
+	Subject       string     `gorm:"not null;default:''" json:"subject"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	Status        string     `gorm:"not null;index" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"index" json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Message mengembalikan pesan yang dikirim untuk delivery ini.
+func (d NotificationDelivery) Message() NotificationMessage {
+	return NotificationMessage{Channel: d.Channel, Recipient: d.Recipient, Subject: d.Subject, Body: d.Body}
+}
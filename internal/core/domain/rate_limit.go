@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Dasar pengelompokan bucket rate limit.
+const (
+	RateLimitByIP   = "ip"
+	RateLimitByUser = "user"
+)
+
+// RateLimitPolicy membatasi request ke sekelompok route dengan token bucket:
+// bucket berisi paling banyak Limit token dan terisi kembali Limit token per
+// PeriodSeconds. Routes berformat "METHOD /path" dengan path template Gin,
+// mis. "POST /transfer"; semua route dalam satu kebijakan berbagi bucket. Key
+// RateLimitByUser memakai IP untuk request yang belum login.
+type RateLimitPolicy struct {
+	Name          string   `json:"name"`
+	Routes        []string `json:"routes"`
+	Key           string   `json:"key"`
+	Limit         int      `json:"limit"`
+	PeriodSeconds int      `json:"period_seconds"`
+}
+
+// Matches melaporkan apakah request ke method dan route terkena kebijakan ini.
+func (p RateLimitPolicy) Matches(method, route string) bool {
+	for _, r := range p.Routes {
+		if r == method+" "+route {
+			return true
+		}
+	}
+	return false
+}
+
+// Take mengisi ulang bucket sesuai waktu yang berlalu lalu mengambil satu
+// token jika tersedia. bucket diperbarui di tempat; bucket baru (RefilledAt
+// nol) dianggap penuh.
+func (p RateLimitPolicy) Take(bucket *RateLimitBucket, now time.Time) RateLimitDecision {
+	capacity := float64(p.Limit)
+	rate := capacity / float64(p.PeriodSeconds)
+	tokens := capacity
+	if !bucket.RefilledAt.IsZero() {
+		elapsed := math.Max(now.Sub(bucket.RefilledAt).Seconds(), 0)
+		tokens = math.Min(capacity, bucket.Tokens+elapsed*rate)
+	}
+
+	decision := RateLimitDecision{Policy: p}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsDuration((1 - tokens) / rate)
+	}
+	decision.Remaining = int(math.Floor(tokens))
+	decision.Reset = secondsDuration((capacity - tokens) / rate)
+
+	bucket.Tokens, bucket.RefilledAt = tokens, now
+	bucket.ExpiresAt = now.Add(decision.Reset)
+	return decision
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+// RateLimitBucket adalah isi token bucket untuk satu kunci, mis.
+// "login|ip:10.0.0.1". ExpiresAt adalah saat bucket penuh kembali; setelah
+// itu bucket boleh dihapus karena bucket baru juga dianggap penuh.
+type RateLimitBucket struct {
+	BucketKey  string    `gorm:"primaryKey"`
+	Tokens     float64   `gorm:"not null"`
+	RefilledAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+}
+
+// RateLimitRequest adalah identitas request yang diperiksa rate limiter.
+// UserID kosong untuk request yang belum login.
+type RateLimitRequest struct {
+	Method    string
+	Route     string
+	IPAddress string
+	UserID    string
+}
+
+// RateLimitDecision adalah hasil pemeriksaan satu kebijakan. Reset adalah
+// waktu sampai bucket penuh kembali dan RetryAfter waktu sampai satu token
+// tersedia bagi request yang ditolak.
+type RateLimitDecision struct {
+	Policy     RateLimitPolicy
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Jenis temuan rekonsiliasi ledger.
+const (
+	IssueChainGap            = "CHAIN_GAP"
+	IssueRowInconsistent     = "ROW_INCONSISTENT"
+	IssueBalanceMismatch     = "BALANCE_MISMATCH"
+	IssueLedgerSumMismatch   = "LEDGER_SUM_MISMATCH"
+	IssueOrphanedTransaction = "ORPHANED_TRANSACTION"
+)
+
+type ReconciliationIssue struct {
+	Type          string     `json:"type"`
+	UserID        uuid.UUID  `json:"user_id"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	Expected      float64    `json:"expected"`
+	Actual        float64    `json:"actual"`
+	Detail        string     `json:"detail"`
+}
+
+type ReconciliationReport struct {
+	StartedAt           time.Time             `json:"started_at"`
+	FinishedAt          time.Time             `json:"finished_at"`
+	UsersChecked        int                   `json:"users_checked"`
+	TransactionsChecked int                   `json:"transactions_checked"`
+	Issues              []ReconciliationIssue `json:"issues"`
+	FrozenUsers         []uuid.UUID           `json:"frozen_users"`
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format file daftar pantauan yang didukung.
+const (
+	WatchlistFormatCSV    = "csv"
+	WatchlistFormatSDNXML = "sdn_xml"
+)
+
+// Pemicu screening. TRANSFER menyaring penerima transfer, OUTGOING menyaring
+// pengirim penarikan dan transfer.
+const (
+	ScreeningTriggerRegister      = "REGISTER"
+	ScreeningTriggerProfileUpdate = "PROFILE_UPDATE"
+	ScreeningTriggerTransfer      = "TRANSFER"
+	ScreeningTriggerOutgoing      = "OUTGOING"
+)
+
+// Status kasus screening. CONFIRMED memblokir akun user.
+const (
+	ScreeningHitPending   = "PENDING"
+	ScreeningHitCleared   = "CLEARED"
+	ScreeningHitConfirmed = "CONFIRMED"
+)
+
+// ScreeningConfig adalah konfigurasi screening daftar pantauan. Nama dengan
+// skor kemiripan minimal MatchThreshold dicatat sebagai kasus; transfer ke
+// user dengan kasus PENDING berskor minimal BlockThreshold ditolak sampai
+// kasusnya diputuskan. Skor berada di antara 0 dan 1.
+type ScreeningConfig struct {
+	MatchThreshold float64           `json:"match_threshold"`
+	BlockThreshold float64           `json:"block_threshold"`
+	ReloadMinutes  int               `json:"reload_minutes"`
+	Lists          []WatchlistSource `json:"lists"`
+}
+
+// WatchlistSource adalah satu file ekspor daftar pantauan, mis. daftar
+// sanksi dalam format CSV atau SDN XML.
+type WatchlistSource struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Format string `json:"format"`
+}
+
+// WatchlistEntry adalah satu pihak pada daftar pantauan beserta nama
+// aliasnya.
+type WatchlistEntry struct {
+	List    string
+	EntryID string
+	Name    string
+	Aliases []string
+	Program string
+}
+
+// ScreeningHit adalah kecocokan nama user dengan entri daftar pantauan yang
+// menunggu atau sudah diputuskan petugas compliance. Satu entri hanya
+// dicatat sekali per user sehingga kasus yang sudah CLEARED tidak muncul lagi.
+type ScreeningHit struct {
+	HitID       uuid.UUID  `gorm:"type:uuid;primaryKey" json:"hit_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_screening_hit_entry" json:"user_id"`
+	Trigger     string     `gorm:"not null" json:"trigger"`
+	List        string     `gorm:"not null;uniqueIndex:idx_screening_hit_entry" json:"list"`
+	EntryID     string     `gorm:"not null;uniqueIndex:idx_screening_hit_entry" json:"entry_id"`
+	EntryName   string     `gorm:"not null" json:"entry_name"`
+	Program     string     `json:"program,omitempty"`
+	MatchedName string     `gorm:"not null" json:"matched_name"`
+	Score       float64    `gorm:"not null" json:"score"`
+	Status      string     `gorm:"not null;index" json:"status"`
+	ReviewedBy  string     `json:"reviewed_by,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
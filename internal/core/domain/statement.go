@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// StatementLine adalah satu transaksi pada rekening koran beserta saldo berjalan.
+type StatementLine struct {
+	TransactionID   uuid.UUID `json:"transaction_id"`
+	Date            time.Time `json:"date"`
+	TransactionType string    `json:"transaction_type"`
+	Category        string    `json:"category"`
+	Remarks         string    `json:"remarks"`
+	Amount          float64   `json:"amount"`
+	RunningBalance  float64   `json:"running_balance"`
+}
+
+// Statement adalah rekening koran untuk periode [From, To).
+type Statement struct {
+	UserID         uuid.UUID       `json:"user_id"`
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	OpeningBalance float64         `json:"opening_balance"`
+	ClosingBalance float64         `json:"closing_balance"`
+	TotalCredits   float64         `json:"total_credits"`
+	TotalDebits    float64         `json:"total_debits"`
+	Lines          []StatementLine `json:"lines"`
+}
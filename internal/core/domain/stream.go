@@ -0,0 +1,11 @@
+package domain
+
+import "github.com/google/uuid"
+
+// StreamMessage adalah transaksi baru milik UserID beserta saldo terkini yang
+// dikirim ke klien real-time.
+type StreamMessage struct {
+	UserID      uuid.UUID   `json:"user_id"`
+	Transaction Transaction `json:"transaction"`
+	Balance     float64     `json:"balance"`
+}
@@ -5,13 +5,47 @@ import (
 	"time"
 )
 
+const (
+	TransactionTypeCredit = "CREDIT"
+	TransactionTypeDebit  = "DEBIT"
+)
+
+// Kategori transaksi, sekaligus dipakai sebagai jenis operasi pada aturan biaya.
+const (
+	CategoryDeposit  = "DEPOSIT"
+	CategoryWithdraw = "WITHDRAW"
+	CategoryTransfer = "TRANSFER"
+	CategoryFee      = "FEE"
+	CategoryInterest = "INTEREST"
+	// CategoryAdjustment adalah koreksi saldo manual oleh petugas operasional.
+	CategoryAdjustment = "ADJUSTMENT"
+)
+
 type Transaction struct {
 	TransactionID   uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
 	UserID          uuid.UUID `gorm:"type:uuid;not null"`
 	TransactionType string    `gorm:"not null"` // CREDIT or DEBIT
+	Category        string    `gorm:"not null;default:''"`
 	Amount          float64   `gorm:"not null"`
 	Remarks         string    `gorm:"not null"`
 	BalanceBefore   float64   `gorm:"not null"`
 	BalanceAfter    float64   `gorm:"not null"`
-	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	// CounterpartyID diisi pada transaksi TRANSFER dengan user di sisi lain transfer.
+	CounterpartyID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+// TransactionFilter membatasi daftar transaksi. Field kosong tidak memfilter.
+type TransactionFilter struct {
+	TransactionType string
+	Category        string
+	From            *time.Time
+	To              *time.Time
+}
+
+// TransactionCursor menandai posisi sebuah transaksi dalam urutan terbaru ke
+// terlama, dipakai untuk pagination berbasis keyset.
+type TransactionCursor struct {
+	CreatedAt     time.Time
+	TransactionID uuid.UUID
 }
@@ -5,6 +5,11 @@ import (
 	"time"
 )
 
+const (
+	AccountTierRegular = "REGULAR"
+	AccountTierPremium = "PREMIUM"
+)
+
 type User struct {
 	UserID      uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
 	FirstName   string    `gorm:"not null" json:"first_name"`
@@ -14,6 +19,15 @@ type User struct {
 	Pin         string    `gorm:"not null" json:"pin"`
 	Balance     float64   `gorm:"default:0" json:"balance"`
 	IsActive    bool      `gorm:"default:true" json:"is_active"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// IsBlocked diset saat kasus screening daftar pantauan dikonfirmasi; akun
+	// yang diblokir tidak dapat login maupun memindahkan dana.
+	IsBlocked bool `gorm:"not null;default:false" json:"is_blocked"`
+	// IsFrozen diset rekonsiliasi saat ledger akun tidak konsisten; akun yang
+	// dibekukan tidak dapat memindahkan dana sampai admin mencairkannya.
+	IsFrozen    bool   `gorm:"not null;default:false" json:"is_frozen"`
+	AccountTier string `gorm:"not null;default:REGULAR" json:"account_tier"`
+	// KYCLevel hanya berubah saat pengajuan KYC disetujui reviewer.
+	KYCLevel  string    `gorm:"not null;default:UNVERIFIED" json:"kyc_level"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status pengiriman webhook dan notifikasi SMS/email.
+const (
+	DeliveryPending    = "PENDING"
+	DeliverySucceeded  = "SUCCEEDED"
+	DeliveryDeadLetter = "DEAD_LETTER"
+)
+
+// WebhookSubscription adalah endpoint partner yang menerima event untuk akun
+// pemiliknya. EventTypes berisi daftar jenis event dipisahkan koma.
+type WebhookSubscription struct {
+	SubscriptionID uuid.UUID `gorm:"primaryKey;type:uuid" json:"subscription_id"`
+	OwnerID        uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	URL            string    `gorm:"not null" json:"url"`
+	EventTypes     string    `gorm:"not null" json:"event_types"`
+	Secret         string    `gorm:"not null" json:"-"`
+	IsActive       bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Matches melaporkan apakah subscription berlangganan eventType.
+func (s WebhookSubscription) Matches(eventType string) bool {
+	for _, t := range strings.Split(s.EventTypes, ",") {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery adalah satu event yang harus dikirim ke satu subscription.
+// Kombinasi SubscriptionID dan EventID unik agar event yang dipublikasikan
+// ulang tidak dikirim dua kali.
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID  `gorm:"primaryKey;type:uuid" json:"delivery_id"`
+	SubscriptionID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"subscription_id"`
+	EventID        uint64     `gorm:"not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"event_id"`
+	EventType      string     `gorm:"not null" json:"event_type"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"not null;index" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// WebhookAttempt adalah log satu percobaan pengiriman.
+type WebhookAttempt struct {
+	AttemptID   uuid.UUID `gorm:"primaryKey;type:uuid" json:"attempt_id"`
+	DeliveryID  uuid.UUID `gorm:"type:uuid;not null;index" json:"delivery_id"`
+	StatusCode  int       `json:"status_code"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
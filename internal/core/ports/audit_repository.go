@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// AuditRepository menyimpan audit log yang hanya bisa ditambah; tidak ada
+// operasi ubah maupun hapus.
+type AuditRepository interface {
+	// LastWithTx mengunci rantai audit sampai dbTx selesai agar penulis lain
+	// menunggu, lalu mengembalikan entri terakhir atau nil jika masih kosong.
+	LastWithTx(ctx context.Context, dbTx *gorm.DB) (*domain.AuditEntry, error)
+	CreateWithTx(ctx context.Context, dbTx *gorm.DB, entry *domain.AuditEntry) error
+	// Find mengembalikan entri sesuai filter dari yang terbaru. before > 0
+	// membatasi hasil ke sequence yang lebih kecil (halaman berikutnya).
+	Find(ctx context.Context, filter domain.AuditFilter, before int64, limit int) ([]domain.AuditEntry, error)
+	// FindAfter mengembalikan entri dengan sequence > after dari yang terlama.
+	FindAfter(ctx context.Context, after int64, limit int) ([]domain.AuditEntry, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore menyimpan file biner, mis. dokumen KYC, berdasarkan key berbentuk
+// path dengan pemisah "/".
+type BlobStore interface {
+	// Put menyimpan seluruh isi r dengan key tersebut dan mengembalikan
+	// jumlah byte yang ditulis. Key yang sudah ada ditimpa.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open membuka isi blob; pemanggil wajib menutupnya.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
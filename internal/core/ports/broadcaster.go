@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// Broadcaster menyebarkan domain event ke semua replika aplikasi agar klien
+// real-time yang terhubung ke replika mana pun menerima pesannya.
+type Broadcaster interface {
+	Broadcast(ctx context.Context, event domain.OutboxEvent) error
+	// Listen memanggil handler untuk setiap event yang disebarkan sampai ctx
+	// dibatalkan.
+	Listen(ctx context.Context, handler func(domain.OutboxEvent)) error
+}
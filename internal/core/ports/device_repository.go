@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type DeviceRepository interface {
+	FindByFingerprint(ctx context.Context, userID uuid.UUID, fingerprint string) (*domain.UserDevice, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	Create(ctx context.Context, device *domain.UserDevice) error
+	Touch(ctx context.Context, deviceID uuid.UUID, ipAddress string, seenAt time.Time) error
+	// WithTx mengembalikan repository yang menjalankan query di dalam transaksi dbTx.
+	WithTx(dbTx *gorm.DB) DeviceRepository
+}
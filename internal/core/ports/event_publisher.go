@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// EventPublisher mengirim domain event ke sistem lain. Event dapat terkirim
+// lebih dari sekali, sehingga konsumen harus idempoten terhadap EventID.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.OutboxEvent) error
+}
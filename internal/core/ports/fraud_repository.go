@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// FraudRepository menyediakan riwayat transaksi untuk aturan fraud dan
+// menyimpan kasus yang ditahan atau diblokir. Method WithTx berjalan di dalam
+// transaksi database pemindahan dana.
+type FraudRepository interface {
+	// CountDebitsSinceWithTx menghitung transaksi DEBIT user pada kategori
+	// categories sejak since.
+	CountDebitsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, categories []string, since time.Time) (int64, error)
+	// SumCreditsSinceWithTx menjumlahkan transaksi CREDIT user pada kategori
+	// category sejak since.
+	SumCreditsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (float64, error)
+	// DebitAmountsSinceWithTx mengembalikan nominal transaksi DEBIT user pada
+	// kategori category sejak since.
+	DebitAmountsSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) ([]float64, error)
+	// HasTransferredToWithTx melaporkan apakah user pernah mentransfer ke
+	// counterpartyID.
+	HasTransferredToWithTx(ctx context.Context, dbTx *gorm.DB, userID, counterpartyID uuid.UUID) (bool, error)
+
+	CreateReviewWithTx(ctx context.Context, dbTx *gorm.DB, review *domain.FraudReview) error
+	// FindReviewForUpdateWithTx membaca kasus dan menguncinya sampai dbTx
+	// selesai, atau gorm.ErrRecordNotFound.
+	FindReviewForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, reviewID uuid.UUID) (*domain.FraudReview, error)
+	UpdateReviewWithTx(ctx context.Context, dbTx *gorm.DB, review *domain.FraudReview) error
+	FindReviewByID(ctx context.Context, reviewID uuid.UUID) (*domain.FraudReview, error)
+	// FindReviews mengembalikan paling banyak limit kasus dengan status
+	// tertentu (kosong berarti semua), dari yang terbaru.
+	FindReviews(ctx context.Context, status string, limit int) ([]domain.FraudReview, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// HealthChecker memeriksa satu dependensi aplikasi. Check mengembalikan error
+// jika dependensi tidak siap melayani request.
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+// HealthCheckFunc mengubah fungsi biasa menjadi HealthChecker.
+type HealthCheckFunc func(ctx context.Context) error
+
+func (f HealthCheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type InterestRepository interface {
+	// CreateAccrual menyimpan accrual dan mengembalikan false jika accrual untuk
+	// user dan tanggal yang sama sudah ada.
+	CreateAccrual(ctx context.Context, accrual *domain.InterestAccrual) (bool, error)
+	FindUnposted(ctx context.Context, from, to time.Time) ([]domain.InterestAccrual, error)
+	// MarkPostedWithTx menandai accrual yang belum diposting dan mengembalikan
+	// false jika salah satunya sudah diposting lebih dulu.
+	MarkPostedWithTx(ctx context.Context, dbTx *gorm.DB, accrualIDs []uuid.UUID, transactionID *uuid.UUID, postedAt time.Time) (bool, error)
+	AccruedTotal(ctx context.Context, userID uuid.UUID) (float64, error)
+	// LastAccrualDate mengembalikan tanggal accrual terbaru dari semua user,
+	// atau nil jika belum ada accrual sama sekali.
+	LastAccrualDate(ctx context.Context) (*time.Time, error)
+	// AdvanceCheckpoint memajukan checkpoint user ke day; checkpoint yang
+	// sudah lebih baru tidak diubah.
+	AdvanceCheckpoint(ctx context.Context, userID uuid.UUID, day time.Time) error
+	// Checkpoints mengembalikan checkpoint accrual setiap user.
+	Checkpoints(ctx context.Context) (map[uuid.UUID]time.Time, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// JobLock memastikan job latar dengan nama yang sama hanya berjalan di satu
+// instance pada satu waktu, misalnya saat aplikasi dijalankan dengan beberapa
+// replika.
+type JobLock interface {
+	// TryRun menjalankan fn selama lock name dipegang dan mengembalikan true.
+	// Jika lock sedang dipegang instance lain, fn tidak dijalankan dan TryRun
+	// mengembalikan false.
+	TryRun(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// KYCRepository menyimpan pengajuan KYC beserta metadata dokumennya.
+type KYCRepository interface {
+	// CreateSubmissionWithTx menyimpan pengajuan beserta Documents.
+	CreateSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, submission *domain.KYCSubmission) error
+	// HasPendingSubmissionWithTx melaporkan apakah user memiliki pengajuan
+	// yang belum diputuskan.
+	HasPendingSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID) (bool, error)
+	// FindSubmissionForUpdateWithTx membaca pengajuan tanpa dokumen dan
+	// menguncinya sampai dbTx selesai, atau gorm.ErrRecordNotFound.
+	FindSubmissionForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, submissionID uuid.UUID) (*domain.KYCSubmission, error)
+	// UpdateSubmissionWithTx menyimpan status pengajuan tanpa dokumennya.
+	UpdateSubmissionWithTx(ctx context.Context, dbTx *gorm.DB, submission *domain.KYCSubmission) error
+	FindSubmissionByID(ctx context.Context, submissionID uuid.UUID) (*domain.KYCSubmission, error)
+	// FindSubmissions mengembalikan paling banyak limit pengajuan dengan
+	// status tertentu (kosong berarti semua), dari yang terlama agar antrean
+	// diproses berurutan.
+	FindSubmissions(ctx context.Context, status string, limit int) ([]domain.KYCSubmission, error)
+	// FindSubmissionsByUser mengembalikan semua pengajuan user, dari yang
+	// terbaru.
+	FindSubmissionsByUser(ctx context.Context, userID uuid.UUID) ([]domain.KYCSubmission, error)
+}
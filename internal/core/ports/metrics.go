@@ -0,0 +1,19 @@
+package ports
+
+// Metrics mencatat metrik bisnis dari core service tanpa bergantung pada
+// library monitoring tertentu.
+type Metrics interface {
+	// RecordTransaction mencatat satu percobaan pergerakan dana. operation
+	// adalah kategori transaksi (DEPOSIT, WITHDRAW, TRANSFER, ADJUSTMENT) dan
+	// outcome adalah hasilnya, misalnya success atau insufficient_balance.
+	RecordTransaction(operation, outcome string, amount float64)
+	// RecordLoginFailure mencatat login yang gagal beserta alasannya.
+	RecordLoginFailure(reason string)
+}
+
+// NoopMetrics mengabaikan semua metrik. Dipakai sebagai default service.
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordTransaction(operation, outcome string, amount float64) {}
+
+func (NoopMetrics) RecordLoginFailure(reason string) {}
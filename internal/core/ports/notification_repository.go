@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+type NotificationRepository interface {
+	// FindPreference mengembalikan gorm.ErrRecordNotFound jika user belum
+	// pernah mengatur preferensinya.
+	FindPreference(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *domain.NotificationPreference) error
+	// CreateDelivery mengabaikan delivery yang sudah ada untuk event, user, template dan kanal yang sama.
+	CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error
+	// ClaimDueDeliveries mengambil pesan yang jatuh tempo dan memundurkan
+	// next_attempt_at sebesar lease, sehingga replika lain tidak mengirimnya
+	// bersamaan selama lease berlaku.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.NotificationDelivery, error)
+	// FindDeliveriesByUser mengembalikan delivery terbaru lebih dulu.
+	FindDeliveriesByUser(ctx context.Context, userID uuid.UUID, limit int) ([]domain.NotificationDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error
+}
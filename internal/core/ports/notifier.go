@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// Notifier mengirim pesan ke user melalui satu kanal, misalnya penyedia SMS
+// atau email. Error membuat pesan dicoba ulang, sehingga pesan dapat terkirim
+// lebih dari sekali.
+type Notifier interface {
+	Send(ctx context.Context, message domain.NotificationMessage) error
+}
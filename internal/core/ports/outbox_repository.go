@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type OutboxRepository interface {
+	CreateWithTx(ctx context.Context, dbTx *gorm.DB, event *domain.OutboxEvent) error
+	// FindUnpublished mengembalikan event yang belum dipublikasikan, diurutkan
+	// berdasarkan EventID.
+	FindUnpublished(ctx context.Context, limit int) ([]domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, eventID uint64, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, eventID uint64, reason string) error
+}
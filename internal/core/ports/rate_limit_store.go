@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// RateLimitStore menyimpan token bucket rate limiter. Adapter bersama
+// (database) membuat limit berlaku di semua replika.
+type RateLimitStore interface {
+	// Take menjalankan policy.Take pada bucket key secara atomik.
+	Take(ctx context.Context, key string, policy domain.RateLimitPolicy, now time.Time) (domain.RateLimitDecision, error)
+	// Purge menghapus bucket yang sudah penuh kembali sebelum now.
+	Purge(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type ReconciliationRepository interface {
+	FindUsers(ctx context.Context) ([]domain.User, error)
+	// FindUserTransactions mengembalikan seluruh transaksi user dari yang paling lama.
+	FindUserTransactions(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error)
+	// FindOrphanedTransactions mengembalikan transaksi yang user-nya tidak ada.
+	FindOrphanedTransactions(ctx context.Context) ([]domain.Transaction, error)
+	WithTx(dbTx *gorm.DB) ReconciliationRepository
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// ScreeningRepository menyimpan kasus screening daftar pantauan.
+type ScreeningRepository interface {
+	// CreateHitWithTx menyimpan hit kecuali entri yang sama sudah pernah
+	// tercatat untuk user tersebut. Mengembalikan false jika hit dilewati.
+	CreateHitWithTx(ctx context.Context, dbTx *gorm.DB, hit *domain.ScreeningHit) (bool, error)
+	// HasPendingHitWithTx melaporkan apakah user memiliki kasus PENDING
+	// dengan skor minimal minScore.
+	HasPendingHitWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, minScore float64) (bool, error)
+	// FindHitForUpdateWithTx membaca kasus dan menguncinya sampai dbTx
+	// selesai, atau gorm.ErrRecordNotFound.
+	FindHitForUpdateWithTx(ctx context.Context, dbTx *gorm.DB, hitID uuid.UUID) (*domain.ScreeningHit, error)
+	UpdateHitWithTx(ctx context.Context, dbTx *gorm.DB, hit *domain.ScreeningHit) error
+	FindHitByID(ctx context.Context, hitID uuid.UUID) (*domain.ScreeningHit, error)
+	// FindHits mengembalikan paling banyak limit kasus dengan status tertentu
+	// (kosong berarti semua), dari yang terbaru.
+	FindHits(ctx context.Context, status string, limit int) ([]domain.ScreeningHit, error)
+}
@@ -1,13 +1,33 @@
 package ports
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
 )
 
 type TransactionRepository interface {
-	Create(tx *domain.Transaction) error
-	CreateWithTx(dbTx *gorm.DB, tx *domain.Transaction) error
-	FindByUser(userID uuid.UUID) ([]domain.Transaction, error)
+	Create(ctx context.Context, tx *domain.Transaction) error
+	CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error)
+	// FindByUserBetween mengembalikan transaksi user pada periode [from, to),
+	// diurutkan dari yang paling lama.
+	FindByUserBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.Transaction, error)
+	// FindLastBefore mengembalikan transaksi terakhir user sebelum waktu before,
+	// atau gorm.ErrRecordNotFound jika belum ada.
+	FindLastBefore(ctx context.Context, userID uuid.UUID, before time.Time) (*domain.Transaction, error)
+	// FindPage mengembalikan paling banyak limit transaksi user yang cocok dengan
+	// filter, diurutkan dari yang terbaru, dimulai setelah posisi after (nil
+	// berarti dari awal).
+	FindPage(ctx context.Context, userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error)
+	// FindAfter mengembalikan paling banyak limit transaksi user setelah posisi
+	// after, diurutkan dari yang paling lama.
+	FindAfter(ctx context.Context, userID uuid.UUID, after domain.TransactionCursor, limit int) ([]domain.Transaction, error)
+	// UsageSinceWithTx menjumlahkan transaksi DEBIT user pada kategori tertentu
+	// sejak waktu since, di dalam transaksi database dbTx.
+	UsageSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (domain.LimitUsage, error)
 }
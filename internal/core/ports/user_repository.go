@@ -1,15 +1,21 @@
 package ports
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
 )
 
 type UserRepository interface {
-	Create(user *domain.User) error
-	FindByPhoneNumber(phoneNumber string) (*domain.User, error)
-	FindByID(id uuid.UUID) (*domain.User, error)
-	Update(user *domain.User) error
-	UpdatePin(userID uuid.UUID, hashedPin string) error
-	SetActive(userID uuid.UUID, active bool) error
+	Create(ctx context.Context, user *domain.User) error
+	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error)
+	Update(ctx context.Context, user *domain.User) error
+	UpdatePin(ctx context.Context, userID uuid.UUID, hashedPin string) error
+	SetActive(ctx context.Context, userID uuid.UUID, active bool) error
+	SetFrozen(ctx context.Context, userID uuid.UUID, frozen bool) error
+	// WithTx mengembalikan repository yang menjalankan query di dalam transaksi dbTx.
+	WithTx(dbTx *gorm.DB) UserRepository
 }
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// WatchlistSource memuat seluruh entri daftar pantauan untuk screening.
+type WatchlistSource interface {
+	Load(ctx context.Context) ([]domain.WatchlistEntry, error)
+}
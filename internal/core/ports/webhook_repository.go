@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	FindSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	FindSubscriptionsByOwner(ctx context.Context, ownerID uuid.UUID) ([]domain.WebhookSubscription, error)
+	FindActiveSubscriptionsByOwners(ctx context.Context, ownerIDs []uuid.UUID) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	// CreateDelivery mengabaikan delivery yang sudah ada untuk subscription dan event yang sama.
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FindDelivery(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	FindDeliveriesBySubscription(ctx context.Context, subscriptionID uuid.UUID) ([]domain.WebhookDelivery, error)
+	// ClaimDueDeliveries mengambil delivery yang jatuh tempo dan memundurkan
+	// next_attempt_at sebesar lease, sehingga replika lain tidak mengirimnya
+	// bersamaan selama lease berlaku.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	CreateAttempt(ctx context.Context, attempt *domain.WebhookAttempt) error
+	FindAttempts(ctx context.Context, deliveryID uuid.UUID) ([]domain.WebhookAttempt, error)
+}
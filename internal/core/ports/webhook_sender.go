@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// WebhookSender mengirim payload webhook ke URL partner dan mengembalikan
+// status code HTTP yang diterima.
+type WebhookSender interface {
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// auditVerifyBatchSize adalah jumlah entri yang dibaca per query saat
+// verifikasi, agar audit log besar tidak dimuat sekaligus.
+const auditVerifyBatchSize = 500
+
+// auditRedacted menggantikan nilai rahasia (PIN) pada diff audit.
+const auditRedacted = "[REDACTED]"
+
+type auditActorKey struct{}
+
+// WithAuditActor menyimpan pelaku aksi di ctx. Adapter memanggilnya untuk
+// setiap request: HTTP dan gRPC dengan user yang login beserta IP dan user
+// agent-nya, hexctl dengan nama operator.
+func WithAuditActor(ctx context.Context, actor domain.AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFrom mengembalikan pelaku dari ctx. Tanpa pelaku, aksi dianggap
+// dilakukan sistem (scheduler bunga dan rekonsiliasi).
+func AuditActorFrom(ctx context.Context) domain.AuditActor {
+	if actor, ok := ctx.Value(auditActorKey{}).(domain.AuditActor); ok {
+		return actor
+	}
+	return domain.AuditActor{Type: domain.ActorSystem}
+}
+
+// AuditRecord adalah isi entri audit sebelum diberi nomor urut dan hash.
+type AuditRecord struct {
+	Action   string
+	TargetID *uuid.UUID
+	Changes  map[string]domain.AuditChange
+	Metadata map[string]interface{}
+	// Actor menimpa pelaku dari context, mis. user yang baru berhasil login.
+	Actor *domain.AuditActor
+}
+
+// AuditService menulis audit log berantai hash dan memverifikasinya.
+type AuditService struct {
+	auditRepo ports.AuditRepository
+	db        *gorm.DB
+	now       func() time.Time
+}
+
+func NewAuditService(auditRepo ports.AuditRepository, db *gorm.DB) *AuditService {
+	return &AuditService{auditRepo: auditRepo, db: db, now: time.Now}
+}
+
+// Record menambahkan entri di dalam dbTx sehingga entri tersimpan jika dan
+// hanya jika perubahan yang diauditnya tersimpan. dbTx nil berarti entri
+// ditulis dalam transaksi tersendiri.
+func (s *AuditService) Record(ctx context.Context, dbTx *gorm.DB, record AuditRecord) error {
+	if dbTx == nil {
+		return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return s.Record(ctx, tx, record)
+		})
+	}
+
+	actor := AuditActorFrom(ctx)
+	if record.Actor != nil {
+		actor = *record.Actor
+	}
+	entry := domain.AuditEntry{
+		OccurredAt: s.now().UTC().Truncate(time.Microsecond),
+		ActorType:  actor.Type,
+		ActorID:    actor.ID,
+		ActorName:  actor.Name,
+		Action:     record.Action,
+		TargetID:   record.TargetID,
+		IPAddress:  actor.IPAddress,
+		UserAgent:  actor.UserAgent,
+		RequestID:  actor.RequestID,
+	}
+	if len(record.Changes) > 0 {
+		data, err := json.Marshal(record.Changes)
+		if err != nil {
+			return err
+		}
+		entry.Changes = string(data)
+	}
+	if len(record.Metadata) > 0 {
+		data, err := json.Marshal(record.Metadata)
+		if err != nil {
+			return err
+		}
+		entry.Metadata = string(data)
+	}
+
+	last, err := s.auditRepo.LastWithTx(ctx, dbTx)
+	if err != nil {
+		return err
+	}
+	entry.Sequence, entry.PrevHash = 1, domain.AuditGenesisHash
+	if last != nil {
+		entry.Sequence, entry.PrevHash = last.Sequence+1, last.Hash
+	}
+	entry.Hash = entry.ComputeHash()
+	return s.auditRepo.CreateWithTx(ctx, dbTx, &entry)
+}
+
+// List mengembalikan entri sesuai filter dari yang terbaru. Jika masih ada
+// entri berikutnya, next berisi nilai before untuk halaman selanjutnya.
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter, before int64, limit int) (entries []domain.AuditEntry, next *int64, err error) {
+	entries, err = s.auditRepo.Find(ctx, filter, before, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		seq := entries[limit-1].Sequence
+		next = &seq
+	}
+	return entries, next, nil
+}
+
+// Verify membaca seluruh audit log dari entri pertama dan memeriksa nomor
+// urut tanpa celah, PrevHash yang sama dengan hash entri sebelumnya, dan hash
+// setiap entri. Pemeriksaan berhenti pada kerusakan pertama.
+func (s *AuditService) Verify(ctx context.Context) (_ *domain.AuditVerification, err error) {
+	ctx, span := startSpan(ctx, "AuditService.Verify")
+	defer func() { endSpan(span, err) }()
+
+	result := &domain.AuditVerification{Valid: true}
+	prevHash := domain.AuditGenesisHash
+	for {
+		entries, err := s.auditRepo.FindAfter(ctx, result.LastSequence, auditVerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			reason := ""
+			switch {
+			case entry.Sequence != result.LastSequence+1:
+				reason = fmt.Sprintf("expected sequence %d, found %d", result.LastSequence+1, entry.Sequence)
+			case entry.PrevHash != prevHash:
+				reason = "previous hash does not match the preceding entry"
+			case entry.Hash != entry.ComputeHash():
+				reason = "entry content does not match its hash"
+			}
+			if reason != "" {
+				seq := entry.Sequence
+				result.Valid, result.BrokenAt, result.Reason = false, &seq, reason
+				return result, nil
+			}
+			result.EntriesChecked++
+			result.LastSequence, result.LastHash = entry.Sequence, entry.Hash
+			prevHash = entry.Hash
+		}
+		if len(entries) < auditVerifyBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// recordAudit menulis entri audit jika audit aktif, setara recordEvent untuk
+// outbox.
+func recordAudit(ctx context.Context, audit *AuditService, dbTx *gorm.DB, record AuditRecord) error {
+	if audit == nil {
+		return nil
+	}
+	return audit.Record(ctx, dbTx, record)
+}
+
+// balanceChange membentuk diff saldo untuk entri audit pergerakan dana.
+func balanceChange(txn domain.Transaction) map[string]domain.AuditChange {
+	return map[string]domain.AuditChange{"balance": {Before: txn.BalanceBefore, After: txn.BalanceAfter}}
+}
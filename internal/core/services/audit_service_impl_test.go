@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+)
+
+func setupAuditDB(t *testing.T) (*gorm.DB, *AuditService) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.AuditEntry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db, NewAuditService(repository.NewAuditRepositoryImpl(db), db)
+}
+
+func recordAuditEntries(t *testing.T, audit *AuditService, actions ...string) {
+	t.Helper()
+	for _, action := range actions {
+		if err := audit.Record(context.Background(), nil, AuditRecord{Action: action}); err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+}
+
+func TestAuditServiceRecordChainsEntries(t *testing.T) {
+	db, audit := setupAuditDB(t)
+	recordAuditEntries(t, audit, domain.AuditDeposit, domain.AuditWithdraw, domain.AuditTransfer)
+
+	var entries []domain.AuditEntry
+	db.Order("sequence").Find(&entries)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	prevHash := domain.AuditGenesisHash
+	for i, entry := range entries {
+		if entry.Sequence != int64(i+1) || entry.PrevHash != prevHash || entry.Hash != entry.ComputeHash() {
+			t.Fatalf("entry %d is not chained: %+v", i, entry)
+		}
+		if entry.ActorType != domain.ActorSystem {
+			t.Fatalf("expected SYSTEM actor without actor in context, got %s", entry.ActorType)
+		}
+		prevHash = entry.Hash
+	}
+
+	result, err := audit.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if !result.Valid || result.EntriesChecked != 3 || result.LastSequence != 3 || result.LastHash != prevHash {
+		t.Fatalf("unexpected verification: %+v", result)
+	}
+}
+
+func TestAuditServiceVerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(db *gorm.DB)
+		brokenAt int64
+		reason   string
+	}{
+		{
+			name: "modified entry",
+			tamper: func(db *gorm.DB) {
+				db.Model(&domain.AuditEntry{}).Where("sequence = ?", 2).Update("action", domain.AuditAdjustment)
+			},
+			brokenAt: 2,
+			reason:   "content",
+		},
+		{
+			name: "modified entry with recomputed hash",
+			tamper: func(db *gorm.DB) {
+				var entry domain.AuditEntry
+				db.First(&entry, "sequence = ?", 2)
+				entry.Action = domain.AuditAdjustment
+				db.Model(&entry).Updates(map[string]interface{}{"action": entry.Action, "hash": entry.ComputeHash()})
+			},
+			brokenAt: 3,
+			reason:   "previous hash",
+		},
+		{
+			name: "deleted entry",
+			tamper: func(db *gorm.DB) {
+				db.Delete(&domain.AuditEntry{}, "sequence = ?", 2)
+			},
+			brokenAt: 3,
+			reason:   "expected sequence 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, audit := setupAuditDB(t)
+			recordAuditEntries(t, audit, domain.AuditDeposit, domain.AuditWithdraw, domain.AuditTransfer)
+			tt.tamper(db)
+
+			result, err := audit.Verify(context.Background())
+			if err != nil {
+				t.Fatalf("Verify returned error: %v", err)
+			}
+			if result.Valid || result.BrokenAt == nil || *result.BrokenAt != tt.brokenAt || !strings.Contains(result.Reason, tt.reason) {
+				t.Fatalf("expected chain broken at %d (%s), got %+v", tt.brokenAt, tt.reason, result)
+			}
+		})
+	}
+}
+
+func TestAuditServiceList(t *testing.T) {
+	_, audit := setupAuditDB(t)
+	recordAuditEntries(t, audit, domain.AuditLogin, domain.AuditDeposit, domain.AuditLogin, domain.AuditDeposit, domain.AuditLogin)
+
+	page, next, err := audit.List(context.Background(), domain.AuditFilter{}, 0, 2)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(page) != 2 || page[0].Sequence != 5 || page[1].Sequence != 4 || next == nil || *next != 4 {
+		t.Fatalf("unexpected first page: %+v next=%v", page, next)
+	}
+	page, next, err = audit.List(context.Background(), domain.AuditFilter{Action: domain.AuditLogin}, *next, 2)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(page) != 2 || page[0].Sequence != 3 || page[1].Sequence != 1 || next != nil {
+		t.Fatalf("unexpected filtered page: %+v next=%v", page, next)
+	}
+}
+
+func TestTransactionServiceWritesAuditEntry(t *testing.T) {
+	db, audit := setupAuditDB(t)
+	service := NewTransactionService(&testTransactionRepo{db: db}, db, WithTransactionAudit(audit))
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	db.Create(&user)
+	ctx := WithAuditActor(context.Background(), domain.AuditActor{
+		Type: domain.ActorUser, ID: &user.UserID, IPAddress: "10.0.0.1", UserAgent: "app/1.0", RequestID: "req-1",
+	})
+
+	if _, err := service.Deposit(ctx, user.UserID, 50, "deposit"); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	if _, err := service.Withdraw(ctx, user.UserID, 500, "withdraw"); err == nil {
+		t.Fatalf("expected insufficient balance error")
+	}
+
+	var entries []domain.AuditEntry
+	db.Find(&entries)
+	if len(entries) != 1 {
+		t.Fatalf("expected only the successful deposit to be audited, got %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Action != domain.AuditDeposit || entry.ActorID == nil || *entry.ActorID != user.UserID ||
+		entry.IPAddress != "10.0.0.1" || entry.UserAgent != "app/1.0" || entry.RequestID != "req-1" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	var changes map[string]domain.AuditChange
+	if err := json.Unmarshal([]byte(entry.Changes), &changes); err != nil {
+		t.Fatalf("invalid changes: %v", err)
+	}
+	if changes["balance"].Before != float64(100) || changes["balance"].After != float64(150) {
+		t.Fatalf("unexpected balance change: %+v", changes)
+	}
+}
+
+func TestUserServiceWritesAuditEntries(t *testing.T) {
+	db, audit := setupAuditDB(t)
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("123456"), bcrypt.MinCost)
+	user := domain.User{UserID: uuid.New(), FirstName: "A", PhoneNumber: "0811", Pin: string(hashed), IsActive: true}
+	db.Create(&user)
+	service := NewUserService(repository.NewUserRepositoryImpl(db), WithUserAudit(db, audit), WithBcryptCost(bcrypt.MinCost))
+	ctx := WithAuditActor(context.Background(), domain.AuditActor{Type: domain.ActorAnonymous, IPAddress: "10.0.0.1"})
+
+	if _, err := service.Login(ctx, "0811", "000000"); err == nil {
+		t.Fatalf("expected invalid PIN error")
+	}
+	if _, err := service.Login(ctx, "0811", "123456"); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	if err := service.ChangePin(ctx, user.UserID, "123456", "654321"); err != nil {
+		t.Fatalf("ChangePin returned error: %v", err)
+	}
+	if err := service.SetActive(ctx, user.UserID, false); err != nil {
+		t.Fatalf("SetActive returned error: %v", err)
+	}
+
+	var entries []domain.AuditEntry
+	db.Order("sequence").Find(&entries)
+	want := []struct {
+		action    string
+		actorType string
+		changes   string
+	}{
+		{domain.AuditLoginFailed, domain.ActorAnonymous, ""},
+		{domain.AuditLogin, domain.ActorUser, ""},
+		{domain.AuditPinChanged, domain.ActorAnonymous, `{"pin":{"before":"[REDACTED]","after":"[REDACTED]"}}`},
+		{domain.AuditAccountDeactivated, domain.ActorAnonymous, `{"is_active":{"before":true,"after":false}}`},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Action != w.action || e.ActorType != w.actorType || e.Changes != w.changes ||
+			e.TargetID == nil || *e.TargetID != user.UserID || e.IPAddress != "10.0.0.1" {
+			t.Fatalf("entry %d: expected %+v, got %+v", i, w, e)
+		}
+	}
+	if strings.Contains(entries[2].Changes, "654321") {
+		t.Fatalf("PIN leaked into audit log: %s", entries[2].Changes)
+	}
+}
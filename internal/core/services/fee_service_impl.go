@@ -0,0 +1,81 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// FeeService menghitung biaya transaksi berdasarkan aturan per jenis operasi.
+// Biaya yang terkumpul dibukukan ke akun pendapatan biaya (revenueAccountID).
+type FeeService struct {
+	rules            map[string]domain.FeeRule
+	revenueAccountID uuid.UUID
+}
+
+func NewFeeService(rules []domain.FeeRule, revenueAccountID uuid.UUID) *FeeService {
+	ruleMap := make(map[string]domain.FeeRule, len(rules))
+	for _, rule := range rules {
+		tiers := append([]domain.FeeTier(nil), rule.Tiers...)
+		sort.SliceStable(tiers, func(i, j int) bool {
+			// tier tanpa batas atas selalu diletakkan paling akhir
+			if tiers[i].UpTo == 0 {
+				return false
+			}
+			return tiers[j].UpTo == 0 || tiers[i].UpTo < tiers[j].UpTo
+		})
+		rule.Tiers = tiers
+		ruleMap[rule.Operation] = rule
+	}
+	return &FeeService{rules: ruleMap, revenueAccountID: revenueAccountID}
+}
+
+func (s *FeeService) RevenueAccountID() uuid.UUID {
+	return s.revenueAccountID
+}
+
+// Quote menghitung biaya untuk operasi dan nominal tertentu. Biaya dibebaskan
+// jika tier akun pengguna termasuk dalam WaivedTiers.
+func (s *FeeService) Quote(operation string, amount float64, accountTier string) domain.FeeQuote {
+	quote := domain.FeeQuote{Operation: operation, Amount: amount, Total: amount}
+	rule, ok := s.rules[operation]
+	if !ok {
+		return quote
+	}
+	if accountTier == "" {
+		accountTier = domain.AccountTierRegular
+	}
+	for _, tier := range rule.WaivedTiers {
+		if tier == accountTier {
+			quote.Waived = true
+			return quote
+		}
+	}
+
+	var fee float64
+	switch rule.Type {
+	case domain.FeeTypeFlat:
+		fee = rule.Flat
+	case domain.FeeTypePercentage:
+		fee = amount * rule.Percentage / 100
+	case domain.FeeTypeTiered:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.Flat + amount*tier.Percentage/100
+				break
+			}
+		}
+	}
+	if rule.Min > 0 && fee < rule.Min {
+		fee = rule.Min
+	}
+	if rule.Max > 0 && fee > rule.Max {
+		fee = rule.Max
+	}
+
+	quote.Fee = math.Round(fee*100) / 100
+	quote.Total = amount + quote.Fee
+	return quote
+}
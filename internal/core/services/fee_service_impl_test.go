@@ -0,0 +1,49 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestFeeServiceQuote(t *testing.T) {
+	service := NewFeeService([]domain.FeeRule{
+		{Operation: domain.CategoryWithdraw, Type: domain.FeeTypePercentage, Percentage: 1, Min: 2, Max: 10},
+		{
+			Operation: domain.CategoryTransfer,
+			Type:      domain.FeeTypeTiered,
+			Tiers: []domain.FeeTier{
+				{UpTo: 0, Percentage: 0.5},
+				{UpTo: 100, Flat: 1},
+			},
+			WaivedTiers: []string{domain.AccountTierPremium},
+		},
+	}, uuid.New())
+
+	cases := []struct {
+		name      string
+		operation string
+		amount    float64
+		tier      string
+		fee       float64
+		waived    bool
+	}{
+		{"percentage", domain.CategoryWithdraw, 500, domain.AccountTierRegular, 5, false},
+		{"percentage min", domain.CategoryWithdraw, 50, domain.AccountTierRegular, 2, false},
+		{"percentage max", domain.CategoryWithdraw, 5000, domain.AccountTierRegular, 10, false},
+		{"tiered lower", domain.CategoryTransfer, 100, "", 1, false},
+		{"tiered upper", domain.CategoryTransfer, 1000, domain.AccountTierRegular, 5, false},
+		{"waived tier", domain.CategoryTransfer, 1000, domain.AccountTierPremium, 0, true},
+		{"no rule", domain.CategoryDeposit, 1000, domain.AccountTierRegular, 0, false},
+	}
+	for _, tc := range cases {
+		quote := service.Quote(tc.operation, tc.amount, tc.tier)
+		if quote.Fee != tc.fee || quote.Waived != tc.waived {
+			t.Errorf("%s: expected fee %v waived %v, got %+v", tc.name, tc.fee, tc.waived, quote)
+		}
+		if quote.Total != tc.amount+tc.fee {
+			t.Errorf("%s: expected total %v, got %v", tc.name, tc.amount+tc.fee, quote.Total)
+		}
+	}
+}
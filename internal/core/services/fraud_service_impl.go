@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// ErrFraudReview dan ErrFraudBlocked dapat dicocokkan dengan errors.Is untuk
+// FraudDecisionError sesuai status kasusnya.
+var (
+	ErrFraudReview  = errors.New("transaction held for fraud review")
+	ErrFraudBlocked = errors.New("transaction blocked by fraud rules")
+)
+
+// ErrReviewNotPending dikembalikan saat menyetujui atau menolak kasus yang
+// sudah diputuskan.
+var ErrReviewNotPending = errors.New("fraud review is not pending")
+
+// FraudDecisionError dikembalikan Withdraw dan Transfer ketika mesin fraud
+// menahan atau memblokir transaksi. Review sudah tersimpan dan dana belum
+// dipindahkan.
+type FraudDecisionError struct {
+	Review *domain.FraudReview
+}
+
+func (e *FraudDecisionError) Error() string {
+	if e.Review.Status == domain.FraudReviewBlocked {
+		return ErrFraudBlocked.Error()
+	}
+	return ErrFraudReview.Error()
+}
+
+func (e *FraudDecisionError) Is(target error) bool {
+	if e.Review.Status == domain.FraudReviewBlocked {
+		return target == ErrFraudBlocked
+	}
+	return target == ErrFraudReview
+}
+
+// FraudService menilai penarikan dan transfer dengan aturan dari
+// domain.FraudConfig dan menyimpan kasus yang ditahan untuk analis.
+type FraudService struct {
+	fraudRepo ports.FraudRepository
+	config    domain.FraudConfig
+	location  *time.Location
+	now       func() time.Time
+}
+
+func NewFraudService(fraudRepo ports.FraudRepository, config domain.FraudConfig) *FraudService {
+	location := time.UTC
+	if config.UnusualHour != nil && config.UnusualHour.Timezone != "" {
+		if loc, err := time.LoadLocation(config.UnusualHour.Timezone); err == nil {
+			location = loc
+		}
+	}
+	return &FraudService{fraudRepo: fraudRepo, config: config, location: location, now: time.Now}
+}
+
+// Assess menjalankan semua aturan aktif di dalam dbTx dan menjumlahkan skor
+// aturan yang terpicu menjadi keputusan ALLOW, REVIEW, atau BLOCK.
+func (s *FraudService) Assess(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (domain.FraudAssessment, error) {
+	if check.At.IsZero() {
+		check.At = s.now()
+	}
+	rules := []func(context.Context, *gorm.DB, domain.FraudCheck) (*domain.FraudRuleHit, error){
+		s.velocity, s.newPayee, s.rapidCashOut, s.unusualHour, s.amountDeviation,
+	}
+	assessment := domain.FraudAssessment{Decision: domain.FraudDecisionAllow, Hits: []domain.FraudRuleHit{}}
+	for _, rule := range rules {
+		hit, err := rule(ctx, dbTx, check)
+		if err != nil {
+			return domain.FraudAssessment{}, err
+		}
+		if hit != nil {
+			assessment.Hits = append(assessment.Hits, *hit)
+			assessment.Score += hit.Score
+		}
+	}
+	switch {
+	case s.config.BlockScore > 0 && assessment.Score >= s.config.BlockScore:
+		assessment.Decision = domain.FraudDecisionBlock
+	case s.config.ReviewScore > 0 && assessment.Score >= s.config.ReviewScore:
+		assessment.Decision = domain.FraudDecisionReview
+	}
+	return assessment, nil
+}
+
+func (s *FraudService) velocity(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (*domain.FraudRuleHit, error) {
+	rule := s.config.Velocity
+	if rule == nil {
+		return nil, nil
+	}
+	since := check.At.Add(-time.Duration(rule.WindowMinutes) * time.Minute)
+	count, err := s.fraudRepo.CountDebitsSinceWithTx(ctx, dbTx, check.UserID, []string{domain.CategoryWithdraw, domain.CategoryTransfer}, since)
+	if err != nil || count < int64(rule.MaxCount) {
+		return nil, err
+	}
+	return &domain.FraudRuleHit{Rule: domain.FraudRuleVelocity, Score: rule.Score,
+		Detail: fmt.Sprintf("%d withdrawals and transfers in the last %d minutes", count, rule.WindowMinutes)}, nil
+}
+
+func (s *FraudService) newPayee(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (*domain.FraudRuleHit, error) {
+	rule := s.config.NewPayee
+	if rule == nil || check.CounterpartyID == nil || check.Amount < rule.MinAmount {
+		return nil, nil
+	}
+	known, err := s.fraudRepo.HasTransferredToWithTx(ctx, dbTx, check.UserID, *check.CounterpartyID)
+	if err != nil || known {
+		return nil, err
+	}
+	return &domain.FraudRuleHit{Rule: domain.FraudRuleNewPayee, Score: rule.Score,
+		Detail: fmt.Sprintf("first transfer to this payee is %v, at least %v", check.Amount, rule.MinAmount)}, nil
+}
+
+func (s *FraudService) rapidCashOut(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (*domain.FraudRuleHit, error) {
+	rule := s.config.RapidCashOut
+	if rule == nil {
+		return nil, nil
+	}
+	since := check.At.Add(-time.Duration(rule.WindowMinutes) * time.Minute)
+	deposited, err := s.fraudRepo.SumCreditsSinceWithTx(ctx, dbTx, check.UserID, domain.CategoryDeposit, since)
+	if err != nil || deposited <= 0 || check.Amount < rule.Ratio*deposited {
+		return nil, err
+	}
+	return &domain.FraudRuleHit{Rule: domain.FraudRuleRapidCashOut, Score: rule.Score,
+		Detail: fmt.Sprintf("moves out %v after %v was deposited in the last %d minutes", check.Amount, deposited, rule.WindowMinutes)}, nil
+}
+
+func (s *FraudService) unusualHour(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (*domain.FraudRuleHit, error) {
+	rule := s.config.UnusualHour
+	if rule == nil || check.Amount < rule.MinAmount {
+		return nil, nil
+	}
+	hour := check.At.In(s.location).Hour()
+	inWindow := hour >= rule.StartHour && hour < rule.EndHour
+	if rule.StartHour > rule.EndHour {
+		inWindow = hour >= rule.StartHour || hour < rule.EndHour
+	}
+	if !inWindow {
+		return nil, nil
+	}
+	return &domain.FraudRuleHit{Rule: domain.FraudRuleUnusualHour, Score: rule.Score,
+		Detail: fmt.Sprintf("made at %02d:00 %s", hour, s.location)}, nil
+}
+
+func (s *FraudService) amountDeviation(ctx context.Context, dbTx *gorm.DB, check domain.FraudCheck) (*domain.FraudRuleHit, error) {
+	rule := s.config.AmountDeviation
+	if rule == nil {
+		return nil, nil
+	}
+	since := check.At.AddDate(0, 0, -rule.LookbackDays)
+	amounts, err := s.fraudRepo.DebitAmountsSinceWithTx(ctx, dbTx, check.UserID, check.Operation, since)
+	if err != nil || len(amounts) < rule.MinSamples || len(amounts) == 0 {
+		return nil, err
+	}
+	mean, stddev := meanStdDev(amounts)
+	threshold := mean + rule.Multiplier*stddev
+	if check.Amount <= threshold {
+		return nil, nil
+	}
+	return &domain.FraudRuleHit{Rule: domain.FraudRuleAmountDeviation, Score: rule.Score,
+		Detail: fmt.Sprintf("amount %v exceeds usual %v (mean %.2f over %d transactions)", check.Amount, math.Round(threshold*100)/100, mean, len(amounts))}, nil
+}
+
+func meanStdDev(values []float64) (mean, stddev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stddev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(values)))
+}
+
+// ListReviews mengembalikan kasus dengan status tertentu dari yang terbaru.
+func (s *FraudService) ListReviews(ctx context.Context, status string, limit int) ([]domain.FraudReview, error) {
+	return s.fraudRepo.FindReviews(ctx, status, limit)
+}
+
+func (s *FraudService) GetReview(ctx context.Context, reviewID uuid.UUID) (*domain.FraudReview, error) {
+	return s.fraudRepo.FindReviewByID(ctx, reviewID)
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+)
+
+func setupFraudDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.FraudReview{}, &domain.AuditEntry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func seedTransaction(db *gorm.DB, userID uuid.UUID, txType, category string, amount float64, at time.Time, counterparty *uuid.UUID) {
+	db.Create(&transactionMigration{TransactionID: uuid.New(), UserID: userID, TransactionType: txType, Category: category,
+		Amount: amount, CounterpartyID: counterparty, CreatedAt: at})
+}
+
+func TestFraudServiceRules(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	userID, payee := uuid.New(), uuid.New()
+	tests := []struct {
+		name   string
+		config domain.FraudConfig
+		seed   func(db *gorm.DB)
+		check  domain.FraudCheck
+		want   string
+	}{
+		{
+			name:   "velocity",
+			config: domain.FraudConfig{Velocity: &domain.VelocityRule{Score: 10, WindowMinutes: 10, MaxCount: 2}},
+			seed: func(db *gorm.DB) {
+				seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryWithdraw, 10, now.Add(-5*time.Minute), nil)
+				seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryTransfer, 10, now.Add(-time.Minute), &payee)
+				seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryWithdraw, 10, now.Add(-time.Hour), nil)
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 10},
+			want:  domain.FraudRuleVelocity,
+		},
+		{
+			name:   "new payee",
+			config: domain.FraudConfig{NewPayee: &domain.NewPayeeRule{Score: 10, MinAmount: 100}},
+			check:  domain.FraudCheck{Operation: domain.CategoryTransfer, CounterpartyID: &payee, Amount: 100},
+			want:   domain.FraudRuleNewPayee,
+		},
+		{
+			name:   "known payee",
+			config: domain.FraudConfig{NewPayee: &domain.NewPayeeRule{Score: 10, MinAmount: 100}},
+			seed: func(db *gorm.DB) {
+				seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryTransfer, 10, now.AddDate(0, -1, 0), &payee)
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryTransfer, CounterpartyID: &payee, Amount: 100},
+		},
+		{
+			name:   "rapid cash out",
+			config: domain.FraudConfig{RapidCashOut: &domain.RapidCashOutRule{Score: 10, WindowMinutes: 30, Ratio: 0.9}},
+			seed: func(db *gorm.DB) {
+				seedTransaction(db, userID, domain.TransactionTypeCredit, domain.CategoryDeposit, 1000, now.Add(-10*time.Minute), nil)
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 950},
+			want:  domain.FraudRuleRapidCashOut,
+		},
+		{
+			name:   "cash out of older deposit",
+			config: domain.FraudConfig{RapidCashOut: &domain.RapidCashOutRule{Score: 10, WindowMinutes: 30, Ratio: 0.9}},
+			seed: func(db *gorm.DB) {
+				seedTransaction(db, userID, domain.TransactionTypeCredit, domain.CategoryDeposit, 1000, now.Add(-time.Hour), nil)
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 950},
+		},
+		{
+			name:   "unusual hour across midnight",
+			config: domain.FraudConfig{UnusualHour: &domain.UnusualHourRule{Score: 10, StartHour: 23, EndHour: 5, Timezone: "Asia/Jakarta"}},
+			check:  domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 10, At: time.Date(2026, 3, 10, 18, 30, 0, 0, time.UTC)},
+			want:   domain.FraudRuleUnusualHour,
+		},
+		{
+			name:   "usual hour",
+			config: domain.FraudConfig{UnusualHour: &domain.UnusualHourRule{Score: 10, StartHour: 23, EndHour: 5, Timezone: "Asia/Jakarta"}},
+			check:  domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 10},
+		},
+		{
+			name:   "amount deviation",
+			config: domain.FraudConfig{AmountDeviation: &domain.AmountDeviationRule{Score: 10, LookbackDays: 30, MinSamples: 3, Multiplier: 2}},
+			seed: func(db *gorm.DB) {
+				for _, amount := range []float64{90, 100, 110} {
+					seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryWithdraw, amount, now.AddDate(0, 0, -1), nil)
+				}
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 500},
+			want:  domain.FraudRuleAmountDeviation,
+		},
+		{
+			name:   "amount deviation without enough history",
+			config: domain.FraudConfig{AmountDeviation: &domain.AmountDeviationRule{Score: 10, LookbackDays: 30, MinSamples: 3, Multiplier: 2}},
+			seed: func(db *gorm.DB) {
+				seedTransaction(db, userID, domain.TransactionTypeDebit, domain.CategoryWithdraw, 100, now.AddDate(0, 0, -1), nil)
+			},
+			check: domain.FraudCheck{Operation: domain.CategoryWithdraw, Amount: 500},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupFraudDB(t)
+			if tt.seed != nil {
+				tt.seed(db)
+			}
+			tt.config.ReviewScore, tt.config.BlockScore = 10, 20
+			service := NewFraudService(repository.NewFraudRepositoryImpl(db), tt.config)
+			service.now = func() time.Time { return now }
+			tt.check.UserID = userID
+
+			assessment, err := service.Assess(context.Background(), db, tt.check)
+			if err != nil {
+				t.Fatalf("Assess returned error: %v", err)
+			}
+			if tt.want == "" {
+				if assessment.Decision != domain.FraudDecisionAllow || len(assessment.Hits) != 0 {
+					t.Fatalf("expected ALLOW without hits, got %+v", assessment)
+				}
+				return
+			}
+			if assessment.Decision != domain.FraudDecisionReview || len(assessment.Hits) != 1 || assessment.Hits[0].Rule != tt.want {
+				t.Fatalf("expected REVIEW by %s, got %+v", tt.want, assessment)
+			}
+		})
+	}
+}
+
+func setupFraudTransactionService(t *testing.T) (*gorm.DB, *TransactionService, domain.User, domain.User) {
+	db := setupFraudDB(t)
+	fraud := NewFraudService(repository.NewFraudRepositoryImpl(db), domain.FraudConfig{
+		ReviewScore:  50,
+		BlockScore:   100,
+		NewPayee:     &domain.NewPayeeRule{Score: 50, MinAmount: 100},
+		RapidCashOut: &domain.RapidCashOutRule{Score: 50, WindowMinutes: 60, Ratio: 0.8},
+	})
+	audit := NewAuditService(repository.NewAuditRepositoryImpl(db), db)
+	service := NewTransactionService(&testTransactionRepo{db: db}, db, WithFraudService(fraud), WithTransactionAudit(audit))
+	from := domain.User{UserID: uuid.New(), FirstName: "A", PhoneNumber: "111", Pin: "1234", Balance: 1000}
+	to := domain.User{UserID: uuid.New(), FirstName: "B", PhoneNumber: "222", Pin: "1234"}
+	db.Create(&from)
+	db.Create(&to)
+	return db, service, from, to
+}
+
+func TestTransactionServiceHoldsTransferForReview(t *testing.T) {
+	db, service, from, to := setupFraudTransactionService(t)
+	ctx := WithAuditActor(context.Background(), domain.AuditActor{Type: domain.ActorAdmin, Name: "analyst"})
+
+	_, _, err := service.Transfer(context.Background(), from.UserID, to.UserID, 200, "rent")
+	var fraudErr *FraudDecisionError
+	if !errors.Is(err, ErrFraudReview) || !errors.As(err, &fraudErr) {
+		t.Fatalf("expected transfer held for review, got %v", err)
+	}
+	review := fraudErr.Review
+	if review.Status != domain.FraudReviewPending || review.Score != 50 || *review.CounterpartyID != to.UserID {
+		t.Fatalf("unexpected review: %+v", review)
+	}
+	var balance domain.User
+	db.First(&balance, "user_id = ?", from.UserID)
+	if balance.Balance != 1000 {
+		t.Fatalf("expected no funds moved while held, got balance %v", balance.Balance)
+	}
+
+	approved, err := service.ApproveReview(ctx, review.ReviewID, "customer confirmed")
+	if err != nil {
+		t.Fatalf("ApproveReview returned error: %v", err)
+	}
+	if approved.Status != domain.FraudReviewApproved || approved.ReviewedBy != "analyst" || approved.ReviewedAt == nil || approved.TransactionID == nil {
+		t.Fatalf("unexpected approved review: %+v", approved)
+	}
+	var payee domain.User
+	db.First(&payee, "user_id = ?", to.UserID)
+	if payee.Balance != 200 {
+		t.Fatalf("expected payee balance 200, got %v", payee.Balance)
+	}
+	if _, err := service.ApproveReview(ctx, review.ReviewID, ""); !errors.Is(err, ErrReviewNotPending) {
+		t.Fatalf("expected ErrReviewNotPending, got %v", err)
+	}
+
+	// Penerima yang sudah pernah ditransfer tidak lagi memicu new_payee.
+	if _, _, err := service.Transfer(context.Background(), from.UserID, to.UserID, 200, "rent"); err != nil {
+		t.Fatalf("expected transfer to known payee to pass, got %v", err)
+	}
+
+	var actions []string
+	db.Model(&domain.AuditEntry{}).Order("sequence").Pluck("action", &actions)
+	want := []string{domain.AuditTransactionHeld, domain.AuditTransfer, domain.AuditReviewApproved, domain.AuditTransfer}
+	if len(actions) != len(want) {
+		t.Fatalf("expected audit actions %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Fatalf("expected audit actions %v, got %v", want, actions)
+		}
+	}
+}
+
+func TestTransactionServiceBlocksWithdraw(t *testing.T) {
+	db, service, from, _ := setupFraudTransactionService(t)
+	if _, err := service.Deposit(context.Background(), from.UserID, 1000, "topup"); err != nil {
+		t.Fatalf("Deposit returned error: %v", err)
+	}
+	if _, err := service.Withdraw(context.Background(), from.UserID, 900, "cash"); !errors.Is(err, ErrFraudReview) {
+		t.Fatalf("expected rapid cash out to be held, got %v", err)
+	}
+
+	// Skor new_payee dan rapid_cash_out mencapai block_score.
+	payee := domain.User{UserID: uuid.New(), FirstName: "C", PhoneNumber: "333", Pin: "1234"}
+	db.Create(&payee)
+	_, _, err := service.Transfer(context.Background(), from.UserID, payee.UserID, 900, "")
+	if !errors.Is(err, ErrFraudBlocked) {
+		t.Fatalf("expected transfer to be blocked, got %v", err)
+	}
+	var fraudErr *FraudDecisionError
+	errors.As(err, &fraudErr)
+	if _, err := service.ApproveReview(context.Background(), fraudErr.Review.ReviewID, ""); !errors.Is(err, ErrReviewNotPending) {
+		t.Fatalf("expected blocked review to be final, got %v", err)
+	}
+
+	var reviews []domain.FraudReview
+	db.Order("created_at").Find(&reviews)
+	if len(reviews) != 2 || reviews[0].Status != domain.FraudReviewPending || reviews[1].Status != domain.FraudReviewBlocked || reviews[1].Score != 100 {
+		t.Fatalf("unexpected reviews: %+v", reviews)
+	}
+}
+
+func TestTransactionServiceRejectReview(t *testing.T) {
+	db, service, from, to := setupFraudTransactionService(t)
+	_, _, err := service.Transfer(context.Background(), from.UserID, to.UserID, 200, "")
+	var fraudErr *FraudDecisionError
+	if !errors.As(err, &fraudErr) {
+		t.Fatalf("expected transfer held for review, got %v", err)
+	}
+
+	rejected, err := service.RejectReview(context.Background(), fraudErr.Review.ReviewID, "mule account")
+	if err != nil {
+		t.Fatalf("RejectReview returned error: %v", err)
+	}
+	if rejected.Status != domain.FraudReviewRejected || rejected.ReviewNote != "mule account" || rejected.TransactionID != nil {
+		t.Fatalf("unexpected rejected review: %+v", rejected)
+	}
+	var count int64
+	db.Model(&domain.Transaction{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no transactions after rejection, got %d", count)
+	}
+	if _, err := service.RejectReview(context.Background(), uuid.New(), ""); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+}
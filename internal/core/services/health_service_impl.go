@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// defaultHealthCheckTimeout membatasi durasi satu pemeriksaan agar probe
+// tidak menggantung ketika dependensi lambat.
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthService adalah registry HealthChecker. Adapter mendaftarkan
+// pemeriksaannya dari cmd/main.go; Check menjalankan semuanya secara paralel.
+type HealthService struct {
+	timeout time.Duration
+	now     func() time.Time
+
+	mu       sync.RWMutex
+	names    []string
+	checkers map[string]ports.HealthChecker
+}
+
+// HealthServiceOption mengatur HealthService.
+type HealthServiceOption func(*HealthService)
+
+// WithHealthCheckTimeout mengganti batas waktu per pemeriksaan.
+func WithHealthCheckTimeout(timeout time.Duration) HealthServiceOption {
+	return func(s *HealthService) {
+		s.timeout = timeout
+	}
+}
+
+func NewHealthService(opts ...HealthServiceOption) *HealthService {
+	s := &HealthService{
+		timeout:  defaultHealthCheckTimeout,
+		now:      time.Now,
+		checkers: make(map[string]ports.HealthChecker),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register menambahkan pemeriksaan bernama name. Mendaftarkan nama yang sama
+// lagi mengganti pemeriksaan sebelumnya.
+func (s *HealthService) Register(name string, checker ports.HealthChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.checkers[name]; !exists {
+		s.names = append(s.names, name)
+	}
+	s.checkers[name] = checker
+}
+
+// Check menjalankan semua pemeriksaan dan mengembalikan hasilnya sesuai
+// urutan pendaftaran.
+func (s *HealthService) Check(ctx context.Context) domain.HealthReport {
+	s.mu.RLock()
+	names := append([]string(nil), s.names...)
+	checkers := make([]ports.HealthChecker, len(names))
+	for i, name := range names {
+		checkers[i] = s.checkers[name]
+	}
+	s.mu.RUnlock()
+
+	report := domain.HealthReport{
+		Status:    domain.HealthStatusUp,
+		CheckedAt: s.now(),
+		Checks:    make([]domain.HealthCheckResult, len(names)),
+	}
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report.Checks[i] = s.run(ctx, names[i], checkers[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status != domain.HealthStatusUp {
+			report.Status = domain.HealthStatusDown
+		}
+	}
+	return report
+}
+
+func (s *HealthService) run(ctx context.Context, name string, checker ports.HealthChecker) domain.HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	started := time.Now()
+	errc := make(chan error, 1)
+	go func() { errc <- checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := domain.HealthCheckResult{
+		Name:      name,
+		Status:    domain.HealthStatusUp,
+		LatencyMs: float64(time.Since(started).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = domain.HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
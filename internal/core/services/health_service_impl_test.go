@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+func TestHealthServiceAllUp(t *testing.T) {
+	service := NewHealthService()
+	service.Register("database", ports.HealthCheckFunc(func(context.Context) error { return nil }))
+	service.Register("workers", ports.HealthCheckFunc(func(context.Context) error { return nil }))
+
+	report := service.Check(context.Background())
+	if report.Status != domain.HealthStatusUp || len(report.Checks) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Checks[0].Name != "database" || report.Checks[1].Name != "workers" {
+		t.Fatalf("checks not in registration order: %+v", report.Checks)
+	}
+}
+
+func TestHealthServiceReportsFailureAndTimeout(t *testing.T) {
+	service := NewHealthService(WithHealthCheckTimeout(20 * time.Millisecond))
+	service.Register("database", ports.HealthCheckFunc(func(context.Context) error { return errors.New("connection refused") }))
+	service.Register("slow", ports.HealthCheckFunc(func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+	service.Register("migrations", ports.HealthCheckFunc(func(context.Context) error { return nil }))
+
+	started := time.Now()
+	report := service.Check(context.Background())
+	if time.Since(started) > 500*time.Millisecond {
+		t.Fatalf("slow check was not cut off by the timeout")
+	}
+	if report.Status != domain.HealthStatusDown {
+		t.Fatalf("expected DOWN, got %+v", report)
+	}
+	if c := report.Checks[0]; c.Status != domain.HealthStatusDown || c.Error != "connection refused" {
+		t.Fatalf("unexpected database result: %+v", c)
+	}
+	if c := report.Checks[1]; c.Status != domain.HealthStatusDown || c.Error != context.DeadlineExceeded.Error() {
+		t.Fatalf("unexpected slow result: %+v", c)
+	}
+	if c := report.Checks[2]; c.Status != domain.HealthStatusUp {
+		t.Fatalf("unexpected migrations result: %+v", c)
+	}
+}
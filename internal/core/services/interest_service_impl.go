@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// interestSchedulerLock adalah nama JobLock yang dipegang selama CatchUp.
+const interestSchedulerLock = "interest-scheduler"
+
+// errAccrualsPosted menandai accrual yang sudah diposting di tx lain.
+var errAccrualsPosted = errors.New("interest accruals already posted")
+
+// InterestService menghitung bunga harian dari saldo akhir hari dan
+// membukukannya setiap bulan sebagai transaksi CREDIT kategori INTEREST.
+type InterestService struct {
+	interestRepo    ports.InterestRepository
+	transactionRepo ports.TransactionRepository
+	db              *gorm.DB
+	dayCount        string
+	rates           map[string]float64
+	now             func() time.Time
+	audit           *AuditService
+	lock            ports.JobLock
+}
+
+// InterestServiceOption mengatur dependensi opsional InterestService.
+type InterestServiceOption func(*InterestService)
+
+// WithInterestAudit mencatat setiap posting bunga ke audit log dalam
+// transaksi database yang sama.
+func WithInterestAudit(audit *AuditService) InterestServiceOption {
+	return func(s *InterestService) {
+		s.audit = audit
+	}
+}
+
+// WithInterestLock menjalankan CatchUp hanya selama lock dipegang, sehingga
+// dari beberapa replika hanya satu yang mengakru dan memposting bunga.
+func WithInterestLock(lock ports.JobLock) InterestServiceOption {
+	return func(s *InterestService) {
+		s.lock = lock
+	}
+}
+
+func NewInterestService(interestRepo ports.InterestRepository, transactionRepo ports.TransactionRepository, db *gorm.DB, cfg domain.InterestConfig, opts ...InterestServiceOption) *InterestService {
+	rates := make(map[string]float64, len(cfg.Rates))
+	for _, rate := range cfg.Rates {
+		rates[rate.AccountTier] = rate.AnnualRate
+	}
+	s := &InterestService{
+		interestRepo:    interestRepo,
+		transactionRepo: transactionRepo,
+		db:              db,
+		dayCount:        cfg.DayCount,
+		rates:           rates,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *InterestService) rateFor(accountTier string) float64 {
+	if accountTier == "" {
+		accountTier = domain.AccountTierRegular
+	}
+	return s.rates[accountTier]
+}
+
+func (s *InterestService) daysInYear() float64 {
+	if s.dayCount == domain.DayCountActual360 {
+		return 360
+	}
+	return 365
+}
+
+// AccrueDaily mencatat bunga hari day untuk setiap user aktif yang memiliki
+// suku bunga. Accrual yang sudah ada dilewati, sehingga aman dijalankan ulang.
+// Mengembalikan jumlah accrual baru yang dibuat.
+func (s *InterestService) AccrueDaily(ctx context.Context, day time.Time) (created int, err error) {
+	ctx, span := startSpan(ctx, "InterestService.AccrueDaily")
+	defer func() { endSpan(span, err) }()
+
+	var users []domain.User
+	if err := s.db.WithContext(ctx).Find(&users).Error; err != nil {
+		return 0, err
+	}
+	return s.accrueDay(ctx, day, users)
+}
+
+// accrueDay mengakru hari day untuk users. Checkpoint setiap user dimajukan
+// setelah user diproses, juga untuk user yang tidak mendapat bunga, sehingga
+// kegagalan di tengah hari hanya mengulang user yang belum diproses.
+func (s *InterestService) accrueDay(ctx context.Context, day time.Time, users []domain.User) (created int, err error) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+	for _, user := range users {
+		ok, err := s.accrueUser(ctx, &user, start, end)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+		if err := s.interestRepo.AdvanceCheckpoint(ctx, user.UserID, start); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// accrueUser mencatat bunga satu hari dari saldo ledger di akhir hari.
+func (s *InterestService) accrueUser(ctx context.Context, user *domain.User, start, end time.Time) (bool, error) {
+	rate := s.rateFor(user.AccountTier)
+	if !user.IsActive || rate <= 0 {
+		return false, nil
+	}
+	balance, err := s.balanceAt(ctx, user.UserID, end)
+	if err != nil || balance <= 0 {
+		return false, err
+	}
+	return s.interestRepo.CreateAccrual(ctx, &domain.InterestAccrual{
+		UserID:      user.UserID,
+		AccrualDate: start,
+		Balance:     balance,
+		AnnualRate:  rate,
+		Amount:      balance * rate / 100 / s.daysInYear(),
+	})
+}
+
+// balanceAt mengambil saldo user dari ledger pada waktu at.
+func (s *InterestService) balanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (float64, error) {
+	last, err := s.transactionRepo.FindLastBefore(ctx, userID, at)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return last.BalanceAfter, nil
+}
+
+// PostMonthly membukukan seluruh accrual yang belum diposting pada bulan month
+// sebagai satu transaksi CREDIT per user. Accrual yang sudah diposting tidak
+// diproses lagi. Mengembalikan jumlah transaksi bunga yang dibuat.
+func (s *InterestService) PostMonthly(ctx context.Context, month time.Time) (posted int, err error) {
+	ctx, span := startSpan(ctx, "InterestService.PostMonthly")
+	defer func() { endSpan(span, err) }()
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+
+	accruals, err := s.interestRepo.FindUnposted(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	byUser := make(map[uuid.UUID][]domain.InterestAccrual)
+	var userIDs []uuid.UUID
+	for _, accrual := range accruals {
+		if _, ok := byUser[accrual.UserID]; !ok {
+			userIDs = append(userIDs, accrual.UserID)
+		}
+		byUser[accrual.UserID] = append(byUser[accrual.UserID], accrual)
+	}
+
+	for _, userID := range userIDs {
+		ok, err := s.postUser(ctx, userID, byUser[userID], from)
+		if err != nil {
+			return posted, err
+		}
+		if ok {
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+func (s *InterestService) postUser(ctx context.Context, userID uuid.UUID, accruals []domain.InterestAccrual, month time.Time) (bool, error) {
+	var total float64
+	ids := make([]uuid.UUID, 0, len(accruals))
+	for _, accrual := range accruals {
+		total += accrual.Amount
+		ids = append(ids, accrual.AccrualID)
+	}
+	total = math.Round(total*100) / 100
+	postedAt := s.now()
+
+	var credit domain.Transaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if total <= 0 {
+			return s.markPosted(ctx, tx, ids, nil, postedAt)
+		}
+		var user domain.User
+		if err := lockUser(tx, &user, userID); err != nil {
+			return err
+		}
+		balanceBefore := user.Balance
+		user.Balance += total
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		credit = domain.Transaction{
+			UserID:          userID,
+			TransactionType: domain.TransactionTypeCredit,
+			Category:        domain.CategoryInterest,
+			Amount:          total,
+			Remarks:         fmt.Sprintf("interest %s", month.Format("2006-01")),
+			BalanceBefore:   balanceBefore,
+			BalanceAfter:    user.Balance,
+		}
+		if err := s.transactionRepo.CreateWithTx(ctx, tx, &credit); err != nil {
+			return err
+		}
+		if err := s.markPosted(ctx, tx, ids, &credit.TransactionID, postedAt); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditInterestPosted,
+			TargetID: &userID,
+			Changes:  balanceChange(credit),
+			Metadata: map[string]interface{}{"transaction_id": credit.TransactionID, "amount": total, "month": month.Format("2006-01")},
+		})
+	})
+	if errors.Is(err, errAccrualsPosted) {
+		// tx lain sudah memposting accrual ini; kredit di tx ini dibatalkan
+		return false, nil
+	}
+	if total > 0 {
+		logFundsMovement(ctx, slog.Default(), domain.CategoryInterest, err, userID, total, &credit)
+	}
+	return err == nil && total > 0, err
+}
+
+// markPosted menandai accrual sebagai diposting dan gagal dengan
+// errAccrualsPosted jika salah satunya sudah diposting tx lain.
+func (s *InterestService) markPosted(ctx context.Context, tx *gorm.DB, ids []uuid.UUID, transactionID *uuid.UUID, postedAt time.Time) error {
+	ok, err := s.interestRepo.MarkPostedWithTx(ctx, tx, ids, transactionID, postedAt)
+	if err == nil && !ok {
+		err = errAccrualsPosted
+	}
+	return err
+}
+
+// AccruedInterest mengembalikan total bunga yang sudah dihitung namun belum diposting.
+func (s *InterestService) AccruedInterest(ctx context.Context, userID uuid.UUID) (float64, error) {
+	return s.interestRepo.AccruedTotal(ctx, userID)
+}
+
+// CatchUp mengakru setiap hari yang belum diproses untuk masing-masing user
+// sampai kemarin, lalu memposting setiap bulan yang sudah lewat sejak hari
+// pertama yang diakru. Hari yang terlewat saat service mati atau saat
+// accrual gagal di tengah jalan ikut diakru dari saldo ledger pada hari itu.
+// Dengan WithInterestLock, CatchUp tidak melakukan apa pun jika lock sedang
+// dipegang replika lain.
+func (s *InterestService) CatchUp(ctx context.Context) (accrued, posted int, err error) {
+	if s.lock == nil {
+		return s.catchUp(ctx)
+	}
+	_, err = s.lock.TryRun(ctx, interestSchedulerLock, func(ctx context.Context) (err error) {
+		accrued, posted, err = s.catchUp(ctx)
+		return err
+	})
+	return accrued, posted, err
+}
+
+func (s *InterestService) catchUp(ctx context.Context) (accrued, posted int, err error) {
+	now := s.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var users []domain.User
+	if err := s.db.WithContext(ctx).Find(&users).Error; err != nil {
+		return 0, 0, err
+	}
+	resume, err := s.resumeDays(ctx, today)
+	if err != nil {
+		return 0, 0, err
+	}
+	from := today
+	for _, user := range users {
+		if day := resume(user.UserID); day.Before(from) {
+			from = day
+		}
+	}
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		var due []domain.User
+		for _, user := range users {
+			if !resume(user.UserID).After(day) {
+				due = append(due, user)
+			}
+		}
+		created, err := s.accrueDay(ctx, day, due)
+		accrued += created
+		if err != nil {
+			return accrued, posted, err
+		}
+	}
+
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, now.Location())
+	if lastMonth := thisMonth.AddDate(0, -1, 0); lastMonth.Before(month) {
+		month = lastMonth
+	}
+	for ; month.Before(thisMonth); month = month.AddDate(0, 1, 0) {
+		created, err := s.PostMonthly(ctx, month)
+		posted += created
+		if err != nil {
+			return accrued, posted, err
+		}
+	}
+	return accrued, posted, nil
+}
+
+// resumeDays mengembalikan fungsi yang memberi hari pertama yang belum
+// diproses untuk seorang user: sehari setelah checkpoint-nya. User tanpa
+// checkpoint dimulai dari checkpoint paling awal milik user lain; jika belum
+// ada checkpoint sama sekali, dari sehari setelah accrual terakhir, atau
+// kemarin jika belum ada accrual.
+func (s *InterestService) resumeDays(ctx context.Context, today time.Time) (func(uuid.UUID) time.Time, error) {
+	checkpoints, err := s.interestRepo.Checkpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	startOf := func(t time.Time) time.Time {
+		t = t.In(today.Location())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, today.Location())
+	}
+	floor := today.AddDate(0, 0, -1)
+	if len(checkpoints) > 0 {
+		floor = today
+		for _, day := range checkpoints {
+			if day := startOf(day); day.Before(floor) {
+				floor = day
+			}
+		}
+	} else {
+		last, err := s.interestRepo.LastAccrualDate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil {
+			floor = startOf(*last).AddDate(0, 0, 1)
+		}
+	}
+	return func(userID uuid.UUID) time.Time {
+		if day, ok := checkpoints[userID]; ok {
+			return startOf(day).AddDate(0, 0, 1)
+		}
+		return floor
+	}, nil
+}
+
+// RunScheduler menjalankan CatchUp setiap interval sampai ctx dibatalkan.
+// Semua langkahnya idempoten.
+func (s *InterestService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, _, err := s.CatchUp(ctx); err != nil {
+			slog.ErrorContext(ctx, "interest catch-up failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
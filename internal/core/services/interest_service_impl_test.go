@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"hexagonal-go/internal/core/domain"
+)
+
+type interestAccrualMigration struct {
+	AccrualID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_interest_accruals_user_date"`
+	AccrualDate   time.Time `gorm:"not null;uniqueIndex:idx_interest_accruals_user_date"`
+	Balance       float64
+	AnnualRate    float64
+	Amount        float64
+	PostedAt      *time.Time
+	TransactionID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time
+}
+
+func (interestAccrualMigration) TableName() string { return "interest_accruals" }
+
+type testInterestRepo struct {
+	db *gorm.DB
+}
+
+func (r *testInterestRepo) CreateAccrual(ctx context.Context, accrual *domain.InterestAccrual) (bool, error) {
+	if accrual.AccrualID == uuid.Nil {
+		accrual.AccrualID = uuid.New()
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(accrual)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *testInterestRepo) FindUnposted(ctx context.Context, from, to time.Time) ([]domain.InterestAccrual, error) {
+	var accruals []domain.InterestAccrual
+	err := r.db.Where("posted_at IS NULL AND accrual_date >= ? AND accrual_date < ?", from, to).Order("user_id, accrual_date").Find(&accruals).Error
+	return accruals, err
+}
+
+func (r *testInterestRepo) MarkPostedWithTx(ctx context.Context, dbTx *gorm.DB, accrualIDs []uuid.UUID, transactionID *uuid.UUID, postedAt time.Time) (bool, error) {
+	result := dbTx.Model(&domain.InterestAccrual{}).Where("accrual_id IN ? AND posted_at IS NULL", accrualIDs).
+		Updates(map[string]interface{}{"posted_at": postedAt, "transaction_id": transactionID})
+	return result.RowsAffected == int64(len(accrualIDs)), result.Error
+}
+
+func (r *testInterestRepo) AccruedTotal(ctx context.Context, userID uuid.UUID) (float64, error) {
+	var total float64
+	err := r.db.Model(&domain.InterestAccrual{}).Select("COALESCE(SUM(amount), 0)").Where("user_id = ? AND posted_at IS NULL", userID).Scan(&total).Error
+	return total, err
+}
+
+func (r *testInterestRepo) LastAccrualDate(ctx context.Context) (*time.Time, error) {
+	var accrual domain.InterestAccrual
+	if err := r.db.Order("accrual_date DESC").First(&accrual).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &accrual.AccrualDate, nil
+}
+
+func (r *testInterestRepo) AdvanceCheckpoint(ctx context.Context, userID uuid.UUID, day time.Time) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"accrued_through", "updated_at"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "interest_checkpoints.accrued_through < excluded.accrued_through"}}},
+	}).Create(&domain.InterestCheckpoint{UserID: userID, AccruedThrough: day}).Error
+}
+
+func (r *testInterestRepo) Checkpoints(ctx context.Context) (map[uuid.UUID]time.Time, error) {
+	var checkpoints []domain.InterestCheckpoint
+	if err := r.db.Find(&checkpoints).Error; err != nil {
+		return nil, err
+	}
+	days := make(map[uuid.UUID]time.Time, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		days[checkpoint.UserID] = checkpoint.AccruedThrough
+	}
+	return days, nil
+}
+
+func setupInterestTest(t *testing.T) (*gorm.DB, *InterestService, domain.User) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&interestAccrualMigration{}, &domain.InterestCheckpoint{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	cfg := domain.InterestConfig{
+		DayCount: domain.DayCountActual365,
+		Rates:    []domain.InterestRate{{AccountTier: domain.AccountTierRegular, AnnualRate: 3.65}},
+	}
+	service := NewInterestService(&testInterestRepo{db: db}, &testTransactionRepo{db: db}, db, cfg)
+
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 1000, IsActive: true, AccountTier: domain.AccountTierRegular}
+	db.Create(&user)
+	db.Create(&domain.Transaction{
+		UserID:          user.UserID,
+		TransactionType: domain.TransactionTypeCredit,
+		Category:        domain.CategoryDeposit,
+		Amount:          1000,
+		BalanceAfter:    1000,
+		CreatedAt:       time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+	})
+	return db, service, user
+}
+
+func TestInterestServiceAccrueDailyIsIdempotent(t *testing.T) {
+	_, service, user := setupInterestTest(t)
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	created, err := service.AccrueDaily(context.Background(), day)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if created != 1 {
+		t.Fatalf("expected 1 accrual, got %d", created)
+	}
+	created, err = service.AccrueDaily(context.Background(), day)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if created != 0 {
+		t.Fatalf("expected rerun to create no accruals, got %d", created)
+	}
+
+	accrued, err := service.AccruedInterest(context.Background(), user.UserID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if accrued < 0.0999 || accrued > 0.1001 {
+		t.Fatalf("expected accrued interest 0.1, got %v", accrued)
+	}
+}
+
+func TestInterestServicePostMonthly(t *testing.T) {
+	db, service, user := setupInterestTest(t)
+	for day := 1; day <= 31; day++ {
+		if _, err := service.AccrueDaily(context.Background(), time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	posted, err := service.PostMonthly(context.Background(), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if posted != 1 {
+		t.Fatalf("expected 1 posting, got %d", posted)
+	}
+	posted, err = service.PostMonthly(context.Background(), time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if posted != 0 {
+		t.Fatalf("expected rerun to post nothing, got %d", posted)
+	}
+
+	var updated domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	if updated.Balance != 1003.1 {
+		t.Fatalf("expected balance 1003.1, got %v", updated.Balance)
+	}
+	var count int64
+	db.Model(&domain.Transaction{}).Where("category = ?", domain.CategoryInterest).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected 1 interest transaction, got %d", count)
+	}
+}
+
+func TestInterestServiceCatchUpAccruesMissedDays(t *testing.T) {
+	db, service, user := setupInterestTest(t)
+	if _, err := service.AccrueDaily(context.Background(), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	service.now = func() time.Time { return time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC) }
+
+	accrued, posted, err := service.CatchUp(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	// 3 Januari sampai 2 Maret, lalu posting Januari dan Februari
+	if accrued != 59 || posted != 2 {
+		t.Fatalf("expected 59 accruals and 2 postings, got %d and %d", accrued, posted)
+	}
+	var count int64
+	db.Model(&domain.InterestAccrual{}).Where("user_id = ?", user.UserID).Count(&count)
+	if count != 60 {
+		t.Fatalf("expected 60 accruals, got %d", count)
+	}
+
+	accrued, posted, err = service.CatchUp(context.Background())
+	if err != nil || accrued != 0 || posted != 0 {
+		t.Fatalf("expected rerun to do nothing, got %d, %d, %v", accrued, posted, err)
+	}
+	remaining, _ := service.AccruedInterest(context.Background(), user.UserID)
+	if remaining < 0.1999 || remaining > 0.2001 {
+		t.Fatalf("expected March accruals to stay unposted, got %v", remaining)
+	}
+}
+
+func TestInterestServiceCatchUpResumesPerUser(t *testing.T) {
+	db, service, first := setupInterestTest(t)
+	second := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234", Balance: 1000, IsActive: true, AccountTier: domain.AccountTierRegular}
+	db.Create(&second)
+	db.Create(&domain.Transaction{
+		UserID:          second.UserID,
+		TransactionType: domain.TransactionTypeCredit,
+		Category:        domain.CategoryDeposit,
+		Amount:          1000,
+		BalanceAfter:    1000,
+		CreatedAt:       time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+	})
+	// accrual 2 Januari gagal setelah user pertama diproses
+	if _, err := service.accrueDay(context.Background(), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), []domain.User{first}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	service.now = func() time.Time { return time.Date(2026, 1, 4, 1, 0, 0, 0, time.UTC) }
+
+	accrued, _, err := service.CatchUp(context.Background())
+	if err != nil || accrued != 3 {
+		t.Fatalf("expected 3 accruals, got %d (%v)", accrued, err)
+	}
+	for _, user := range []domain.User{first, second} {
+		var count int64
+		db.Model(&domain.InterestAccrual{}).Where("user_id = ?", user.UserID).Count(&count)
+		if count != 2 {
+			t.Fatalf("expected 2 accruals for %s, got %d", user.FirstName, count)
+		}
+	}
+}
+
+func TestInterestServicePostsAccrualsOnce(t *testing.T) {
+	db, service, user := setupInterestTest(t)
+	month := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := 1; day <= 31; day++ {
+		if _, err := service.AccrueDaily(context.Background(), time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+	// replika lain membaca accrual yang sama sebelum posting pertama selesai
+	stale, err := service.interestRepo.FindUnposted(context.Background(), month, month.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if posted, err := service.PostMonthly(context.Background(), month); err != nil || posted != 1 {
+		t.Fatalf("expected 1 posting, got %d (%v)", posted, err)
+	}
+	ok, err := service.postUser(context.Background(), user.UserID, stale, month)
+	if err != nil || ok {
+		t.Fatalf("expected stale posting to be skipped, got %v (%v)", ok, err)
+	}
+
+	var updated domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	if updated.Balance != 1003.1 {
+		t.Fatalf("expected balance 1003.1, got %v", updated.Balance)
+	}
+	var count int64
+	db.Model(&domain.Transaction{}).Where("category = ?", domain.CategoryInterest).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected 1 interest transaction, got %d", count)
+	}
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+var (
+	// ErrKYCRequired dikembalikan saat level KYC user tidak mengizinkan
+	// operasi tersebut.
+	ErrKYCRequired = errors.New("kyc level does not allow this operation")
+	// ErrBalanceCapExceeded dikembalikan saat saldo akan melebihi batas saldo
+	// level KYC user.
+	ErrBalanceCapExceeded = errors.New("balance cap of kyc level exceeded")
+	// ErrInvalidKYCSubmission dikembalikan untuk data atau dokumen pengajuan
+	// yang tidak lengkap atau tidak valid.
+	ErrInvalidKYCSubmission = errors.New("invalid kyc submission")
+	// ErrKYCSubmissionPending dikembalikan saat user masih memiliki pengajuan
+	// yang belum diputuskan.
+	ErrKYCSubmissionPending = errors.New("kyc submission already pending")
+	// ErrSubmissionNotPending dikembalikan saat memutuskan pengajuan yang
+	// sudah diputuskan.
+	ErrSubmissionNotPending = errors.New("kyc submission is not pending")
+)
+
+// kycRequiredDocuments adalah dokumen wajib untuk setiap level yang dapat
+// diajukan.
+var kycRequiredDocuments = map[string][]string{
+	domain.KYCLevelBasic: {domain.KYCDocumentIDCard},
+	domain.KYCLevelFull:  {domain.KYCDocumentIDCard, domain.KYCDocumentSelfie},
+}
+
+// kycContentTypes adalah jenis file dokumen yang diterima, dideteksi dari
+// isinya.
+var kycContentTypes = map[string]bool{"image/jpeg": true, "image/png": true, "application/pdf": true}
+
+// KYCService mengelola pengajuan KYC dan kemampuan akun per level KYC.
+type KYCService struct {
+	kycRepo  ports.KYCRepository
+	blobs    ports.BlobStore
+	db       *gorm.DB
+	policies map[string]domain.KYCPolicy
+	audit    *AuditService
+}
+
+// KYCServiceOption mengatur dependensi opsional KYCService.
+type KYCServiceOption func(*KYCService)
+
+// WithKYCAudit mencatat pengajuan dan keputusan reviewer ke audit log.
+func WithKYCAudit(audit *AuditService) KYCServiceOption {
+	return func(s *KYCService) {
+		s.audit = audit
+	}
+}
+
+func NewKYCService(kycRepo ports.KYCRepository, blobs ports.BlobStore, db *gorm.DB, policies []domain.KYCPolicy, opts ...KYCServiceOption) *KYCService {
+	policyMap := make(map[string]domain.KYCPolicy, len(policies))
+	for _, policy := range policies {
+		policyMap[policy.Level] = policy
+	}
+	s := &KYCService{kycRepo: kycRepo, blobs: blobs, db: db, policies: policyMap}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Policy mengembalikan kebijakan level KYC user; level kosong dianggap
+// UNVERIFIED.
+func (s *KYCService) Policy(level string) (domain.KYCPolicy, bool) {
+	if level == "" {
+		level = domain.KYCLevelUnverified
+	}
+	policy, ok := s.policies[level]
+	return policy, ok
+}
+
+// Allow memastikan level KYC user mengizinkan operasi WITHDRAW atau
+// TRANSFER keluar.
+func (s *KYCService) Allow(user *domain.User, operation string) error {
+	policy, ok := s.Policy(user.KYCLevel)
+	if !ok {
+		return nil
+	}
+	allowed := true
+	switch operation {
+	case domain.CategoryWithdraw:
+		allowed = policy.Withdraw
+	case domain.CategoryTransfer:
+		allowed = policy.TransferOut
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s not allowed at level %s", ErrKYCRequired, strings.ToLower(operation), policy.Level)
+	}
+	return nil
+}
+
+// CheckBalance memastikan saldo baru user tidak melebihi batas saldo level
+// KYC-nya.
+func (s *KYCService) CheckBalance(user *domain.User, balance float64) error {
+	policy, ok := s.Policy(user.KYCLevel)
+	if !ok || policy.MaxBalance <= 0 || balance <= policy.MaxBalance {
+		return nil
+	}
+	return fmt.Errorf("%w: level %s allows a balance of at most %v", ErrBalanceCapExceeded, policy.Level, policy.MaxBalance)
+}
+
+// Status mengembalikan level, kemampuan akun, dan riwayat pengajuan user.
+func (s *KYCService) Status(ctx context.Context, userID uuid.UUID) (*domain.KYCStatus, error) {
+	var user domain.User
+	if err := s.db.WithContext(ctx).First(&user, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	submissions, err := s.kycRepo.FindSubmissionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	status := &domain.KYCStatus{Level: user.KYCLevel, Submissions: submissions}
+	if status.Level == "" {
+		status.Level = domain.KYCLevelUnverified
+	}
+	if policy, ok := s.Policy(status.Level); ok {
+		status.Policy = &policy
+	}
+	return status, nil
+}
+
+// Submit menyimpan dokumen ke BlobStore lalu mencatat pengajuan PENDING.
+// Level yang diajukan harus lebih tinggi dari level user saat ini dan user
+// tidak boleh memiliki pengajuan lain yang belum diputuskan. Dokumen yang
+// sudah tersimpan dihapus lagi jika pengajuan gagal dicatat.
+func (s *KYCService) Submit(ctx context.Context, userID uuid.UUID, application domain.KYCApplication) (_ *domain.KYCSubmission, err error) {
+	ctx, span := startSpan(ctx, "KYCService.Submit", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+
+	if err := validateKYCApplication(application); err != nil {
+		return nil, err
+	}
+	submission := &domain.KYCSubmission{
+		SubmissionID: uuid.New(),
+		UserID:       userID,
+		Level:        application.Level,
+		FullName:     strings.TrimSpace(application.FullName),
+		IDNumber:     strings.TrimSpace(application.IDNumber),
+		DateOfBirth:  application.DateOfBirth,
+		Status:       domain.KYCSubmissionPending,
+	}
+	defer func() {
+		if err != nil {
+			s.deleteDocuments(ctx, submission.Documents)
+		}
+	}()
+	for _, upload := range application.Documents {
+		document, err := s.storeDocument(ctx, submission, upload)
+		if err != nil {
+			return nil, err
+		}
+		submission.Documents = append(submission.Documents, *document)
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Baris user dikunci agar dua pengajuan bersamaan tidak sama-sama lolos
+		// pemeriksaan pengajuan PENDING.
+		var user domain.User
+		if err := lockUser(tx, &user, userID); err != nil {
+			return err
+		}
+		if user.IsBlocked {
+			return ErrAccountBlocked
+		}
+		if domain.KYCLevelRank(application.Level) <= domain.KYCLevelRank(user.KYCLevel) {
+			return fmt.Errorf("%w: level must be higher than the current level", ErrInvalidKYCSubmission)
+		}
+		pending, err := s.kycRepo.HasPendingSubmissionWithTx(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return ErrKYCSubmissionPending
+		}
+		if err := s.kycRepo.CreateSubmissionWithTx(ctx, tx, submission); err != nil {
+			return err
+		}
+		kinds := make([]string, 0, len(submission.Documents))
+		for _, document := range submission.Documents {
+			kinds = append(kinds, document.Kind)
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditKYCSubmitted,
+			TargetID: &userID,
+			Metadata: map[string]interface{}{"submission_id": submission.SubmissionID, "level": submission.Level, "documents": kinds},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+func validateKYCApplication(application domain.KYCApplication) error {
+	required, ok := kycRequiredDocuments[application.Level]
+	if !ok {
+		return fmt.Errorf("%w: level must be %s or %s", ErrInvalidKYCSubmission, domain.KYCLevelBasic, domain.KYCLevelFull)
+	}
+	if strings.TrimSpace(application.FullName) == "" || strings.TrimSpace(application.IDNumber) == "" {
+		return fmt.Errorf("%w: full_name and id_number are required", ErrInvalidKYCSubmission)
+	}
+	if _, err := time.Parse("2006-01-02", application.DateOfBirth); err != nil {
+		return fmt.Errorf("%w: date_of_birth must be YYYY-MM-DD", ErrInvalidKYCSubmission)
+	}
+	kinds := make(map[string]bool)
+	for _, upload := range application.Documents {
+		switch upload.Kind {
+		case domain.KYCDocumentIDCard, domain.KYCDocumentSelfie, domain.KYCDocumentProofOfAddress:
+		default:
+			return fmt.Errorf("%w: unknown document kind %q", ErrInvalidKYCSubmission, upload.Kind)
+		}
+		if kinds[upload.Kind] {
+			return fmt.Errorf("%w: duplicate %s document", ErrInvalidKYCSubmission, upload.Kind)
+		}
+		kinds[upload.Kind] = true
+	}
+	for _, kind := range required {
+		if !kinds[kind] {
+			return fmt.Errorf("%w: %s document is required for level %s", ErrInvalidKYCSubmission, kind, application.Level)
+		}
+	}
+	return nil
+}
+
+// storeDocument menyimpan satu dokumen setelah memeriksa jenis file dari
+// isinya dan ukurannya.
+func (s *KYCService) storeDocument(ctx context.Context, submission *domain.KYCSubmission, upload domain.KYCUpload) (*domain.KYCDocument, error) {
+	content := bufio.NewReaderSize(upload.Content, 512)
+	head, err := content.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head)
+	if !kycContentTypes[contentType] {
+		return nil, fmt.Errorf("%w: %s document must be JPEG, PNG or PDF", ErrInvalidKYCSubmission, upload.Kind)
+	}
+	document := &domain.KYCDocument{
+		DocumentID:   uuid.New(),
+		SubmissionID: submission.SubmissionID,
+		Kind:         upload.Kind,
+		FileName:     upload.FileName,
+		ContentType:  contentType,
+	}
+	document.BlobKey = fmt.Sprintf("kyc/%s/%s/%s", submission.UserID, submission.SubmissionID, document.DocumentID)
+	document.Size, err = s.blobs.Put(ctx, document.BlobKey, io.LimitReader(content, domain.MaxKYCDocumentSize+1))
+	if err == nil && document.Size > domain.MaxKYCDocumentSize {
+		err = fmt.Errorf("%w: %s document exceeds %d bytes", ErrInvalidKYCSubmission, upload.Kind, domain.MaxKYCDocumentSize)
+	}
+	if err != nil {
+		s.deleteDocuments(ctx, []domain.KYCDocument{*document})
+		return nil, err
+	}
+	return document, nil
+}
+
+// deleteDocuments menghapus isi dokumen dari BlobStore; kegagalan hanya
+// dicatat ke log.
+func (s *KYCService) deleteDocuments(ctx context.Context, documents []domain.KYCDocument) {
+	for _, document := range documents {
+		if err := s.blobs.Delete(context.WithoutCancel(ctx), document.BlobKey); err != nil {
+			slog.WarnContext(ctx, "failed to delete kyc document", "document_id", document.DocumentID, "error", err)
+		}
+	}
+}
+
+// ApproveSubmission menaikkan level KYC user ke level yang diajukan.
+// Reviewer diambil dari AuditActor pada ctx.
+func (s *KYCService) ApproveSubmission(ctx context.Context, submissionID uuid.UUID, note string) (*domain.KYCSubmission, error) {
+	return s.decide(ctx, submissionID, domain.KYCSubmissionApproved, note)
+}
+
+// RejectSubmission menolak pengajuan tanpa mengubah level user; user dapat
+// mengajukan ulang.
+func (s *KYCService) RejectSubmission(ctx context.Context, submissionID uuid.UUID, note string) (*domain.KYCSubmission, error) {
+	return s.decide(ctx, submissionID, domain.KYCSubmissionRejected, note)
+}
+
+func (s *KYCService) decide(ctx context.Context, submissionID uuid.UUID, status, note string) (_ *domain.KYCSubmission, err error) {
+	ctx, span := startSpan(ctx, "KYCService.decide", attribute.String("submission.id", submissionID.String()))
+	defer func() { endSpan(span, err) }()
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		submission, err := s.kycRepo.FindSubmissionForUpdateWithTx(ctx, tx, submissionID)
+		if err != nil {
+			return err
+		}
+		if submission.Status != domain.KYCSubmissionPending {
+			return ErrSubmissionNotPending
+		}
+		now := time.Now()
+		submission.Status = status
+		submission.ReviewedBy = AuditActorFrom(ctx).Name
+		submission.ReviewNote = note
+		submission.ReviewedAt = &now
+		if err := s.kycRepo.UpdateSubmissionWithTx(ctx, tx, submission); err != nil {
+			return err
+		}
+		record := AuditRecord{
+			Action:   domain.AuditKYCRejected,
+			TargetID: &submission.UserID,
+			Metadata: map[string]interface{}{"submission_id": submission.SubmissionID, "level": submission.Level, "note": note},
+		}
+		if status == domain.KYCSubmissionApproved {
+			var user domain.User
+			if err := tx.First(&user, "user_id = ?", submission.UserID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&user).Update("kyc_level", submission.Level).Error; err != nil {
+				return err
+			}
+			before := user.KYCLevel
+			if before == "" {
+				before = domain.KYCLevelUnverified
+			}
+			record.Action = domain.AuditKYCApproved
+			record.Changes = map[string]domain.AuditChange{"kyc_level": {Before: before, After: submission.Level}}
+		}
+		return recordAudit(ctx, s.audit, tx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.kycRepo.FindSubmissionByID(ctx, submissionID)
+}
+
+func (s *KYCService) ListSubmissions(ctx context.Context, status string, limit int) ([]domain.KYCSubmission, error) {
+	return s.kycRepo.FindSubmissions(ctx, status, limit)
+}
+
+func (s *KYCService) GetSubmission(ctx context.Context, submissionID uuid.UUID) (*domain.KYCSubmission, error) {
+	return s.kycRepo.FindSubmissionByID(ctx, submissionID)
+}
+
+// OpenDocument membuka isi dokumen sebuah pengajuan untuk reviewer, atau
+// gorm.ErrRecordNotFound jika dokumen bukan milik pengajuan tersebut.
+func (s *KYCService) OpenDocument(ctx context.Context, submissionID, documentID uuid.UUID) (*domain.KYCDocument, io.ReadCloser, error) {
+	submission, err := s.kycRepo.FindSubmissionByID(ctx, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, document := range submission.Documents {
+		if document.DocumentID == documentID {
+			content, err := s.blobs.Open(ctx, document.BlobKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &document, content, nil
+		}
+	}
+	return nil, nil, gorm.ErrRecordNotFound
+}
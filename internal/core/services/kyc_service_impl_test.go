@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+)
+
+type memoryBlobStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (m *memoryBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+func (m *memoryBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, errors.New("blob not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.blobs, key)
+	return nil
+}
+
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+func setupKYC(t *testing.T) (*gorm.DB, *KYCService, *memoryBlobStore) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.KYCSubmission{}, &domain.KYCDocument{}, &domain.AuditEntry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	blobs := &memoryBlobStore{blobs: make(map[string][]byte)}
+	kyc := NewKYCService(repository.NewKYCRepositoryImpl(db), blobs, db, []domain.KYCPolicy{
+		{Level: domain.KYCLevelUnverified, MaxBalance: 1000},
+		{Level: domain.KYCLevelBasic, Withdraw: true, TransferOut: true, MaxBalance: 5000},
+		{Level: domain.KYCLevelFull, Withdraw: true, TransferOut: true},
+	})
+	return db, kyc, blobs
+}
+
+func basicApplication(documents ...domain.KYCUpload) domain.KYCApplication {
+	return domain.KYCApplication{
+		Level:       domain.KYCLevelBasic,
+		FullName:    "Alice Smith",
+		IDNumber:    "3171000000000001",
+		DateOfBirth: "1990-01-31",
+		Documents:   documents,
+	}
+}
+
+func TestKYCTransactionRestrictions(t *testing.T) {
+	db, kyc, _ := setupKYC(t)
+	ctx := context.Background()
+	transactions := NewTransactionService(&testTransactionRepo{db: db}, db, WithKYCService(kyc))
+	unverified := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "1", Balance: 500, IsActive: true, KYCLevel: domain.KYCLevelUnverified}
+	basic := domain.User{UserID: uuid.New(), FirstName: "Bob", PhoneNumber: "2", Balance: 4900, IsActive: true, KYCLevel: domain.KYCLevelBasic}
+	db.Create(&unverified)
+	db.Create(&basic)
+
+	if _, err := transactions.Withdraw(ctx, unverified.UserID, 100, ""); !errors.Is(err, ErrKYCRequired) {
+		t.Fatalf("expected ErrKYCRequired on withdraw, got %v", err)
+	}
+	if _, _, err := transactions.Transfer(ctx, unverified.UserID, basic.UserID, 50, ""); !errors.Is(err, ErrKYCRequired) {
+		t.Fatalf("expected ErrKYCRequired on transfer, got %v", err)
+	}
+	if _, err := transactions.Deposit(ctx, unverified.UserID, 600, ""); !errors.Is(err, ErrBalanceCapExceeded) {
+		t.Fatalf("expected ErrBalanceCapExceeded on deposit, got %v", err)
+	}
+	if _, err := transactions.Deposit(ctx, unverified.UserID, 500, ""); err != nil {
+		t.Fatalf("expected deposit up to the cap to succeed, got %v", err)
+	}
+	if _, _, err := transactions.Transfer(ctx, basic.UserID, unverified.UserID, 1, ""); !errors.Is(err, ErrBalanceCapExceeded) {
+		t.Fatalf("expected ErrBalanceCapExceeded for the recipient, got %v", err)
+	}
+	if _, err := transactions.Withdraw(ctx, basic.UserID, 100, ""); err != nil {
+		t.Fatalf("expected BASIC withdraw to succeed, got %v", err)
+	}
+}
+
+func TestKYCSubmitValidation(t *testing.T) {
+	db, kyc, blobs := setupKYC(t)
+	ctx := context.Background()
+	user := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "1", IsActive: true, KYCLevel: domain.KYCLevelUnverified}
+	db.Create(&user)
+
+	invalid := []domain.KYCApplication{
+		basicApplication(),
+		{Level: domain.KYCLevelUnverified, FullName: "Alice", IDNumber: "1", DateOfBirth: "1990-01-31"},
+		func() domain.KYCApplication {
+			app := basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: bytes.NewReader(testPNG)})
+			app.DateOfBirth = "31/01/1990"
+			return app
+		}(),
+		basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.txt", Content: bytes.NewReader([]byte("plain text"))}),
+		basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: io.MultiReader(bytes.NewReader(testPNG), bytes.NewReader(make([]byte, domain.MaxKYCDocumentSize)))}),
+	}
+	for i, app := range invalid {
+		if _, err := kyc.Submit(ctx, user.UserID, app); !errors.Is(err, ErrInvalidKYCSubmission) {
+			t.Fatalf("case %d: expected ErrInvalidKYCSubmission, got %v", i, err)
+		}
+	}
+	if len(blobs.blobs) != 0 {
+		t.Fatalf("expected rejected uploads to be removed, got %d blobs", len(blobs.blobs))
+	}
+
+	submission, err := kyc.Submit(ctx, user.UserID, basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: bytes.NewReader(testPNG)}))
+	if err != nil {
+		t.Fatalf("Submit returned error: %v", err)
+	}
+	if submission.Status != domain.KYCSubmissionPending || len(submission.Documents) != 1 || submission.Documents[0].ContentType != "image/png" {
+		t.Fatalf("unexpected submission: %+v", submission)
+	}
+	if _, err := kyc.Submit(ctx, user.UserID, basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: bytes.NewReader(testPNG)})); !errors.Is(err, ErrKYCSubmissionPending) {
+		t.Fatalf("expected ErrKYCSubmissionPending, got %v", err)
+	}
+	if len(blobs.blobs) != 1 {
+		t.Fatalf("expected only the pending submission's document to be stored, got %d blobs", len(blobs.blobs))
+	}
+}
+
+func TestKYCReview(t *testing.T) {
+	db, kyc, _ := setupKYC(t)
+	ctx := context.Background()
+	user := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "1", IsActive: true, KYCLevel: domain.KYCLevelUnverified}
+	db.Create(&user)
+	submit := func() *domain.KYCSubmission {
+		submission, err := kyc.Submit(ctx, user.UserID, basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: bytes.NewReader(testPNG)}))
+		if err != nil {
+			t.Fatalf("Submit returned error: %v", err)
+		}
+		return submission
+	}
+
+	rejected, err := kyc.RejectSubmission(ctx, submit().SubmissionID, "blurry photo")
+	if err != nil || rejected.Status != domain.KYCSubmissionRejected || rejected.ReviewedAt == nil {
+		t.Fatalf("unexpected rejection: %+v, %v", rejected, err)
+	}
+	if _, err := kyc.ApproveSubmission(ctx, rejected.SubmissionID, ""); !errors.Is(err, ErrSubmissionNotPending) {
+		t.Fatalf("expected ErrSubmissionNotPending, got %v", err)
+	}
+
+	submission := submit()
+	document, content, err := kyc.OpenDocument(ctx, submission.SubmissionID, submission.Documents[0].DocumentID)
+	if err != nil {
+		t.Fatalf("OpenDocument returned error: %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if document.Kind != domain.KYCDocumentIDCard || !bytes.Equal(data, testPNG) {
+		t.Fatalf("unexpected document %+v with %d bytes", document, len(data))
+	}
+	if _, _, err := kyc.OpenDocument(ctx, rejected.SubmissionID, submission.Documents[0].DocumentID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound for a document of another submission, got %v", err)
+	}
+
+	if _, err := kyc.ApproveSubmission(ctx, submission.SubmissionID, "ok"); err != nil {
+		t.Fatalf("ApproveSubmission returned error: %v", err)
+	}
+	status, err := kyc.Status(ctx, user.UserID)
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if status.Level != domain.KYCLevelBasic || status.Policy == nil || status.Policy.MaxBalance != 5000 || len(status.Submissions) != 2 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if _, err := kyc.Submit(ctx, user.UserID, basicApplication(domain.KYCUpload{Kind: domain.KYCDocumentIDCard, FileName: "id.png", Content: bytes.NewReader(testPNG)})); !errors.Is(err, ErrInvalidKYCSubmission) {
+		t.Fatalf("expected resubmitting the current level to be rejected, got %v", err)
+	}
+	if pending, err := kyc.ListSubmissions(ctx, domain.KYCSubmissionPending, 10); err != nil || len(pending) != 0 {
+		t.Fatalf("expected empty review queue, got %v, %v", pending, err)
+	}
+}
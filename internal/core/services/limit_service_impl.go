@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// ErrLimitExceeded dapat dicocokkan dengan errors.Is untuk semua LimitExceededError.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitExceededError menjelaskan limit mana yang terlampaui oleh sebuah operasi.
+type LimitExceededError struct {
+	Operation string  `json:"operation"`
+	Limit     string  `json:"limit"`
+	Max       float64 `json:"max"`
+	Attempted float64 `json:"attempted"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("limit exceeded: %s %s limit is %v, attempted %v", e.Operation, e.Limit, e.Max, e.Attempted)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// LimitService menyimpan kebijakan limit per tier akun dan jenis operasi.
+type LimitService struct {
+	policies map[string]domain.LimitPolicy
+	now      func() time.Time
+}
+
+func NewLimitService(policies []domain.LimitPolicy) *LimitService {
+	policyMap := make(map[string]domain.LimitPolicy, len(policies))
+	for _, policy := range policies {
+		policyMap[limitKey(policy.AccountTier, policy.Operation)] = policy
+	}
+	return &LimitService{policies: policyMap, now: time.Now}
+}
+
+func limitKey(accountTier, operation string) string {
+	if accountTier == "" {
+		accountTier = domain.AccountTierRegular
+	}
+	return accountTier + "/" + operation
+}
+
+// Policy mengembalikan kebijakan limit untuk tier dan operasi tertentu.
+func (s *LimitService) Policy(accountTier, operation string) (domain.LimitPolicy, bool) {
+	policy, ok := s.policies[limitKey(accountTier, operation)]
+	return policy, ok
+}
+
+// DayStart dan MonthStart adalah awal periode limit harian dan bulanan saat ini.
+func (s *LimitService) DayStart() time.Time {
+	now := s.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+func (s *LimitService) MonthStart() time.Time {
+	now := s.now()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+// Check memastikan amount masih berada dalam limit berdasarkan pemakaian harian
+// dan bulanan yang sudah terjadi.
+func (s *LimitService) Check(policy domain.LimitPolicy, amount float64, daily, monthly domain.LimitUsage) error {
+	if policy.PerTransaction > 0 && amount > policy.PerTransaction {
+		return &LimitExceededError{Operation: policy.Operation, Limit: "per_transaction", Max: policy.PerTransaction, Attempted: amount}
+	}
+	if policy.DailyAmount > 0 && daily.Amount+amount > policy.DailyAmount {
+		return &LimitExceededError{Operation: policy.Operation, Limit: "daily_amount", Max: policy.DailyAmount, Attempted: daily.Amount + amount}
+	}
+	if policy.MonthlyAmount > 0 && monthly.Amount+amount > policy.MonthlyAmount {
+		return &LimitExceededError{Operation: policy.Operation, Limit: "monthly_amount", Max: policy.MonthlyAmount, Attempted: monthly.Amount + amount}
+	}
+	if policy.DailyCount > 0 && daily.Count+1 > policy.DailyCount {
+		return &LimitExceededError{Operation: policy.Operation, Limit: "daily_count", Max: float64(policy.DailyCount), Attempted: float64(daily.Count + 1)}
+	}
+	if policy.MonthlyCount > 0 && monthly.Count+1 > policy.MonthlyCount {
+		return &LimitExceededError{Operation: policy.Operation, Limit: "monthly_count", Max: float64(policy.MonthlyCount), Attempted: float64(monthly.Count + 1)}
+	}
+	return nil
+}
+
+// Remaining menghitung sisa limit dari kebijakan dan pemakaian saat ini.
+func (s *LimitService) Remaining(policy domain.LimitPolicy, daily, monthly domain.LimitUsage) domain.RemainingLimit {
+	remaining := domain.RemainingLimit{Operation: policy.Operation}
+	if policy.PerTransaction > 0 {
+		v := policy.PerTransaction
+		remaining.PerTransaction = &v
+	}
+	if policy.DailyAmount > 0 {
+		v := max(policy.DailyAmount-daily.Amount, 0)
+		remaining.DailyAmount = &v
+	}
+	if policy.MonthlyAmount > 0 {
+		v := max(policy.MonthlyAmount-monthly.Amount, 0)
+		remaining.MonthlyAmount = &v
+	}
+	if policy.DailyCount > 0 {
+		v := max(policy.DailyCount-daily.Count, 0)
+		remaining.DailyCount = &v
+	}
+	if policy.MonthlyCount > 0 {
+		v := max(policy.MonthlyCount-monthly.Count, 0)
+		remaining.MonthlyCount = &v
+	}
+	return remaining
+}
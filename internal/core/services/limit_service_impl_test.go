@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestLimitServiceCheck(t *testing.T) {
+	policy := domain.LimitPolicy{
+		AccountTier:    domain.AccountTierRegular,
+		Operation:      domain.CategoryTransfer,
+		PerTransaction: 100,
+		DailyAmount:    150,
+		MonthlyAmount:  1000,
+		DailyCount:     3,
+	}
+	service := NewLimitService([]domain.LimitPolicy{policy})
+
+	cases := []struct {
+		name    string
+		amount  float64
+		daily   domain.LimitUsage
+		monthly domain.LimitUsage
+		limit   string
+	}{
+		{"within limits", 50, domain.LimitUsage{Amount: 50, Count: 1}, domain.LimitUsage{Amount: 50, Count: 1}, ""},
+		{"per transaction", 101, domain.LimitUsage{}, domain.LimitUsage{}, "per_transaction"},
+		{"daily amount", 60, domain.LimitUsage{Amount: 100, Count: 1}, domain.LimitUsage{Amount: 100, Count: 1}, "daily_amount"},
+		{"monthly amount", 60, domain.LimitUsage{}, domain.LimitUsage{Amount: 950, Count: 10}, "monthly_amount"},
+		{"daily count", 10, domain.LimitUsage{Amount: 30, Count: 3}, domain.LimitUsage{Amount: 30, Count: 3}, "daily_count"},
+	}
+	for _, tc := range cases {
+		err := service.Check(policy, tc.amount, tc.daily, tc.monthly)
+		if tc.limit == "" {
+			if err != nil {
+				t.Errorf("%s: expected nil error, got %v", tc.name, err)
+			}
+			continue
+		}
+		var limitErr *LimitExceededError
+		if !errors.As(err, &limitErr) || limitErr.Limit != tc.limit {
+			t.Errorf("%s: expected %s limit error, got %v", tc.name, tc.limit, err)
+		}
+	}
+}
+
+func TestLimitServicePolicyDefaultsToRegularTier(t *testing.T) {
+	service := NewLimitService([]domain.LimitPolicy{{AccountTier: domain.AccountTierRegular, Operation: domain.CategoryWithdraw, PerTransaction: 10}})
+	if _, ok := service.Policy("", domain.CategoryWithdraw); !ok {
+		t.Fatalf("expected policy for empty tier")
+	}
+	if _, ok := service.Policy(domain.AccountTierPremium, domain.CategoryWithdraw); ok {
+		t.Fatalf("expected no policy for premium tier")
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// logFundsMovement mencatat hasil setiap pergerakan dana dengan atribut yang
+// seragam. Penolakan bisnis (saldo kurang, limit, user tidak ditemukan)
+// dicatat sebagai warning dan kegagalan lain sebagai error. account_id adalah
+// rekening yang saldonya berubah; user_id yang login ditambahkan handler log
+// dari context.
+func logFundsMovement(ctx context.Context, logger *slog.Logger, operation string, err error, accountID uuid.UUID, amount float64, txn *domain.Transaction, attrs ...slog.Attr) {
+	outcome := transactionOutcome(err)
+	attrs = append([]slog.Attr{
+		slog.String("operation", operation),
+		slog.String("outcome", outcome),
+		slog.String("account_id", accountID.String()),
+		slog.Float64("amount", amount),
+	}, attrs...)
+
+	level, msg := slog.LevelInfo, "funds moved"
+	switch outcome {
+	case OutcomeSuccess:
+		if txn != nil {
+			attrs = append(attrs, slog.String("transaction_id", txn.TransactionID.String()), slog.Float64("balance_after", txn.BalanceAfter))
+		}
+	case OutcomeError:
+		level, msg = slog.LevelError, "funds movement failed"
+	default:
+		level, msg = slog.LevelWarn, "funds movement rejected"
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
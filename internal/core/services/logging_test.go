@@ -0,0 +1,56 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestTransactionServiceLogsFundsMovements(t *testing.T) {
+	db := setupTestDB(t)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	service := NewTransactionService(&testTransactionRepo{db: db}, db, WithTransactionLogger(logger))
+	from := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	to := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234"}
+	db.Create(&from)
+	db.Create(&to)
+
+	debit, _, err := service.Transfer(context.Background(), from.UserID, to.UserID, 30, "transfer")
+	if err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	service.Withdraw(context.Background(), from.UserID, 500, "withdraw")
+
+	var entries []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var entry map[string]interface{}
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("invalid log line: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d: %s", len(entries), buf.String())
+	}
+
+	moved := entries[0]
+	if moved["level"] != "INFO" || moved["msg"] != "funds moved" || moved["operation"] != domain.CategoryTransfer ||
+		moved["account_id"] != from.UserID.String() || moved["counterparty_id"] != to.UserID.String() ||
+		moved["transaction_id"] != debit.TransactionID.String() || moved["amount"] != 30.0 || moved["balance_after"] != 70.0 {
+		t.Errorf("unexpected transfer log entry: %v", moved)
+	}
+	rejected := entries[1]
+	if rejected["level"] != "WARN" || rejected["outcome"] != OutcomeInsufficientBalance || rejected["error"] != ErrInsufficientBalance.Error() {
+		t.Errorf("unexpected rejection log entry: %v", rejected)
+	}
+	if _, ok := rejected["transaction_id"]; ok {
+		t.Error("rejected movements must not log a transaction id")
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Outcome transaksi dan alasan login gagal yang dilaporkan ke ports.Metrics.
+const (
+	OutcomeSuccess             = "success"
+	OutcomeInsufficientBalance = "insufficient_balance"
+	OutcomeLimitExceeded       = "limit_exceeded"
+	OutcomeNotFound            = "not_found"
+	OutcomeFraudReview         = "fraud_review"
+	OutcomeFraudBlocked        = "fraud_blocked"
+	OutcomeAccountBlocked      = "account_blocked"
+	OutcomeAccountFrozen       = "account_frozen"
+	OutcomeKYCRestricted       = "kyc_restricted"
+	OutcomeError               = "error"
+
+	LoginFailureUnknownUser = "unknown_user"
+	LoginFailureInactive    = "inactive"
+	LoginFailureInvalidPin  = "invalid_pin"
+	LoginFailureBlocked     = "blocked"
+	LoginFailureError       = "error"
+)
+
+// transactionOutcome memetakan hasil Deposit, Withdraw, Transfer, atau Adjust
+// ke label outcome dengan kardinalitas terbatas.
+func transactionOutcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, ErrInsufficientBalance):
+		return OutcomeInsufficientBalance
+	case errors.Is(err, ErrLimitExceeded):
+		return OutcomeLimitExceeded
+	case errors.Is(err, ErrFraudReview):
+		return OutcomeFraudReview
+	case errors.Is(err, ErrFraudBlocked):
+		return OutcomeFraudBlocked
+	case errors.Is(err, ErrAccountBlocked), errors.Is(err, ErrCounterpartyUnderReview), errors.Is(err, ErrAccountUnderReview):
+		return OutcomeAccountBlocked
+	case errors.Is(err, ErrAccountFrozen):
+		return OutcomeAccountFrozen
+	case errors.Is(err, ErrKYCRequired), errors.Is(err, ErrBalanceCapExceeded):
+		return OutcomeKYCRestricted
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return OutcomeNotFound
+	}
+	return OutcomeError
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+// recordingMetrics menyimpan setiap metrik yang dilaporkan service.
+type recordingMetrics struct {
+	transactions  []string
+	loginFailures []string
+}
+
+func (m *recordingMetrics) RecordTransaction(operation, outcome string, amount float64) {
+	m.transactions = append(m.transactions, fmt.Sprintf("%s %s %v", operation, outcome, amount))
+}
+
+func (m *recordingMetrics) RecordLoginFailure(reason string) {
+	m.loginFailures = append(m.loginFailures, reason)
+}
+
+func TestTransactionOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, OutcomeSuccess},
+		{ErrInsufficientBalance, OutcomeInsufficientBalance},
+		{&LimitExceededError{}, OutcomeLimitExceeded},
+		{ErrAccountFrozen, OutcomeAccountFrozen},
+		{fmt.Errorf("find user: %w", gorm.ErrRecordNotFound), OutcomeNotFound},
+		{errors.New("connection reset"), OutcomeError},
+	}
+	for _, tt := range tests {
+		if got := transactionOutcome(tt.err); got != tt.want {
+			t.Errorf("transactionOutcome(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionServiceRecordsMetrics(t *testing.T) {
+	db := setupTestDB(t)
+	metrics := &recordingMetrics{}
+	service := NewTransactionService(&testTransactionRepo{db: db}, db, WithTransactionMetrics(metrics))
+	from := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	to := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234"}
+	db.Create(&from)
+	db.Create(&to)
+
+	service.Deposit(context.Background(), from.UserID, 50, "deposit")
+	service.Withdraw(context.Background(), from.UserID, 500, "withdraw")
+	service.Transfer(context.Background(), from.UserID, to.UserID, 30, "transfer")
+	service.Deposit(context.Background(), uuid.New(), 10, "deposit")
+	service.Adjust(context.Background(), from.UserID, -20, "correction")
+
+	want := []string{
+		"DEPOSIT success 50",
+		"WITHDRAW insufficient_balance 500",
+		"TRANSFER success 30",
+		"DEPOSIT not_found 10",
+		"ADJUSTMENT success 20",
+	}
+	if !reflect.DeepEqual(metrics.transactions, want) {
+		t.Fatalf("unexpected metrics:\n got %v\nwant %v", metrics.transactions, want)
+	}
+}
+
+func TestUserServiceRecordsLoginFailures(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("1234"), bcrypt.MinCost)
+	users := map[string]*domain.User{
+		"active":   {Pin: string(hashed), IsActive: true},
+		"inactive": {Pin: string(hashed), IsActive: false},
+	}
+	repo := &mockUserRepository{
+		findByPhoneNumberFn: func(phone string) (*domain.User, error) {
+			if user, ok := users[phone]; ok {
+				return user, nil
+			}
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	metrics := &recordingMetrics{}
+	service := NewUserService(repo, WithUserMetrics(metrics))
+
+	service.Login(context.Background(), "active", "1234")
+	service.Login(context.Background(), "active", "0000")
+	service.Login(context.Background(), "inactive", "1234")
+	service.Login(context.Background(), "unknown", "1234")
+
+	want := []string{LoginFailureInvalidPin, LoginFailureInactive, LoginFailureUnknownUser}
+	if !reflect.DeepEqual(metrics.loginFailures, want) {
+		t.Fatalf("unexpected login failures: got %v, want %v", metrics.loginFailures, want)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+// notificationBufferSize adalah kapasitas antrean notifikasi per koneksi
+// WebSocket. Koneksi yang antreannya penuh ditutup oleh hub.
+const notificationBufferSize = 32
+
+// NotificationHub mengubah domain event menjadi notifikasi untuk aplikasi
+// mobile (perubahan saldo, transfer masuk, dan pemberitahuan keamanan) lalu
+// mengirimkannya ke koneksi lokal milik user terkait.
+type NotificationHub struct {
+	hub *userHub[domain.Notification]
+}
+
+func NewNotificationHub() *NotificationHub {
+	return &NotificationHub{hub: newUserHub[domain.Notification](notificationBufferSize)}
+}
+
+// Subscribe mendaftarkan koneksi baru untuk userID. Panggil fungsi yang
+// dikembalikan saat koneksi selesai.
+func (h *NotificationHub) Subscribe(userID uuid.UUID) (*Subscription[domain.Notification], func()) {
+	return h.hub.subscribe(userID)
+}
+
+// Close menutup semua koneksi notifikasi saat server berhenti.
+func (h *NotificationHub) Close() {
+	h.hub.closeAll()
+}
+
+// HandleEvent dipasang sebagai subscriber event lokal replika.
+func (h *NotificationHub) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	notifications, err := notificationsFor(event)
+	if err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		h.hub.dispatch(notification.UserID, notification)
+	}
+	return nil
+}
+
+func notificationsFor(event domain.OutboxEvent) ([]domain.Notification, error) {
+	notify := func(userID uuid.UUID, notificationType string, data interface{}) domain.Notification {
+		return domain.Notification{UserID: userID, Type: notificationType, EventID: event.EventID, Data: data, CreatedAt: event.OccurredAt}
+	}
+
+	switch event.EventType {
+	case domain.EventFundsDeposited, domain.EventFundsWithdrawn, domain.EventFundsAdjusted:
+		var payload domain.FundsMovedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return []domain.Notification{
+			notify(payload.UserID, domain.NotificationBalanceUpdate, domain.BalanceUpdate{Balance: payload.Balance, Transaction: payload.Transaction}),
+		}, nil
+	case domain.EventTransferCompleted:
+		var payload domain.TransferCompletedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return []domain.Notification{
+			notify(payload.FromUserID, domain.NotificationBalanceUpdate, domain.BalanceUpdate{Balance: payload.FromBalance, Transaction: payload.Debit}),
+			notify(payload.ToUserID, domain.NotificationBalanceUpdate, domain.BalanceUpdate{Balance: payload.ToBalance, Transaction: payload.Credit}),
+			notify(payload.ToUserID, domain.NotificationIncomingTransfer, domain.IncomingTransfer{
+				FromUserID: payload.FromUserID,
+				Amount:     payload.Amount,
+				Remarks:    payload.Credit.Remarks,
+			}),
+		}, nil
+	case domain.EventPinChanged:
+		return []domain.Notification{
+			notify(event.AggregateID, domain.NotificationSecurityNotice, domain.SecurityNotice{
+				Event:   event.EventType,
+				Message: "Your PIN was changed. If this was not you, contact support immediately.",
+			}),
+		}, nil
+	case domain.EventNewDeviceLogin:
+		var payload domain.NewDeviceLoginPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return []domain.Notification{
+			notify(payload.UserID, domain.NotificationSecurityNotice, domain.SecurityNotice{
+				Event:     event.EventType,
+				Message:   "New login from a device you have not used before.",
+				UserAgent: payload.UserAgent,
+				IPAddress: payload.IPAddress,
+			}),
+		}, nil
+	}
+	return nil, nil
+}
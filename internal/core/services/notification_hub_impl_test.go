@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestNotificationHubTransferNotifiesRecipient(t *testing.T) {
+	hub := NewNotificationHub()
+	from, to := uuid.New(), uuid.New()
+	fromSub, cancelFrom := hub.Subscribe(from)
+	defer cancelFrom()
+	toSub, cancelTo := hub.Subscribe(to)
+	defer cancelTo()
+
+	payload, _ := json.Marshal(domain.TransferCompletedPayload{
+		FromUserID:  from,
+		ToUserID:    to,
+		Amount:      25,
+		Debit:       domain.Transaction{UserID: from, Amount: 25},
+		Credit:      domain.Transaction{UserID: to, Amount: 25, Remarks: "rent"},
+		FromBalance: 75,
+		ToBalance:   125,
+	})
+	event := domain.OutboxEvent{EventID: 7, AggregateID: from, EventType: domain.EventTransferCompleted, Payload: string(payload)}
+	if err := hub.HandleEvent(context.Background(), event); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if n := <-fromSub.Messages; n.Type != domain.NotificationBalanceUpdate || n.Data.(domain.BalanceUpdate).Balance != 75 {
+		t.Fatalf("unexpected notification for sender: %+v", n)
+	}
+	if n := <-toSub.Messages; n.Type != domain.NotificationBalanceUpdate || n.Data.(domain.BalanceUpdate).Balance != 125 {
+		t.Fatalf("unexpected balance notification for recipient: %+v", n)
+	}
+	n := <-toSub.Messages
+	incoming, ok := n.Data.(domain.IncomingTransfer)
+	if n.Type != domain.NotificationIncomingTransfer || !ok || incoming.FromUserID != from || incoming.Remarks != "rent" || n.EventID != 7 {
+		t.Fatalf("unexpected incoming transfer notification: %+v", n)
+	}
+	select {
+	case n := <-fromSub.Messages:
+		t.Fatalf("unexpected extra notification for sender: %+v", n)
+	default:
+	}
+}
+
+func TestNotificationHubSecurityNotices(t *testing.T) {
+	hub := NewNotificationHub()
+	userID := uuid.New()
+	sub, cancel := hub.Subscribe(userID)
+	defer cancel()
+
+	pinPayload, _ := json.Marshal(domain.AccountEventPayload{UserID: userID})
+	devicePayload, _ := json.Marshal(domain.NewDeviceLoginPayload{UserID: userID, UserAgent: "app/2.0", IPAddress: "10.0.0.9"})
+	for _, event := range []domain.OutboxEvent{
+		{EventID: 1, AggregateID: userID, EventType: domain.EventPinChanged, Payload: string(pinPayload)},
+		{EventID: 2, AggregateID: userID, EventType: domain.EventNewDeviceLogin, Payload: string(devicePayload)},
+		{EventID: 3, AggregateID: userID, EventType: domain.EventUserRegistered, Payload: "{}"},
+	} {
+		if err := hub.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	if n := <-sub.Messages; n.Type != domain.NotificationSecurityNotice || n.Data.(domain.SecurityNotice).Event != domain.EventPinChanged {
+		t.Fatalf("unexpected pin notice: %+v", n)
+	}
+	if n := <-sub.Messages; n.Data.(domain.SecurityNotice).IPAddress != "10.0.0.9" {
+		t.Fatalf("unexpected device notice: %+v", n)
+	}
+	select {
+	case n := <-sub.Messages:
+		t.Fatalf("unexpected notification: %+v", n)
+	default:
+	}
+}
+
+func TestNotificationHubClosesSlowSubscriber(t *testing.T) {
+	hub := NewNotificationHub()
+	userID := uuid.New()
+	sub, cancel := hub.Subscribe(userID)
+	defer cancel()
+	for i := 0; i <= notificationBufferSize; i++ {
+		hub.hub.dispatch(userID, domain.Notification{UserID: userID})
+	}
+	select {
+	case <-sub.Closed:
+	default:
+		t.Fatalf("expected slow subscriber to be closed")
+	}
+	if sub.Err() != ErrSubscriberTooSlow {
+		t.Fatalf("expected ErrSubscriberTooSlow, got %v", sub.Err())
+	}
+}
+
+func TestNotificationHubCloseEndsSubscriptions(t *testing.T) {
+	hub := NewNotificationHub()
+	sub, cancel := hub.Subscribe(uuid.New())
+	defer cancel()
+
+	hub.Close()
+	select {
+	case <-sub.Closed:
+	default:
+		t.Fatalf("expected subscription to be closed")
+	}
+	if sub.Err() != ErrHubClosed {
+		t.Fatalf("expected ErrHubClosed, got %v", sub.Err())
+	}
+
+	late, cancelLate := hub.Subscribe(uuid.New())
+	defer cancelLate()
+	if late.Err() != ErrHubClosed {
+		t.Fatalf("expected new subscriptions to be rejected after Close, got %v", late.Err())
+	}
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+const (
+	notificationMaxAttempts = 6
+	notificationBaseBackoff = 15 * time.Second
+	notificationMaxBackoff  = time.Hour
+	notificationBatchSize   = 50
+	// notificationClaimLease memberi waktu satu batch untuk terkirim sebelum
+	// delivery yang belum selesai boleh diklaim replika lain.
+	notificationClaimLease = 10 * time.Minute
+)
+
+// ErrInvalidNotificationPreference dikembalikan untuk preferensi notifikasi
+// yang tidak valid.
+var ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+
+// NotificationService mengirim SMS dan email kepada user saat transfer,
+// penarikan besar, perubahan PIN, dan login dari perangkat baru. Pesan
+// dirender saat event diterima lalu dikirim oleh Run melalui antrean dengan
+// retry exponential backoff; pesan yang gagal notificationMaxAttempts kali
+// dipindahkan ke status DEAD_LETTER.
+type NotificationService struct {
+	notificationRepo ports.NotificationRepository
+	userRepo         ports.UserRepository
+	notifiers        map[string]ports.Notifier
+	largeWithdrawal  float64
+	defaultLanguage  string
+	now              func() time.Time
+}
+
+// NotificationServiceOption mengatur dependensi opsional NotificationService.
+type NotificationServiceOption func(*NotificationService)
+
+// WithNotifier mendaftarkan adapter pengirim untuk channel (domain.ChannelSMS
+// atau domain.ChannelEmail). Channel tanpa adapter tidak dikirimi pesan.
+func WithNotifier(channel string, notifier ports.Notifier) NotificationServiceOption {
+	return func(s *NotificationService) {
+		s.notifiers[channel] = notifier
+	}
+}
+
+// WithLargeWithdrawalThreshold mengatur nominal penarikan minimal yang
+// dikirimi notifikasi; 0 berarti semua penarikan.
+func WithLargeWithdrawalThreshold(amount float64) NotificationServiceOption {
+	return func(s *NotificationService) {
+		s.largeWithdrawal = amount
+	}
+}
+
+// WithDefaultLanguage mengatur bahasa untuk user yang belum mengatur
+// preferensinya.
+func WithDefaultLanguage(language string) NotificationServiceOption {
+	return func(s *NotificationService) {
+		s.defaultLanguage = language
+	}
+}
+
+func NewNotificationService(notificationRepo ports.NotificationRepository, userRepo ports.UserRepository, opts ...NotificationServiceOption) *NotificationService {
+	s := &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		notifiers:        make(map[string]ports.Notifier),
+		defaultLanguage:  domain.LanguageIndonesian,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Preference mengembalikan preferensi notifikasi user, atau bawaan (SMS dalam
+// bahasa default) jika belum pernah diatur.
+func (s *NotificationService) Preference(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreference, error) {
+	preference, err := s.notificationRepo.FindPreference(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.NotificationPreference{UserID: userID, Language: s.defaultLanguage, SMS: true}, nil
+	}
+	return preference, err
+}
+
+// UpdatePreference menyimpan preferensi notifikasi user. Email hanya dapat
+// diaktifkan bersama alamat email yang valid.
+func (s *NotificationService) UpdatePreference(ctx context.Context, userID uuid.UUID, preference domain.NotificationPreference) (*domain.NotificationPreference, error) {
+	preference.UserID = userID
+	preference.Language = strings.ToLower(preference.Language)
+	preference.EmailAddress = strings.TrimSpace(preference.EmailAddress)
+	if !slices.Contains(domain.NotificationLanguages, preference.Language) {
+		return nil, fmt.Errorf("%w: language must be one of %v", ErrInvalidNotificationPreference, domain.NotificationLanguages)
+	}
+	if preference.EmailAddress != "" {
+		if address, err := mail.ParseAddress(preference.EmailAddress); err != nil || address.Address != preference.EmailAddress {
+			return nil, fmt.Errorf("%w: invalid email_address", ErrInvalidNotificationPreference)
+		}
+	}
+	if preference.Email && preference.EmailAddress == "" {
+		return nil, fmt.Errorf("%w: email_address is required to enable email", ErrInvalidNotificationPreference)
+	}
+	if err := s.notificationRepo.SavePreference(ctx, &preference); err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// ListDeliveries mengembalikan SMS dan email terbaru milik user beserta
+// status pengirimannya.
+func (s *NotificationService) ListDeliveries(ctx context.Context, userID uuid.UUID, limit int) ([]domain.NotificationDelivery, error) {
+	return s.notificationRepo.FindDeliveriesByUser(ctx, userID, limit)
+}
+
+// notificationRequest adalah satu pesan yang harus dikirim ke userID.
+type notificationRequest struct {
+	userID   uuid.UUID
+	template string
+	data     notificationData
+	// counterpartyID diisi untuk transfer; namanya dimuat saat merender.
+	counterpartyID uuid.UUID
+}
+
+// HandleEvent memasukkan pesan untuk event ke antrean pengiriman. Dipasang
+// sebagai subscriber pada EventPublisher.
+func (s *NotificationService) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	if len(s.notifiers) == 0 {
+		return nil
+	}
+	requests, err := s.notificationRequests(event)
+	if err != nil || len(requests) == 0 {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(requests)*2)
+	for _, request := range requests {
+		ids = append(ids, request.userID)
+		if request.counterpartyID != uuid.Nil {
+			ids = append(ids, request.counterpartyID)
+		}
+	}
+	users, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]domain.User, len(users))
+	for _, user := range users {
+		byID[user.UserID] = user
+	}
+
+	for _, request := range requests {
+		user, ok := byID[request.userID]
+		if !ok {
+			continue
+		}
+		request.data.FirstName = user.FirstName
+		if counterparty, ok := byID[request.counterpartyID]; ok {
+			request.data.Counterparty = strings.TrimSpace(counterparty.FirstName + " " + counterparty.LastName)
+		}
+		if err := s.enqueue(ctx, event, &user, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) notificationRequests(event domain.OutboxEvent) ([]notificationRequest, error) {
+	switch event.EventType {
+	case domain.EventTransferCompleted:
+		var payload domain.TransferCompletedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return []notificationRequest{
+			{userID: payload.FromUserID, template: domain.TemplateTransferSent, counterpartyID: payload.ToUserID,
+				data: notificationData{Amount: payload.Amount, Balance: payload.FromBalance, Time: event.OccurredAt}},
+			{userID: payload.ToUserID, template: domain.TemplateTransferReceived, counterpartyID: payload.FromUserID,
+				data: notificationData{Amount: payload.Amount, Balance: payload.ToBalance, Time: event.OccurredAt}},
+		}, nil
+	case domain.EventFundsWithdrawn:
+		var payload domain.FundsMovedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		if payload.Transaction.Amount < s.largeWithdrawal {
+			return nil, nil
+		}
+		return []notificationRequest{
+			{userID: payload.UserID, template: domain.TemplateLargeWithdrawal,
+				data: notificationData{Amount: payload.Transaction.Amount, Balance: payload.Balance, Time: event.OccurredAt}},
+		}, nil
+	case domain.EventPinChanged:
+		return []notificationRequest{
+			{userID: event.AggregateID, template: domain.TemplatePinChanged, data: notificationData{Time: event.OccurredAt}},
+		}, nil
+	case domain.EventNewDeviceLogin:
+		var payload domain.NewDeviceLoginPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return []notificationRequest{
+			{userID: payload.UserID, template: domain.TemplateNewDeviceLogin,
+				data: notificationData{UserAgent: payload.UserAgent, IPAddress: payload.IPAddress, Time: event.OccurredAt}},
+		}, nil
+	}
+	return nil, nil
+}
+
+// enqueue merender pesan dalam bahasa pilihan user untuk setiap channel yang
+// diaktifkan dan memiliki adapter.
+func (s *NotificationService) enqueue(ctx context.Context, event domain.OutboxEvent, user *domain.User, request notificationRequest) error {
+	preference, err := s.Preference(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+	subject, body, err := renderNotification(request.template, preference.Language, request.data)
+	if err != nil {
+		return err
+	}
+
+	recipients := map[string]string{}
+	if preference.SMS {
+		recipients[domain.ChannelSMS] = user.PhoneNumber
+	}
+	if preference.Email && preference.EmailAddress != "" {
+		recipients[domain.ChannelEmail] = preference.EmailAddress
+	}
+	for _, channel := range []string{domain.ChannelSMS, domain.ChannelEmail} {
+		recipient, ok := recipients[channel]
+		if !ok || s.notifiers[channel] == nil {
+			continue
+		}
+		delivery := &domain.NotificationDelivery{
+			DeliveryID:    uuid.New(),
+			UserID:        user.UserID,
+			EventID:       event.EventID,
+			Template:      request.template,
+			Channel:       channel,
+			Recipient:     recipient,
+			Body:          body,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: s.now(),
+		}
+		if channel == domain.ChannelEmail {
+			delivery.Subject = subject
+		}
+		if err := s.notificationRepo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverDue mengirim pesan yang sudah jatuh tempo dan mengembalikan jumlah
+// yang berhasil terkirim. Pesan diklaim lebih dulu agar tidak terkirim dua
+// kali oleh replika yang berbeda.
+func (s *NotificationService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.notificationRepo.ClaimDueDeliveries(ctx, s.now(), notificationClaimLease, notificationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for i := range deliveries {
+		ok, err := s.deliver(ctx, &deliveries[i])
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+func (s *NotificationService) deliver(ctx context.Context, delivery *domain.NotificationDelivery) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.deliver",
+		attribute.String("notification.delivery_id", delivery.DeliveryID.String()), attribute.String("notification.channel", delivery.Channel))
+	defer func() { endSpan(span, err) }()
+
+	delivery.Attempts++
+	notifier := s.notifiers[delivery.Channel]
+	if notifier == nil {
+		delivery.Status = domain.DeliveryDeadLetter
+		delivery.LastError = "no notifier for channel " + delivery.Channel
+		return false, s.notificationRepo.UpdateDelivery(ctx, delivery)
+	}
+	if sendErr := notifier.Send(ctx, delivery.Message()); sendErr != nil {
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= notificationMaxAttempts {
+			delivery.Status = domain.DeliveryDeadLetter
+		} else {
+			delivery.NextAttemptAt = s.now().Add(notificationBackoff(delivery.Attempts))
+		}
+		return false, s.notificationRepo.UpdateDelivery(ctx, delivery)
+	}
+	sentAt := s.now()
+	delivery.Status = domain.DeliverySucceeded
+	delivery.SentAt = &sentAt
+	delivery.LastError = ""
+	return true, s.notificationRepo.UpdateDelivery(ctx, delivery)
+}
+
+// notificationBackoff menghitung jeda sebelum percobaan berikutnya: 15 detik
+// digandakan setiap kegagalan, maksimal 1 jam.
+func notificationBackoff(attempts int) time.Duration {
+	backoff := notificationBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > notificationMaxBackoff {
+		return notificationMaxBackoff
+	}
+	return backoff
+}
+
+// Run mengirim pesan yang jatuh tempo setiap interval sampai ctx dibatalkan.
+func (s *NotificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.DeliverDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "notification delivery failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+)
+
+type flakyNotifier struct {
+	failures int
+	sent     []domain.NotificationMessage
+}
+
+func (n *flakyNotifier) Send(ctx context.Context, message domain.NotificationMessage) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("provider unavailable")
+	}
+	n.sent = append(n.sent, message)
+	return nil
+}
+
+func setupNotifications(t *testing.T, notifier *flakyNotifier) (*gorm.DB, *NotificationService, *repository.NotificationRepositoryImpl) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.NotificationPreference{}, &domain.NotificationDelivery{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	repo := repository.NewNotificationRepositoryImpl(db)
+	service := NewNotificationService(repo, repository.NewUserRepositoryImpl(db),
+		WithNotifier(domain.ChannelSMS, notifier), WithNotifier(domain.ChannelEmail, notifier),
+		WithLargeWithdrawalThreshold(1000000))
+	return db, service, repo
+}
+
+func notificationEvent(t *testing.T, id uint64, eventType string, aggregateID uuid.UUID, payload interface{}) domain.OutboxEvent {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	return domain.OutboxEvent{EventID: id, AggregateType: domain.AggregateUser, AggregateID: aggregateID, EventType: eventType,
+		Payload: string(body), OccurredAt: time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)}
+}
+
+func TestNotificationTemplates(t *testing.T) {
+	data := notificationData{FirstName: "Alice", Amount: 1250000.5, Balance: 2000, Counterparty: "Bob", UserAgent: "curl", IPAddress: "10.0.0.1",
+		Time: time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)}
+	for name := range notificationTemplateSources {
+		for _, language := range domain.NotificationLanguages {
+			subject, body, err := renderNotification(name, language, data)
+			if err != nil || subject == "" || !strings.Contains(body, "Alice") {
+				t.Errorf("%s/%s: unexpected render %q %q (%v)", name, language, subject, body, err)
+			}
+		}
+	}
+
+	_, body, _ := renderNotification(domain.TemplateTransferSent, domain.LanguageIndonesian, data)
+	if !strings.Contains(body, "Rp1.250.000,50") || !strings.Contains(body, "05/03/2026 14:30") {
+		t.Fatalf("unexpected indonesian body: %s", body)
+	}
+	_, body, _ = renderNotification(domain.TemplateTransferSent, domain.LanguageEnglish, data)
+	if !strings.Contains(body, "IDR 1,250,000.50") || !strings.Contains(body, "IDR 2,000.") || !strings.Contains(body, "Mar 5, 2026 14:30") {
+		t.Fatalf("unexpected english body: %s", body)
+	}
+	if _, _, err := renderNotification(domain.TemplatePinChanged, "fr", data); err == nil {
+		t.Fatalf("expected error for unknown language")
+	}
+}
+
+func TestNotificationServiceEnqueuesByPreference(t *testing.T) {
+	notifier := &flakyNotifier{}
+	db, service, repo := setupNotifications(t, notifier)
+	ctx := context.Background()
+	alice := domain.User{UserID: uuid.New(), FirstName: "Alice", LastName: "Smith", PhoneNumber: "0811", IsActive: true}
+	bob := domain.User{UserID: uuid.New(), FirstName: "Bob", LastName: "Jones", PhoneNumber: "0812", IsActive: true}
+	db.Create(&alice)
+	db.Create(&bob)
+	if _, err := service.UpdatePreference(ctx, bob.UserID, domain.NotificationPreference{Language: "EN", Email: true, EmailAddress: "bob@example.com"}); err != nil {
+		t.Fatalf("UpdatePreference returned error: %v", err)
+	}
+
+	transfer := notificationEvent(t, 1, domain.EventTransferCompleted, alice.UserID, domain.TransferCompletedPayload{
+		FromUserID: alice.UserID, ToUserID: bob.UserID, Amount: 50000, FromBalance: 150000, ToBalance: 50000,
+	})
+	for i := 0; i < 2; i++ {
+		if err := service.HandleEvent(ctx, transfer); err != nil {
+			t.Fatalf("HandleEvent returned error: %v", err)
+		}
+	}
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 2 {
+		t.Fatalf("expected 2 delivered messages, got %d (%v)", delivered, err)
+	}
+	sms, email := notifier.sent[0], notifier.sent[1]
+	if sms.Channel != domain.ChannelSMS {
+		sms, email = email, sms
+	}
+	if sms.Channel != domain.ChannelSMS || sms.Recipient != "0811" || sms.Subject != "" || !strings.Contains(sms.Body, "Rp50.000 ke Bob Jones") {
+		t.Fatalf("unexpected sms: %+v", sms)
+	}
+	if email.Recipient != "bob@example.com" || email.Subject != "You received IDR 50,000" || !strings.Contains(email.Body, "from Alice Smith") {
+		t.Fatalf("unexpected email: %+v", email)
+	}
+
+	small := notificationEvent(t, 2, domain.EventFundsWithdrawn, alice.UserID, domain.FundsMovedPayload{UserID: alice.UserID, Transaction: domain.Transaction{Amount: 999999}})
+	large := notificationEvent(t, 3, domain.EventFundsWithdrawn, alice.UserID, domain.FundsMovedPayload{UserID: alice.UserID, Transaction: domain.Transaction{Amount: 1000000}})
+	login := notificationEvent(t, 4, domain.EventNewDeviceLogin, alice.UserID, domain.NewDeviceLoginPayload{UserID: alice.UserID, UserAgent: "curl/8.0", IPAddress: "10.0.0.1"})
+	for _, event := range []domain.OutboxEvent{small, large, login} {
+		if err := service.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent returned error: %v", err)
+		}
+	}
+	deliveries, err := repo.FindDeliveriesByUser(ctx, alice.UserID, 10)
+	if err != nil {
+		t.Fatalf("FindDeliveriesByUser returned error: %v", err)
+	}
+	templates := map[string]string{}
+	for _, delivery := range deliveries {
+		templates[delivery.Template] = delivery.Body
+	}
+	if len(deliveries) != 3 || templates[domain.TemplateLargeWithdrawal] == "" || !strings.Contains(templates[domain.TemplateNewDeviceLogin], "(curl/8.0) dengan IP 10.0.0.1") {
+		t.Fatalf("unexpected deliveries: %+v", deliveries)
+	}
+}
+
+func TestNotificationServiceRetriesAndDeadLetters(t *testing.T) {
+	notifier := &flakyNotifier{failures: 2}
+	db, service, repo := setupNotifications(t, notifier)
+	ctx := context.Background()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	user := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "0811", IsActive: true}
+	db.Create(&user)
+
+	if err := service.HandleEvent(ctx, notificationEvent(t, 1, domain.EventPinChanged, user.UserID, domain.AccountEventPayload{UserID: user.UserID})); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 0 {
+		t.Fatalf("expected first attempt to fail, got %d (%v)", delivered, err)
+	}
+	if delivered, _ := service.DeliverDue(ctx); delivered != 0 {
+		t.Fatalf("expected retry to wait for backoff")
+	}
+	now = now.Add(notificationBaseBackoff)
+	service.DeliverDue(ctx)
+	now = now.Add(2 * notificationBaseBackoff)
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 1 {
+		t.Fatalf("expected third attempt to succeed, got %d (%v)", delivered, err)
+	}
+	deliveries, _ := repo.FindDeliveriesByUser(ctx, user.UserID, 10)
+	if deliveries[0].Status != domain.DeliverySucceeded || deliveries[0].Attempts != 3 || deliveries[0].SentAt == nil {
+		t.Fatalf("unexpected delivery: %+v", deliveries[0])
+	}
+
+	notifier.failures = notificationMaxAttempts
+	if err := service.HandleEvent(ctx, notificationEvent(t, 2, domain.EventPinChanged, user.UserID, domain.AccountEventPayload{UserID: user.UserID})); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+	for i := 0; i < notificationMaxAttempts; i++ {
+		service.DeliverDue(ctx)
+		now = now.Add(notificationMaxBackoff)
+	}
+	deliveries, _ = repo.FindDeliveriesByUser(ctx, user.UserID, 10)
+	var deadLetter *domain.NotificationDelivery
+	for i := range deliveries {
+		if deliveries[i].EventID == 2 {
+			deadLetter = &deliveries[i]
+		}
+	}
+	if deadLetter == nil || deadLetter.Status != domain.DeliveryDeadLetter || deadLetter.Attempts != notificationMaxAttempts || deadLetter.LastError != "provider unavailable" {
+		t.Fatalf("expected dead letter after %d attempts, got %+v", notificationMaxAttempts, deadLetter)
+	}
+}
+
+func TestNotificationPreferenceValidation(t *testing.T) {
+	_, service, _ := setupNotifications(t, &flakyNotifier{})
+	ctx := context.Background()
+	userID := uuid.New()
+	if preference, err := service.Preference(ctx, userID); err != nil || preference.Language != domain.LanguageIndonesian || !preference.SMS || preference.Email {
+		t.Fatalf("unexpected default preference: %+v, %v", preference, err)
+	}
+	for _, preference := range []domain.NotificationPreference{
+		{Language: "fr", SMS: true},
+		{Language: "en", Email: true},
+		{Language: "en", Email: true, EmailAddress: "Bob <bob@example.com>"},
+		{Language: "en", EmailAddress: "not-an-email"},
+	} {
+		if _, err := service.UpdatePreference(ctx, userID, preference); !errors.Is(err, ErrInvalidNotificationPreference) {
+			t.Errorf("expected ErrInvalidNotificationPreference for %+v, got %v", preference, err)
+		}
+	}
+}
+
+func TestNotificationServiceClaimsDeliveriesOnce(t *testing.T) {
+	notifier := &flakyNotifier{}
+	db, service, repo := setupNotifications(t, notifier)
+	ctx := context.Background()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	user := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "0811", IsActive: true}
+	db.Create(&user)
+	if err := service.HandleEvent(ctx, notificationEvent(t, 1, domain.EventPinChanged, user.UserID, domain.AccountEventPayload{UserID: user.UserID})); err != nil {
+		t.Fatalf("HandleEvent returned error: %v", err)
+	}
+
+	// replika lain sudah mengklaim pesan ini tetapi belum selesai mengirim
+	if claimed, err := repo.ClaimDueDeliveries(ctx, now, notificationClaimLease, notificationBatchSize); err != nil || len(claimed) != 1 {
+		t.Fatalf("expected 1 claimed delivery, got %d (%v)", len(claimed), err)
+	}
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 0 || len(notifier.sent) != 0 {
+		t.Fatalf("expected claimed delivery to be skipped, got %d (%v)", delivered, err)
+	}
+	now = now.Add(notificationClaimLease)
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 1 {
+		t.Fatalf("expected expired claim to be delivered, got %d (%v)", delivered, err)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+)
+
+// notificationData adalah nilai yang tersedia di template notifikasi.
+type notificationData struct {
+	FirstName    string
+	Amount       float64
+	Balance      float64
+	Counterparty string
+	UserAgent    string
+	IPAddress    string
+	Time         time.Time
+}
+
+// notificationTemplate adalah subject dan isi pesan untuk satu bahasa. SMS
+// hanya memakai Body.
+type notificationTemplate struct {
+	Subject string
+	Body    string
+}
+
+// notificationTemplateSources berisi teks template per template dan bahasa.
+// Setiap template wajib tersedia dalam semua domain.NotificationLanguages.
+var notificationTemplateSources = map[string]map[string]notificationTemplate{
+	domain.TemplateTransferSent: {
+		domain.LanguageIndonesian: {
+			Subject: "Transfer keluar {{amount .Amount}}",
+			Body:    "Hai {{.FirstName}}, transfer {{amount .Amount}} ke {{.Counterparty}} berhasil pada {{when .Time}}. Saldo Anda {{amount .Balance}}. Jika bukan Anda, segera hubungi layanan pelanggan.",
+		},
+		domain.LanguageEnglish: {
+			Subject: "Outgoing transfer of {{amount .Amount}}",
+			Body:    "Hi {{.FirstName}}, your transfer of {{amount .Amount}} to {{.Counterparty}} succeeded on {{when .Time}}. Your balance is {{amount .Balance}}. If this was not you, contact support immediately.",
+		},
+	},
+	domain.TemplateTransferReceived: {
+		domain.LanguageIndonesian: {
+			Subject: "Dana masuk {{amount .Amount}}",
+			Body:    "Hai {{.FirstName}}, Anda menerima {{amount .Amount}} dari {{.Counterparty}} pada {{when .Time}}. Saldo Anda {{amount .Balance}}.",
+		},
+		domain.LanguageEnglish: {
+			Subject: "You received {{amount .Amount}}",
+			Body:    "Hi {{.FirstName}}, you received {{amount .Amount}} from {{.Counterparty}} on {{when .Time}}. Your balance is {{amount .Balance}}.",
+		},
+	},
+	domain.TemplateLargeWithdrawal: {
+		domain.LanguageIndonesian: {
+			Subject: "Penarikan {{amount .Amount}}",
+			Body:    "Hai {{.FirstName}}, penarikan {{amount .Amount}} berhasil pada {{when .Time}}. Saldo Anda {{amount .Balance}}. Jika bukan Anda, segera hubungi layanan pelanggan.",
+		},
+		domain.LanguageEnglish: {
+			Subject: "Withdrawal of {{amount .Amount}}",
+			Body:    "Hi {{.FirstName}}, a withdrawal of {{amount .Amount}} succeeded on {{when .Time}}. Your balance is {{amount .Balance}}. If this was not you, contact support immediately.",
+		},
+	},
+	domain.TemplatePinChanged: {
+		domain.LanguageIndonesian: {
+			Subject: "PIN Anda telah diubah",
+			Body:    "Hai {{.FirstName}}, PIN akun Anda diubah pada {{when .Time}}. Jika bukan Anda, segera hubungi layanan pelanggan.",
+		},
+		domain.LanguageEnglish: {
+			Subject: "Your PIN was changed",
+			Body:    "Hi {{.FirstName}}, the PIN of your account was changed on {{when .Time}}. If this was not you, contact support immediately.",
+		},
+	},
+	domain.TemplateNewDeviceLogin: {
+		domain.LanguageIndonesian: {
+			Subject: "Login dari perangkat baru",
+			Body:    "Hai {{.FirstName}}, akun Anda login dari perangkat baru{{if .UserAgent}} ({{.UserAgent}}){{end}}{{if .IPAddress}} dengan IP {{.IPAddress}}{{end}} pada {{when .Time}}. Jika bukan Anda, segera ubah PIN dan hubungi layanan pelanggan.",
+		},
+		domain.LanguageEnglish: {
+			Subject: "New device login",
+			Body:    "Hi {{.FirstName}}, your account was logged in from a new device{{if .UserAgent}} ({{.UserAgent}}){{end}}{{if .IPAddress}} with IP {{.IPAddress}}{{end}} on {{when .Time}}. If this was not you, change your PIN and contact support immediately.",
+		},
+	},
+}
+
+// parsedTemplate adalah notificationTemplate yang sudah di-parse.
+type parsedTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// notificationTemplates di-parse sekali saat start; template yang tidak valid
+// membuat program panic.
+var notificationTemplates = parseNotificationTemplates()
+
+func parseNotificationTemplates() map[string]map[string]parsedTemplate {
+	parsed := make(map[string]map[string]parsedTemplate, len(notificationTemplateSources))
+	for name, languages := range notificationTemplateSources {
+		parsed[name] = make(map[string]parsedTemplate, len(languages))
+		for language, source := range languages {
+			funcs := notificationFuncs(language)
+			parsed[name][language] = parsedTemplate{
+				subject: template.Must(template.New(name + ".subject").Funcs(funcs).Parse(source.Subject)),
+				body:    template.Must(template.New(name + ".body").Funcs(funcs).Parse(source.Body)),
+			}
+		}
+	}
+	return parsed
+}
+
+// renderNotification merender template dalam bahasa yang diminta.
+func renderNotification(name, language string, data notificationData) (subject, body string, err error) {
+	tmpl, ok := notificationTemplates[name][language]
+	if !ok {
+		return "", "", fmt.Errorf("notification template %s not available in %q", name, language)
+	}
+	var subjectText, bodyText strings.Builder
+	if err := tmpl.subject.Execute(&subjectText, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&bodyText, data); err != nil {
+		return "", "", err
+	}
+	return subjectText.String(), bodyText.String(), nil
+}
+
+// notificationFuncs mengembalikan fungsi format angka dan waktu sesuai
+// bahasa: "Rp1.250.000,50" dan "02/01/2006 15:04" untuk Indonesia,
+// "IDR 1,250,000.50" dan "Jan 2, 2006 15:04" untuk Inggris.
+func notificationFuncs(language string) template.FuncMap {
+	if language == domain.LanguageEnglish {
+		return template.FuncMap{
+			"amount": func(v float64) string { return "IDR " + formatAmount(v, ",", ".") },
+			"when":   func(t time.Time) string { return t.Format("Jan 2, 2006 15:04 MST") },
+		}
+	}
+	return template.FuncMap{
+		"amount": func(v float64) string { return "Rp" + formatAmount(v, ".", ",") },
+		"when":   func(t time.Time) string { return t.Format("02/01/2006 15:04 MST") },
+	}
+}
+
+// formatAmount memformat v dengan pemisah ribuan; dua desimal hanya
+// ditampilkan jika v bukan bilangan bulat.
+func formatAmount(v float64, thousands, decimal string) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	cents := int64(math.Round(v * 100))
+	digits := strconv.FormatInt(cents/100, 10)
+	var grouped strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(thousands)
+		}
+		grouped.WriteRune(d)
+	}
+	if fraction := cents % 100; fraction != 0 {
+		return fmt.Sprintf("%s%s%s%02d", sign, grouped.String(), decimal, fraction)
+	}
+	return sign + grouped.String()
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// recordEvent menulis domain event ke outbox di dalam transaksi database dbTx.
+// Tidak melakukan apa pun jika outbox tidak dikonfigurasi.
+func recordEvent(ctx context.Context, outbox ports.OutboxRepository, dbTx *gorm.DB, aggregateID uuid.UUID, eventType string, payload interface{}) error {
+	if outbox == nil {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return outbox.CreateWithTx(ctx, dbTx, &domain.OutboxEvent{
+		AggregateType: domain.AggregateUser,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       string(data),
+	})
+}
+
+// outboxRelayLock adalah nama JobLock yang dipegang relay selama satu batch.
+const outboxRelayLock = "outbox-relay"
+
+// OutboxRelay memindahkan event dari tabel outbox ke EventPublisher dengan
+// jaminan at-least-once. Event untuk aggregate yang sama dikirim berurutan:
+// jika satu event gagal, event berikutnya dari aggregate tersebut ditunda
+// sampai percobaan berikutnya. Urutan hanya terjaga jika satu relay yang
+// memproses outbox pada satu waktu; dengan beberapa replika pasang
+// WithRelayLock.
+type OutboxRelay struct {
+	outboxRepo ports.OutboxRepository
+	publisher  ports.EventPublisher
+	batchSize  int
+	now        func() time.Time
+	lock       ports.JobLock
+}
+
+// OutboxRelayOption mengatur dependensi opsional OutboxRelay.
+type OutboxRelayOption func(*OutboxRelay)
+
+// WithRelayLock memproses setiap batch hanya selama lock dipegang, sehingga
+// dari beberapa replika hanya satu relay yang mempublikasikan event.
+func WithRelayLock(lock ports.JobLock) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.lock = lock
+	}
+}
+
+func NewOutboxRelay(outboxRepo ports.OutboxRepository, publisher ports.EventPublisher, batchSize int, opts ...OutboxRelayOption) *OutboxRelay {
+	r := &OutboxRelay{outboxRepo: outboxRepo, publisher: publisher, batchSize: batchSize, now: time.Now}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// ProcessBatch mempublikasikan satu batch event dan mengembalikan jumlah event
+// yang berhasil dipublikasikan. Jika lock relay sedang dipegang replika lain,
+// tidak ada event yang diproses.
+func (r *OutboxRelay) ProcessBatch(ctx context.Context) (published int, err error) {
+	if r.lock == nil {
+		return r.processBatch(ctx)
+	}
+	_, err = r.lock.TryRun(ctx, outboxRelayLock, func(ctx context.Context) (err error) {
+		published, err = r.processBatch(ctx)
+		return err
+	})
+	return published, err
+}
+
+func (r *OutboxRelay) processBatch(ctx context.Context) (int, error) {
+	events, err := r.outboxRepo.FindUnpublished(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := make(map[uuid.UUID]bool)
+	published := 0
+	for _, event := range events {
+		if blocked[event.AggregateID] {
+			continue
+		}
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			blocked[event.AggregateID] = true
+			if markErr := r.outboxRepo.MarkFailed(ctx, event.EventID, err.Error()); markErr != nil {
+				return published, markErr
+			}
+			continue
+		}
+		if err := r.outboxRepo.MarkPublished(ctx, event.EventID, r.now()); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// Run memproses outbox setiap interval sampai ctx dibatalkan.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.ProcessBatch(ctx); err != nil {
+			slog.ErrorContext(ctx, "outbox relay failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+)
+
+type testOutboxRepo struct {
+	db *gorm.DB
+}
+
+func (r *testOutboxRepo) CreateWithTx(ctx context.Context, dbTx *gorm.DB, event *domain.OutboxEvent) error {
+	return dbTx.Create(event).Error
+}
+
+func (r *testOutboxRepo) FindUnpublished(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := r.db.Where("published_at IS NULL").Order("event_id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *testOutboxRepo) MarkPublished(ctx context.Context, eventID uint64, publishedAt time.Time) error {
+	return r.db.Model(&domain.OutboxEvent{}).Where("event_id = ?", eventID).Update("published_at", publishedAt).Error
+}
+
+func (r *testOutboxRepo) MarkFailed(ctx context.Context, eventID uint64, reason string) error {
+	return r.db.Model(&domain.OutboxEvent{}).Where("event_id = ?", eventID).
+		Updates(map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": reason}).Error
+}
+
+type publisherFunc func(ctx context.Context, event domain.OutboxEvent) error
+
+func (f publisherFunc) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	return f(ctx, event)
+}
+
+func setupOutboxDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.OutboxEvent{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestOutboxRelayKeepsOrderPerAggregate(t *testing.T) {
+	db := setupOutboxDB(t)
+	repo := &testOutboxRepo{db: db}
+	failing, healthy := uuid.New(), uuid.New()
+	for _, aggregateID := range []uuid.UUID{failing, healthy, failing, healthy} {
+		if err := recordEvent(context.Background(), repo, db, aggregateID, domain.EventFundsDeposited, domain.AccountEventPayload{UserID: aggregateID}); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+	}
+
+	var delivered []domain.OutboxEvent
+	fail := true
+	relay := NewOutboxRelay(repo, publisherFunc(func(ctx context.Context, event domain.OutboxEvent) error {
+		if event.AggregateID == failing && fail {
+			return errors.New("broker unavailable")
+		}
+		delivered = append(delivered, event)
+		return nil
+	}), 10)
+
+	published, err := relay.ProcessBatch(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if published != 2 {
+		t.Fatalf("expected 2 published events, got %d", published)
+	}
+	for _, event := range delivered {
+		if event.AggregateID == failing {
+			t.Fatalf("expected events of failing aggregate to be held back")
+		}
+	}
+
+	fail = false
+	published, err = relay.ProcessBatch(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if published != 2 {
+		t.Fatalf("expected 2 published events on retry, got %d", published)
+	}
+	if delivered[2].EventID > delivered[3].EventID {
+		t.Fatalf("expected events to be delivered in order, got %d before %d", delivered[2].EventID, delivered[3].EventID)
+	}
+
+	var failed domain.OutboxEvent
+	db.First(&failed, "event_id = ?", delivered[2].EventID)
+	if failed.Attempts != 1 || failed.LastError == "" {
+		t.Fatalf("expected failed attempt to be recorded, got %+v", failed)
+	}
+}
+
+// heldLock adalah ports.JobLock yang sedang dipegang replika lain jika held.
+type heldLock struct {
+	held bool
+}
+
+func (l *heldLock) TryRun(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	if l.held {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func TestOutboxRelaySkipsBatchWithoutLock(t *testing.T) {
+	db := setupOutboxDB(t)
+	repo := &testOutboxRepo{db: db}
+	userID := uuid.New()
+	if err := recordEvent(context.Background(), repo, db, userID, domain.EventFundsDeposited, domain.AccountEventPayload{UserID: userID}); err != nil {
+		t.Fatalf("failed to record event: %v", err)
+	}
+
+	var delivered int
+	lock := &heldLock{held: true}
+	relay := NewOutboxRelay(repo, publisherFunc(func(ctx context.Context, event domain.OutboxEvent) error {
+		delivered++
+		return nil
+	}), 10, WithRelayLock(lock))
+
+	if published, err := relay.ProcessBatch(context.Background()); err != nil || published != 0 || delivered != 0 {
+		t.Fatalf("expected no events while another replica holds the lock, got %d (%v)", published, err)
+	}
+	lock.held = false
+	if published, err := relay.ProcessBatch(context.Background()); err != nil || published != 1 || delivered != 1 {
+		t.Fatalf("expected 1 published event once the lock is free, got %d (%v)", published, err)
+	}
+}
+
+func TestTransactionService_Transfer_WritesOutboxEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	service := NewTransactionService(&testTransactionRepo{db: db}, db, WithTransactionOutbox(&testOutboxRepo{db: db}))
+	fromUser := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	toUser := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234", Balance: 50}
+	db.Create(&fromUser)
+	db.Create(&toUser)
+
+	if _, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 30, "transfer"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 300, "transfer"); err == nil {
+		t.Fatalf("expected insufficient balance error")
+	}
+
+	var events []domain.OutboxEvent
+	db.Find(&events)
+	if len(events) != 1 || events[0].EventType != domain.EventTransferCompleted || events[0].AggregateID != fromUser.UserID {
+		t.Fatalf("expected one TransferCompleted event, got %+v", events)
+	}
+	var payload domain.TransferCompletedPayload
+	if err := json.Unmarshal([]byte(events[0].Payload), &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.ToUserID != toUser.UserID || payload.Amount != 30 {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestUserServiceRegisterWritesOutboxEvent(t *testing.T) {
+	db := setupOutboxDB(t)
+	repo := &mockUserRepository{
+		createFn: func(u *domain.User) error {
+			u.UserID = uuid.New()
+			return nil
+		},
+	}
+	service := NewUserService(repo, WithUserOutbox(db, &testOutboxRepo{db: db}))
+	user := &domain.User{PhoneNumber: "08123", Pin: "1234"}
+	if err := service.Register(context.Background(), user); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	var event domain.OutboxEvent
+	if err := db.First(&event).Error; err != nil {
+		t.Fatalf("expected outbox event, got %v", err)
+	}
+	if event.EventType != domain.EventUserRegistered || event.AggregateID != user.UserID {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// RateLimitService membatasi laju request per IP dan per user sesuai
+// kebijakan per route. Bucket disimpan di ports.RateLimitStore.
+type RateLimitService struct {
+	policies []domain.RateLimitPolicy
+	store    ports.RateLimitStore
+	now      func() time.Time
+}
+
+func NewRateLimitService(policies []domain.RateLimitPolicy, store ports.RateLimitStore) *RateLimitService {
+	return &RateLimitService{policies: policies, store: store, now: time.Now}
+}
+
+// Allow mengambil token dari setiap kebijakan yang cocok dengan request dan
+// mengembalikan keputusan yang paling ketat: penolakan dengan RetryAfter
+// terlama, atau sisa token paling sedikit. Hasil nil berarti route tidak
+// dibatasi.
+func (s *RateLimitService) Allow(ctx context.Context, request domain.RateLimitRequest) (*domain.RateLimitDecision, error) {
+	var strictest *domain.RateLimitDecision
+	now := s.now()
+	for _, policy := range s.policies {
+		if !policy.Matches(request.Method, request.Route) {
+			continue
+		}
+		decision, err := s.store.Take(ctx, rateLimitKey(policy, request), policy, now)
+		if err != nil {
+			return nil, err
+		}
+		if strictest == nil || stricter(decision, *strictest) {
+			strictest = &decision
+		}
+	}
+	return strictest, nil
+}
+
+// rateLimitKey membentuk kunci bucket dari nama kebijakan dan identitas
+// peminta, mis. "transfer|user:<uuid>" atau "login|ip:10.0.0.1".
+func rateLimitKey(policy domain.RateLimitPolicy, request domain.RateLimitRequest) string {
+	if policy.Key == domain.RateLimitByUser && request.UserID != "" {
+		return policy.Name + "|user:" + request.UserID
+	}
+	return policy.Name + "|ip:" + request.IPAddress
+}
+
+func stricter(a, b domain.RateLimitDecision) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+// RunJanitor menghapus bucket yang sudah penuh kembali setiap interval
+// sampai ctx dibatalkan, agar store tidak tumbuh tanpa batas.
+func (s *RateLimitService) RunJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.store.Purge(ctx, s.now()); err != nil {
+			slog.ErrorContext(ctx, "rate limit purge failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"hexagonal-go/internal/adapters/ratelimit"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+func rateLimitStores(t *testing.T) map[string]ports.RateLimitStore {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.RateLimitBucket{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return map[string]ports.RateLimitStore{
+		"memory":   ratelimit.NewMemoryStore(),
+		"database": ratelimit.NewDatabaseStore(db),
+	}
+}
+
+func TestRateLimitServiceTokenBucket(t *testing.T) {
+	for name, store := range rateLimitStores(t) {
+		t.Run(name, func(t *testing.T) {
+			policy := domain.RateLimitPolicy{Name: "login", Routes: []string{"POST /login"}, Key: domain.RateLimitByIP, Limit: 2, PeriodSeconds: 60}
+			service := NewRateLimitService([]domain.RateLimitPolicy{policy}, store)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			service.now = func() time.Time { return now }
+			request := domain.RateLimitRequest{Method: "POST", Route: "/login", IPAddress: "10.0.0.1"}
+
+			for i, wantRemaining := range []int{1, 0} {
+				decision, err := service.Allow(context.Background(), request)
+				if err != nil || !decision.Allowed || decision.Remaining != wantRemaining {
+					t.Fatalf("request %d: expected allowed with %d remaining, got %+v, %v", i, wantRemaining, decision, err)
+				}
+			}
+			decision, _ := service.Allow(context.Background(), request)
+			if decision.Allowed || decision.RetryAfter != 30*time.Second || decision.Reset != time.Minute {
+				t.Fatalf("expected rejection for 30s, got %+v", decision)
+			}
+
+			// IP lain memiliki bucket sendiri.
+			other := request
+			other.IPAddress = "10.0.0.2"
+			if decision, _ := service.Allow(context.Background(), other); !decision.Allowed {
+				t.Fatalf("expected other IP to be allowed, got %+v", decision)
+			}
+
+			// Satu token terisi kembali setelah 30 detik.
+			now = now.Add(30 * time.Second)
+			if decision, _ := service.Allow(context.Background(), request); !decision.Allowed || decision.Remaining != 0 {
+				t.Fatalf("expected refilled token, got %+v", decision)
+			}
+
+			// Bucket yang sudah penuh kembali dihapus janitor dan dimulai ulang penuh.
+			now = now.Add(2 * time.Minute)
+			if err := store.Purge(context.Background(), now); err != nil {
+				t.Fatalf("Purge returned error: %v", err)
+			}
+			if decision, _ := service.Allow(context.Background(), request); !decision.Allowed || decision.Remaining != 1 {
+				t.Fatalf("expected full bucket after purge, got %+v", decision)
+			}
+		})
+	}
+}
+
+func TestRateLimitServiceStrictestPolicy(t *testing.T) {
+	money := []string{"POST /deposit", "POST /transfer"}
+	service := NewRateLimitService([]domain.RateLimitPolicy{
+		{Name: "money-user", Routes: money, Key: domain.RateLimitByUser, Limit: 1, PeriodSeconds: 60},
+		{Name: "money-ip", Routes: money, Key: domain.RateLimitByIP, Limit: 3, PeriodSeconds: 60},
+	}, ratelimit.NewMemoryStore())
+	alice := domain.RateLimitRequest{Method: "POST", Route: "/deposit", IPAddress: "10.0.0.1", UserID: "alice"}
+
+	decision, _ := service.Allow(context.Background(), alice)
+	if !decision.Allowed || decision.Policy.Name != "money-user" || decision.Remaining != 0 {
+		t.Fatalf("expected per-user policy as strictest, got %+v", decision)
+	}
+	// Route lain dalam kebijakan yang sama berbagi bucket user.
+	transfer := alice
+	transfer.Route = "/transfer"
+	if decision, _ := service.Allow(context.Background(), transfer); decision.Allowed || decision.Policy.Name != "money-user" {
+		t.Fatalf("expected shared per-user bucket to reject, got %+v", decision)
+	}
+	// User lain dari IP yang sama masih diizinkan sampai limit IP habis.
+	bob := alice
+	bob.UserID = "bob"
+	if decision, _ := service.Allow(context.Background(), bob); !decision.Allowed {
+		t.Fatalf("expected bob to be allowed, got %+v", decision)
+	}
+	if decision, _ := service.Allow(context.Background(), domain.RateLimitRequest{Method: "POST", Route: "/deposit", IPAddress: "10.0.0.1"}); decision.Allowed || decision.Policy.Name != "money-ip" {
+		t.Fatalf("expected per-IP limit to reject, got %+v", decision)
+	}
+	if decision, _ := service.Allow(context.Background(), domain.RateLimitRequest{Method: "GET", Route: "/profile", IPAddress: "10.0.0.1"}); decision != nil {
+		t.Fatalf("expected unlimited route, got %+v", decision)
+	}
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// balanceEpsilon adalah toleransi pembulatan float saat membandingkan saldo.
+const balanceEpsilon = 0.005
+
+// ErrAccountFrozen dikembalikan untuk akun yang dibekukan rekonsiliasi.
+var ErrAccountFrozen = errors.New("account frozen")
+
+// reconciliationLock adalah nama JobLock yang dipegang scheduler selama satu
+// rekonsiliasi.
+const reconciliationLock = "reconciliation"
+
+// ReconciliationService memeriksa konsistensi ledger: rantai BalanceBefore/
+// BalanceAfter tiap user, kecocokan saldo users.balance dengan ledger, dan
+// transaksi yatim (tanpa user).
+type ReconciliationService struct {
+	reconRepo ports.ReconciliationRepository
+	userRepo  ports.UserRepository
+	now       func() time.Time
+	db        *gorm.DB
+	audit     *AuditService
+	lock      ports.JobLock
+}
+
+// ReconciliationServiceOption mengatur dependensi opsional ReconciliationService.
+type ReconciliationServiceOption func(*ReconciliationService)
+
+// WithReconciliationAudit mencatat akun yang dibekukan rekonsiliasi ke audit
+// log dalam transaksi database yang sama dengan pembekuannya.
+func WithReconciliationAudit(db *gorm.DB, audit *AuditService) ReconciliationServiceOption {
+	return func(s *ReconciliationService) {
+		s.db = db
+		s.audit = audit
+	}
+}
+
+// WithReconciliationLock menjalankan rekonsiliasi terjadwal hanya selama
+// lock dipegang, sehingga dari beberapa replika hanya satu yang berjalan.
+func WithReconciliationLock(lock ports.JobLock) ReconciliationServiceOption {
+	return func(s *ReconciliationService) {
+		s.lock = lock
+	}
+}
+
+func NewReconciliationService(reconRepo ports.ReconciliationRepository, userRepo ports.UserRepository, opts ...ReconciliationServiceOption) *ReconciliationService {
+	s := &ReconciliationService{reconRepo: reconRepo, userRepo: userRepo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run menjalankan rekonsiliasi penuh. Jika freeze bernilai true, akun dengan
+// temuan dibekukan lewat UserRepository.SetFrozen. Pembekuan tidak dapat
+// dibatalkan user sendiri; hanya admin (UserService.Unfreeze) yang dapat
+// mencairkannya. Dengan WithReconciliationAudit, user yang memiliki temuan
+// diperiksa ulang dengan baris user terkunci sebelum dilaporkan atau
+// dibekukan.
+func (s *ReconciliationService) Run(ctx context.Context, freeze bool) (_ *domain.ReconciliationReport, err error) {
+	ctx, span := startSpan(ctx, "ReconciliationService.Run")
+	defer func() { endSpan(span, err) }()
+
+	report := &domain.ReconciliationReport{
+		StartedAt:   s.now(),
+		Issues:      []domain.ReconciliationIssue{},
+		FrozenUsers: []uuid.UUID{},
+	}
+
+	users, err := s.reconRepo.FindUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		txs, err := s.reconRepo.FindUserTransactions(ctx, user.UserID)
+		if err != nil {
+			return nil, err
+		}
+		issues := checkUserLedger(user, orderChain(txs))
+		frozen := false
+		if len(issues) > 0 && s.db != nil {
+			// transaksi yang di-commit di antara pembacaan user dan ledger di
+			// atas tampak sebagai temuan palsu
+			txs, issues, frozen, err = s.recheck(ctx, user.UserID, freeze)
+			if err != nil {
+				return nil, err
+			}
+		} else if freeze && len(issues) > 0 && !user.IsFrozen {
+			if err := s.userRepo.SetFrozen(ctx, user.UserID, true); err != nil {
+				return nil, err
+			}
+			frozen = true
+		}
+		report.UsersChecked++
+		report.TransactionsChecked += len(txs)
+		report.Issues = append(report.Issues, issues...)
+		if frozen {
+			report.FrozenUsers = append(report.FrozenUsers, user.UserID)
+		}
+	}
+
+	orphans, err := s.reconRepo.FindOrphanedTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range orphans {
+		txID := tx.TransactionID
+		report.TransactionsChecked++
+		report.Issues = append(report.Issues, domain.ReconciliationIssue{
+			Type:          domain.IssueOrphanedTransaction,
+			UserID:        tx.UserID,
+			TransactionID: &txID,
+			Actual:        tx.Amount,
+			Detail:        "transaction references a user that does not exist",
+		})
+	}
+
+	report.FinishedAt = s.now()
+	return report, nil
+}
+
+// orderChain mengurutkan ulang transaksi dengan CreatedAt yang sama sehingga
+// BalanceBefore tiap baris menyambung ke BalanceAfter baris sebelumnya bila
+// memungkinkan. Input diasumsikan sudah terurut berdasarkan CreatedAt.
+func orderChain(txs []domain.Transaction) []domain.Transaction {
+	ordered := append([]domain.Transaction(nil), txs...)
+	for i := 1; i < len(ordered); i++ {
+		prev := ordered[i-1].BalanceAfter
+		if floatEqual(ordered[i].BalanceBefore, prev) {
+			continue
+		}
+		for j := i + 1; j < len(ordered) && ordered[j].CreatedAt.Equal(ordered[i].CreatedAt); j++ {
+			if floatEqual(ordered[j].BalanceBefore, prev) {
+				ordered[i], ordered[j] = ordered[j], ordered[i]
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+func checkUserLedger(user domain.User, txs []domain.Transaction) []domain.ReconciliationIssue {
+	var issues []domain.ReconciliationIssue
+	var sum, previousAfter float64
+	for i, tx := range txs {
+		txID := tx.TransactionID
+		delta := tx.Amount
+		if tx.TransactionType == domain.TransactionTypeDebit {
+			delta = -tx.Amount
+		}
+		sum += delta
+
+		if !floatEqual(tx.BalanceBefore+delta, tx.BalanceAfter) {
+			issues = append(issues, domain.ReconciliationIssue{
+				Type:          domain.IssueRowInconsistent,
+				UserID:        user.UserID,
+				TransactionID: &txID,
+				Expected:      tx.BalanceBefore + delta,
+				Actual:        tx.BalanceAfter,
+				Detail:        "balance_after does not equal balance_before plus amount",
+			})
+		}
+		if !floatEqual(tx.BalanceBefore, previousAfter) {
+			detail := "balance_before does not match previous balance_after"
+			if i == 0 {
+				detail = "first transaction does not start from zero balance"
+			}
+			issues = append(issues, domain.ReconciliationIssue{
+				Type:          domain.IssueChainGap,
+				UserID:        user.UserID,
+				TransactionID: &txID,
+				Expected:      previousAfter,
+				Actual:        tx.BalanceBefore,
+				Detail:        detail,
+			})
+		}
+		previousAfter = tx.BalanceAfter
+	}
+
+	if len(txs) > 0 && !floatEqual(user.Balance, previousAfter) {
+		issues = append(issues, domain.ReconciliationIssue{
+			Type:     domain.IssueBalanceMismatch,
+			UserID:   user.UserID,
+			Expected: previousAfter,
+			Actual:   user.Balance,
+			Detail:   "user balance does not match last balance_after",
+		})
+	}
+	if !floatEqual(user.Balance, sum) {
+		issues = append(issues, domain.ReconciliationIssue{
+			Type:     domain.IssueLedgerSumMismatch,
+			UserID:   user.UserID,
+			Expected: sum,
+			Actual:   user.Balance,
+			Detail:   "user balance does not match sum of credits minus debits",
+		})
+	}
+	return issues
+}
+
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) < balanceEpsilon
+}
+
+// recheck memeriksa ulang ledger user dengan baris user terkunci, sehingga
+// saldo dan transaksi terbaca dari keadaan yang sama, lalu membekukan akun di
+// transaksi yang sama jika freeze bernilai true dan temuan masih ada.
+func (s *ReconciliationService) recheck(ctx context.Context, userID uuid.UUID, freeze bool) (txs []domain.Transaction, issues []domain.ReconciliationIssue, frozen bool, err error) {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := lockUser(tx, &user, userID); err != nil {
+			return err
+		}
+		var err error
+		if txs, err = s.reconRepo.WithTx(tx).FindUserTransactions(ctx, userID); err != nil {
+			return err
+		}
+		issues = checkUserLedger(user, orderChain(txs))
+		if !freeze || len(issues) == 0 || user.IsFrozen {
+			return nil
+		}
+		if err := s.userRepo.WithTx(tx).SetFrozen(ctx, userID, true); err != nil {
+			return err
+		}
+		frozen = true
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditAccountFrozen,
+			TargetID: &userID,
+			Changes:  map[string]domain.AuditChange{"is_frozen": {Before: false, After: true}},
+			Metadata: map[string]interface{}{"reason": "reconciliation", "issues": len(issues)},
+		})
+	})
+	return txs, issues, frozen, err
+}
+
+// RunScheduler menjalankan rekonsiliasi setiap interval sampai ctx dibatalkan
+// dan mencatat ringkasan hasilnya ke log. Dengan WithReconciliationLock,
+// putaran dilewati jika lock sedang dipegang replika lain.
+func (s *ReconciliationService) RunScheduler(ctx context.Context, interval time.Duration, freeze bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		report, err := s.scheduledRun(ctx, freeze)
+		if report == nil && err == nil {
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "reconciliation failed", "error", err)
+			continue
+		}
+		level := slog.LevelInfo
+		if len(report.Issues) > 0 {
+			level = slog.LevelWarn
+		}
+		slog.Log(ctx, level, "reconciliation finished",
+			"users_checked", report.UsersChecked, "transactions_checked", report.TransactionsChecked,
+			"issues", len(report.Issues), "accounts_frozen", len(report.FrozenUsers))
+	}
+}
+
+// scheduledRun menjalankan Run di bawah lock scheduler. Report nil tanpa
+// error berarti replika lain sedang menjalankan rekonsiliasi.
+func (s *ReconciliationService) scheduledRun(ctx context.Context, freeze bool) (report *domain.ReconciliationReport, err error) {
+	if s.lock == nil {
+		return s.Run(ctx, freeze)
+	}
+	_, err = s.lock.TryRun(ctx, reconciliationLock, func(ctx context.Context) (err error) {
+		report, err = s.Run(ctx, freeze)
+		return err
+	})
+	return report, err
+}
+
+// SummarizeReconciliation membuat ringkasan satu baris dari laporan rekonsiliasi.
+func SummarizeReconciliation(report *domain.ReconciliationReport) string {
+	return fmt.Sprintf("reconciliation: %d users, %d transactions, %d issues, %d accounts frozen",
+		report.UsersChecked, report.TransactionsChecked, len(report.Issues), len(report.FrozenUsers))
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+type testReconciliationRepo struct {
+	db *gorm.DB
+}
+
+func (r *testReconciliationRepo) FindUsers(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.Find(&users).Error
+	return users, err
+}
+
+func (r *testReconciliationRepo) FindUserTransactions(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
+	var txs []domain.Transaction
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&txs).Error
+	return txs, err
+}
+
+func (r *testReconciliationRepo) FindOrphanedTransactions(ctx context.Context) ([]domain.Transaction, error) {
+	var txs []domain.Transaction
+	err := r.db.Where("NOT EXISTS (SELECT 1 FROM users WHERE users.user_id = transactions.user_id)").Find(&txs).Error
+	return txs, err
+}
+
+func (r *testReconciliationRepo) WithTx(dbTx *gorm.DB) ports.ReconciliationRepository {
+	return &testReconciliationRepo{db: dbTx}
+}
+
+// racingReconciliationRepo membukukan deposit tepat sebelum ledger pertama
+// dibaca, seolah transaksi lain di-commit di antara pembacaan user dan
+// ledger.
+type racingReconciliationRepo struct {
+	testReconciliationRepo
+	race func()
+}
+
+func (r *racingReconciliationRepo) FindUserTransactions(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
+	if r.race != nil {
+		r.race()
+		r.race = nil
+	}
+	return r.testReconciliationRepo.FindUserTransactions(ctx, userID)
+}
+
+func createLedger(db *gorm.DB, userID uuid.UUID, entries ...domain.Transaction) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range entries {
+		entries[i].TransactionID = uuid.New()
+		entries[i].UserID = userID
+		entries[i].CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		db.Create(&entries[i])
+	}
+}
+
+func TestReconciliationServiceRun(t *testing.T) {
+	db := setupTestDB(t)
+	var frozen []uuid.UUID
+	userRepo := &mockUserRepository{
+		setActiveFn: func(userID uuid.UUID, active bool) error {
+			t.Errorf("expected reconciliation to freeze instead of deactivating")
+			return nil
+		},
+		setFrozenFn: func(userID uuid.UUID, freeze bool) error {
+			if !freeze {
+				t.Errorf("expected account to be frozen")
+			}
+			frozen = append(frozen, userID)
+			return nil
+		},
+	}
+	service := NewReconciliationService(&testReconciliationRepo{db: db}, userRepo)
+
+	healthy := domain.User{UserID: uuid.New(), PhoneNumber: "111", Balance: 70, IsActive: true}
+	broken := domain.User{UserID: uuid.New(), PhoneNumber: "222", Balance: 90, IsActive: true}
+	db.Create(&healthy)
+	db.Create(&broken)
+	createLedger(db, healthy.UserID,
+		domain.Transaction{TransactionType: domain.TransactionTypeCredit, Amount: 100, BalanceBefore: 0, BalanceAfter: 100},
+		domain.Transaction{TransactionType: domain.TransactionTypeDebit, Amount: 30, BalanceBefore: 100, BalanceAfter: 70},
+	)
+	createLedger(db, broken.UserID,
+		domain.Transaction{TransactionType: domain.TransactionTypeCredit, Amount: 100, BalanceBefore: 0, BalanceAfter: 100},
+		domain.Transaction{TransactionType: domain.TransactionTypeDebit, Amount: 30, BalanceBefore: 90, BalanceAfter: 60},
+	)
+	createLedger(db, uuid.New(),
+		domain.Transaction{TransactionType: domain.TransactionTypeCredit, Amount: 10, BalanceBefore: 0, BalanceAfter: 10},
+	)
+
+	report, err := service.Run(context.Background(), true)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if report.UsersChecked != 2 || report.TransactionsChecked != 5 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+
+	found := map[string]bool{}
+	for _, issue := range report.Issues {
+		if issue.UserID == healthy.UserID {
+			t.Errorf("unexpected issue for healthy user: %+v", issue)
+		}
+		found[issue.Type] = true
+	}
+	for _, issueType := range []string{domain.IssueChainGap, domain.IssueBalanceMismatch, domain.IssueLedgerSumMismatch, domain.IssueOrphanedTransaction} {
+		if !found[issueType] {
+			t.Errorf("expected %s issue, got %+v", issueType, report.Issues)
+		}
+	}
+	if len(frozen) != 1 || frozen[0] != broken.UserID {
+		t.Fatalf("expected only broken user to be frozen, got %v", frozen)
+	}
+}
+
+func TestReconciliationServiceIgnoresConcurrentTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.AuditEntry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	user := domain.User{UserID: uuid.New(), PhoneNumber: "111", Balance: 100, IsActive: true}
+	db.Create(&user)
+	createLedger(db, user.UserID,
+		domain.Transaction{TransactionType: domain.TransactionTypeCredit, Amount: 100, BalanceBefore: 0, BalanceAfter: 100},
+	)
+	repo := &racingReconciliationRepo{testReconciliationRepo: testReconciliationRepo{db: db}, race: func() {
+		db.Create(&domain.Transaction{TransactionID: uuid.New(), UserID: user.UserID, TransactionType: domain.TransactionTypeCredit,
+			Amount: 50, BalanceBefore: 100, BalanceAfter: 150, CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)})
+		db.Model(&domain.User{}).Where("user_id = ?", user.UserID).Update("balance", 150)
+	}}
+	service := NewReconciliationService(repo, repository.NewUserRepositoryImpl(db), WithReconciliationAudit(db, NewAuditService(repository.NewAuditRepositoryImpl(db), db)))
+
+	report, err := service.Run(context.Background(), true)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(report.Issues) != 0 || len(report.FrozenUsers) != 0 || report.TransactionsChecked != 2 {
+		t.Fatalf("expected a clean report after the recheck, got %+v", report)
+	}
+	var updated domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	if updated.IsFrozen {
+		t.Fatalf("expected healthy account to stay unfrozen")
+	}
+}
+
+func TestOrderChainResolvesEqualTimestamps(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	txs := []domain.Transaction{
+		{TransactionType: domain.TransactionTypeCredit, Amount: 100, BalanceBefore: 0, BalanceAfter: 100, CreatedAt: at},
+		{TransactionType: domain.TransactionTypeDebit, Amount: 5, BalanceBefore: 60, BalanceAfter: 55, CreatedAt: at.Add(time.Second)},
+		{TransactionType: domain.TransactionTypeDebit, Amount: 40, BalanceBefore: 100, BalanceAfter: 60, CreatedAt: at.Add(time.Second)},
+	}
+	issues := checkUserLedger(domain.User{Balance: 55}, orderChain(txs))
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+}
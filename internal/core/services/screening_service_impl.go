@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+var (
+	// ErrAccountBlocked dikembalikan untuk akun yang diblokir karena kasus
+	// screening daftar pantauan dikonfirmasi.
+	ErrAccountBlocked = errors.New("account blocked")
+	// ErrCounterpartyUnderReview dikembalikan saat penerima transfer memiliki
+	// kasus screening yang belum diputuskan dengan skor di atas block_threshold.
+	ErrCounterpartyUnderReview = errors.New("counterparty under watchlist review")
+	// ErrAccountUnderReview dikembalikan saat pengirim dana memiliki kasus
+	// screening yang belum diputuskan dengan skor di atas block_threshold.
+	ErrAccountUnderReview = errors.New("account under watchlist review")
+	// ErrHitNotPending dikembalikan saat memutuskan kasus yang sudah diputuskan.
+	ErrHitNotPending = errors.New("screening hit is not pending")
+)
+
+// ScreeningService mencocokkan nama user dengan daftar pantauan dan
+// mengelola kasus yang ditemukan.
+type ScreeningService struct {
+	screeningRepo ports.ScreeningRepository
+	source        ports.WatchlistSource
+	db            *gorm.DB
+	config        domain.ScreeningConfig
+	audit         *AuditService
+
+	mu      sync.RWMutex
+	entries []watchlistName
+}
+
+// watchlistName adalah satu nama (utama atau alias) entri daftar pantauan
+// yang sudah dinormalisasi untuk pencocokan.
+type watchlistName struct {
+	entry      *domain.WatchlistEntry
+	normalized string
+}
+
+// ScreeningServiceOption mengatur dependensi opsional ScreeningService.
+type ScreeningServiceOption func(*ScreeningService)
+
+// WithScreeningAudit mencatat kasus baru dan keputusan petugas ke audit log.
+func WithScreeningAudit(audit *AuditService) ScreeningServiceOption {
+	return func(s *ScreeningService) {
+		s.audit = audit
+	}
+}
+
+func NewScreeningService(screeningRepo ports.ScreeningRepository, source ports.WatchlistSource, db *gorm.DB, config domain.ScreeningConfig, opts ...ScreeningServiceOption) *ScreeningService {
+	s := &ScreeningService{screeningRepo: screeningRepo, source: source, db: db, config: config}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Reload memuat ulang daftar pantauan dari source. Jika gagal, daftar yang
+// lama tetap dipakai.
+func (s *ScreeningService) Reload(ctx context.Context) error {
+	entries, err := s.source.Load(ctx)
+	if err != nil {
+		return err
+	}
+	names := make([]watchlistName, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+			if normalized := normalizeName(name); normalized != "" {
+				names = append(names, watchlistName{entry: entry, normalized: normalized})
+			}
+		}
+	}
+	s.mu.Lock()
+	s.entries = names
+	s.mu.Unlock()
+	slog.InfoContext(ctx, "watchlists loaded", "entries", len(entries), "names", len(names))
+	return nil
+}
+
+// RunReloader memuat ulang daftar pantauan setiap interval sampai ctx selesai.
+func (s *ScreeningService) RunReloader(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.Reload(ctx); err != nil {
+			slog.ErrorContext(ctx, "watchlist reload failed", "error", err)
+		}
+	}
+}
+
+// Match mengembalikan entri daftar pantauan yang mirip dengan nama user,
+// satu hit dengan skor tertinggi per entri, tanpa menyimpannya.
+func (s *ScreeningService) Match(firstName, lastName string) []domain.ScreeningHit {
+	subject := strings.TrimSpace(firstName + " " + lastName)
+	normalized := normalizeName(subject)
+	if normalized == "" {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	best := make(map[*domain.WatchlistEntry]float64)
+	for _, name := range s.entries {
+		score := jaroWinkler(normalized, name.normalized)
+		if score >= s.config.MatchThreshold && score > best[name.entry] {
+			best[name.entry] = score
+		}
+	}
+	hits := make([]domain.ScreeningHit, 0, len(best))
+	for entry, score := range best {
+		hits = append(hits, domain.ScreeningHit{
+			List:        entry.List,
+			EntryID:     entry.EntryID,
+			EntryName:   entry.Name,
+			Program:     entry.Program,
+			MatchedName: subject,
+			Score:       float64(int(score*1000)) / 1000,
+		})
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
+
+// ScreenWithTx mencocokkan user dan menyimpan hit yang belum pernah tercatat
+// sebagai kasus PENDING di dalam dbTx. Mengembalikan hit yang baru dibuat.
+func (s *ScreeningService) ScreenWithTx(ctx context.Context, dbTx *gorm.DB, user *domain.User, trigger string) ([]domain.ScreeningHit, error) {
+	var created []domain.ScreeningHit
+	for _, hit := range s.Match(user.FirstName, user.LastName) {
+		hit.HitID = uuid.New()
+		hit.UserID = user.UserID
+		hit.Trigger = trigger
+		hit.Status = domain.ScreeningHitPending
+		ok, err := s.screeningRepo.CreateHitWithTx(ctx, dbTx, &hit)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if err := recordAudit(ctx, s.audit, dbTx, AuditRecord{
+			Action:   domain.AuditScreeningHit,
+			TargetID: &user.UserID,
+			Metadata: map[string]interface{}{"hit_id": hit.HitID, "list": hit.List, "entry_id": hit.EntryID, "score": hit.Score, "trigger": trigger},
+		}); err != nil {
+			return nil, err
+		}
+		created = append(created, hit)
+	}
+	return created, nil
+}
+
+// ScreenCounterparty menyaring penerima transfer dalam transaksi database
+// tersendiri sehingga hit tetap tersimpan walaupun transfer ditolak.
+func (s *ScreeningService) ScreenCounterparty(ctx context.Context, userID uuid.UUID) error {
+	return s.screenAccount(ctx, userID, domain.ScreeningTriggerTransfer, ErrCounterpartyUnderReview)
+}
+
+// ScreenSender menyaring pengirim penarikan atau transfer, sehingga nama
+// yang diganti setelah registrasi tetap tersaring sebelum dana keluar.
+func (s *ScreeningService) ScreenSender(ctx context.Context, userID uuid.UUID) error {
+	return s.screenAccount(ctx, userID, domain.ScreeningTriggerOutgoing, ErrAccountUnderReview)
+}
+
+// screenAccount menyaring user dengan pemicu trigger dan mengembalikan
+// underReviewErr jika user memiliki kasus PENDING berskor minimal
+// block_threshold.
+func (s *ScreeningService) screenAccount(ctx context.Context, userID uuid.UUID, trigger string, underReviewErr error) error {
+	var underReview bool
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.First(&user, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		if user.IsBlocked {
+			return ErrAccountBlocked
+		}
+		if _, err := s.ScreenWithTx(ctx, tx, &user, trigger); err != nil {
+			return err
+		}
+		var err error
+		underReview, err = s.screeningRepo.HasPendingHitWithTx(ctx, tx, userID, s.config.BlockThreshold)
+		return err
+	})
+	if err == nil && underReview {
+		err = underReviewErr
+	}
+	return err
+}
+
+// ClearHit menutup kasus sebagai salah cocok. Entri yang sama tidak akan
+// memicu kasus baru untuk user ini.
+func (s *ScreeningService) ClearHit(ctx context.Context, hitID uuid.UUID, note string) (*domain.ScreeningHit, error) {
+	return s.decide(ctx, hitID, domain.ScreeningHitCleared, note)
+}
+
+// ConfirmHit mengonfirmasi kasus dan memblokir akun user.
+func (s *ScreeningService) ConfirmHit(ctx context.Context, hitID uuid.UUID, note string) (*domain.ScreeningHit, error) {
+	return s.decide(ctx, hitID, domain.ScreeningHitConfirmed, note)
+}
+
+func (s *ScreeningService) decide(ctx context.Context, hitID uuid.UUID, status, note string) (_ *domain.ScreeningHit, err error) {
+	ctx, span := startSpan(ctx, "ScreeningService.decide")
+	defer func() { endSpan(span, err) }()
+
+	var hit *domain.ScreeningHit
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if hit, err = s.screeningRepo.FindHitForUpdateWithTx(ctx, tx, hitID); err != nil {
+			return err
+		}
+		if hit.Status != domain.ScreeningHitPending {
+			return ErrHitNotPending
+		}
+		now := time.Now()
+		hit.Status = status
+		hit.ReviewedBy = AuditActorFrom(ctx).Name
+		hit.ReviewNote = note
+		hit.ReviewedAt = &now
+		if err := s.screeningRepo.UpdateHitWithTx(ctx, tx, hit); err != nil {
+			return err
+		}
+		record := AuditRecord{
+			Action:   domain.AuditScreeningCleared,
+			TargetID: &hit.UserID,
+			Metadata: map[string]interface{}{"hit_id": hit.HitID, "list": hit.List, "entry_id": hit.EntryID, "note": note},
+		}
+		if status == domain.ScreeningHitConfirmed {
+			var user domain.User
+			if err := tx.First(&user, "user_id = ?", hit.UserID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&user).Update("is_blocked", true).Error; err != nil {
+				return err
+			}
+			record.Action = domain.AuditScreeningConfirmed
+			record.Changes = map[string]domain.AuditChange{"is_blocked": {Before: false, After: true}}
+		}
+		return recordAudit(ctx, s.audit, tx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hit, nil
+}
+
+func (s *ScreeningService) ListHits(ctx context.Context, status string, limit int) ([]domain.ScreeningHit, error) {
+	return s.screeningRepo.FindHits(ctx, status, limit)
+}
+
+func (s *ScreeningService) GetHit(ctx context.Context, hitID uuid.UUID) (*domain.ScreeningHit, error) {
+	return s.screeningRepo.FindHitByID(ctx, hitID)
+}
+
+// normalizeName menyamakan huruf kecil, membuang tanda baca, dan mengurutkan
+// kata sehingga "SMITH, John" dan "john smith" dibandingkan sebagai nama yang
+// sama.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// jaroWinkler menghitung kemiripan Jaro-Winkler dua string antara 0 dan 1.
+func jaroWinkler(a, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	window := max(len(s1), len(s2))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		for j := max(0, i-window); j < min(len(s2), i+window+1); j++ {
+			if !matched2[j] && s1[i] == s2[j] {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, k := 0, 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[k] {
+			k++
+		}
+		if s1[i] != s2[k] {
+			transpositions++
+		}
+		k++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s1), len(s2)) && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/core/domain"
+)
+
+type staticWatchlist []domain.WatchlistEntry
+
+func (w staticWatchlist) Load(context.Context) ([]domain.WatchlistEntry, error) {
+	return w, nil
+}
+
+func setupScreening(t *testing.T) (*gorm.DB, *ScreeningService) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.ScreeningHit{}, &domain.AuditEntry{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	screening := NewScreeningService(repository.NewScreeningRepositoryImpl(db), staticWatchlist{
+		{List: "ofac", EntryID: "1", Name: "Viktor Bout", Aliases: []string{"Victor Butt"}, Program: "ARMS"},
+		{List: "ofac", EntryID: "2", Name: "Example Trading LLC"},
+	}, db, domain.ScreeningConfig{MatchThreshold: 0.85, BlockThreshold: 0.95})
+	if err := screening.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	return db, screening
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.840},
+		{"abc", "xyz", 0},
+		{"same", "same", 1},
+	}
+	for _, tt := range tests {
+		if got := jaroWinkler(tt.a, tt.b); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("jaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestScreeningMatch(t *testing.T) {
+	_, screening := setupScreening(t)
+	if hits := screening.Match("BOUT,", "viktor"); len(hits) != 1 || hits[0].Score != 1 || hits[0].EntryID != "1" {
+		t.Fatalf("expected exact match regardless of order and case, got %+v", hits)
+	}
+	if hits := screening.Match("Victor", "Bout"); len(hits) != 1 || hits[0].Score >= 1 || hits[0].Score < 0.95 {
+		t.Fatalf("expected fuzzy match, got %+v", hits)
+	}
+	if hits := screening.Match("Alice", "Smith"); len(hits) != 0 {
+		t.Fatalf("expected no match, got %+v", hits)
+	}
+}
+
+func TestScreeningCaseManagement(t *testing.T) {
+	db, screening := setupScreening(t)
+	ctx := context.Background()
+	repo := &testTransactionRepo{db: db}
+	transactions := NewTransactionService(repo, db, WithTransactionScreening(screening))
+	sender := domain.User{UserID: uuid.New(), FirstName: "Alice", PhoneNumber: "1", Balance: 1000, IsActive: true}
+	viktor := domain.User{UserID: uuid.New(), FirstName: "Viktor", LastName: "Bout", PhoneNumber: "2", IsActive: true}
+	victor := domain.User{UserID: uuid.New(), FirstName: "Victor", LastName: "Bout", PhoneNumber: "3", IsActive: true}
+	db.Create(&sender)
+	db.Create(&viktor)
+	db.Create(&victor)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := transactions.Transfer(ctx, sender.UserID, viktor.UserID, 10, ""); !errors.Is(err, ErrCounterpartyUnderReview) {
+			t.Fatalf("expected ErrCounterpartyUnderReview, got %v", err)
+		}
+	}
+	hits, err := screening.ListHits(ctx, domain.ScreeningHitPending, 10)
+	if err != nil || len(hits) != 1 || hits[0].Trigger != domain.ScreeningTriggerTransfer {
+		t.Fatalf("expected a single deduplicated hit, got %+v, %v", hits, err)
+	}
+
+	if _, err := screening.ConfirmHit(ctx, hits[0].HitID, "confirmed"); err != nil {
+		t.Fatalf("ConfirmHit returned error: %v", err)
+	}
+	if _, err := screening.ClearHit(ctx, hits[0].HitID, ""); !errors.Is(err, ErrHitNotPending) {
+		t.Fatalf("expected ErrHitNotPending, got %v", err)
+	}
+	if _, _, err := transactions.Transfer(ctx, sender.UserID, viktor.UserID, 10, ""); !errors.Is(err, ErrAccountBlocked) {
+		t.Fatalf("expected ErrAccountBlocked for blocked payee, got %v", err)
+	}
+	if _, err := transactions.Withdraw(ctx, viktor.UserID, 10, ""); !errors.Is(err, ErrAccountBlocked) {
+		t.Fatalf("expected ErrAccountBlocked for blocked user, got %v", err)
+	}
+
+	// Kasus yang dibersihkan tidak muncul lagi untuk entri yang sama.
+	if _, _, err := transactions.Transfer(ctx, sender.UserID, victor.UserID, 10, ""); !errors.Is(err, ErrCounterpartyUnderReview) {
+		t.Fatalf("expected ErrCounterpartyUnderReview, got %v", err)
+	}
+	hits, _ = screening.ListHits(ctx, domain.ScreeningHitPending, 10)
+	if len(hits) != 1 || hits[0].UserID != victor.UserID {
+		t.Fatalf("expected pending hit for victor, got %+v", hits)
+	}
+	if _, err := screening.ClearHit(ctx, hits[0].HitID, "different person"); err != nil {
+		t.Fatalf("ClearHit returned error: %v", err)
+	}
+	if _, _, err := transactions.Transfer(ctx, sender.UserID, victor.UserID, 10, ""); err != nil {
+		t.Fatalf("expected transfer after clearing, got %v", err)
+	}
+	if hits, _ := screening.ListHits(ctx, "", 10); len(hits) != 2 {
+		t.Fatalf("expected no new hits after clearing, got %+v", hits)
+	}
+}
+
+func TestScreeningRenamedSender(t *testing.T) {
+	db, screening := setupScreening(t)
+	ctx := context.Background()
+	users := NewUserService(repository.NewUserRepositoryImpl(db), WithUserScreening(db, screening))
+	transactions := NewTransactionService(&testTransactionRepo{db: db}, db, WithTransactionScreening(screening))
+	sender := domain.User{UserID: uuid.New(), FirstName: "Alice", LastName: "Smith", PhoneNumber: "1", Balance: 1000, IsActive: true}
+	payee := domain.User{UserID: uuid.New(), FirstName: "Bob", PhoneNumber: "2", IsActive: true}
+	db.Create(&sender)
+	db.Create(&payee)
+
+	if err := users.UpdateProfile(ctx, &domain.User{UserID: sender.UserID, FirstName: "Viktor", LastName: "Bout", PhoneNumber: "1"}); err != nil {
+		t.Fatalf("UpdateProfile returned error: %v", err)
+	}
+	hits, err := screening.ListHits(ctx, domain.ScreeningHitPending, 10)
+	if err != nil || len(hits) != 1 || hits[0].UserID != sender.UserID || hits[0].Trigger != domain.ScreeningTriggerProfileUpdate {
+		t.Fatalf("expected a PROFILE_UPDATE hit for the renamed user, got %+v, %v", hits, err)
+	}
+
+	if _, _, err := transactions.Transfer(ctx, sender.UserID, payee.UserID, 10, ""); !errors.Is(err, ErrAccountUnderReview) {
+		t.Fatalf("expected ErrAccountUnderReview for transfer, got %v", err)
+	}
+	if _, err := transactions.Withdraw(ctx, sender.UserID, 10, ""); !errors.Is(err, ErrAccountUnderReview) {
+		t.Fatalf("expected ErrAccountUnderReview for withdrawal, got %v", err)
+	}
+	var updated domain.User
+	db.First(&updated, "user_id = ?", sender.UserID)
+	if updated.Balance != 1000 {
+		t.Fatalf("expected balance 1000, got %v", updated.Balance)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+type StatementService struct {
+	transactionRepo ports.TransactionRepository
+}
+
+func NewStatementService(transactionRepo ports.TransactionRepository) *StatementService {
+	return &StatementService{transactionRepo: transactionRepo}
+}
+
+// Generate menyusun rekening koran user untuk periode [from, to). Saldo awal
+// diambil dari BalanceAfter transaksi terakhir sebelum from.
+func (s *StatementService) Generate(ctx context.Context, userID uuid.UUID, from, to time.Time) (_ *domain.Statement, err error) {
+	ctx, span := startSpan(ctx, "StatementService.Generate")
+	defer func() { endSpan(span, err) }()
+
+	if !from.Before(to) {
+		return nil, errors.New("invalid statement period")
+	}
+	txs, err := s.transactionRepo.FindByUserBetween(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &domain.Statement{UserID: userID, From: from, To: to, Lines: []domain.StatementLine{}}
+	last, err := s.transactionRepo.FindLastBefore(ctx, userID, from)
+	switch {
+	case err == nil:
+		statement.OpeningBalance = last.BalanceAfter
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if len(txs) > 0 {
+			statement.OpeningBalance = txs[0].BalanceBefore
+		}
+	default:
+		return nil, err
+	}
+
+	statement.ClosingBalance = statement.OpeningBalance
+	for _, tx := range txs {
+		if tx.TransactionType == domain.TransactionTypeCredit {
+			statement.TotalCredits += tx.Amount
+		} else {
+			statement.TotalDebits += tx.Amount
+		}
+		statement.Lines = append(statement.Lines, domain.StatementLine{
+			TransactionID:   tx.TransactionID,
+			Date:            tx.CreatedAt,
+			TransactionType: tx.TransactionType,
+			Category:        tx.Category,
+			Remarks:         tx.Remarks,
+			Amount:          tx.Amount,
+			RunningBalance:  tx.BalanceAfter,
+		})
+		statement.ClosingBalance = tx.BalanceAfter
+	}
+	return statement, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestStatementServiceGenerate(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewStatementService(&testTransactionRepo{db: db})
+	userID := uuid.New()
+	entries := []domain.Transaction{
+		{TransactionType: domain.TransactionTypeCredit, Amount: 100, BalanceBefore: 0, BalanceAfter: 100, CreatedAt: time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{TransactionType: domain.TransactionTypeCredit, Amount: 50, BalanceBefore: 100, BalanceAfter: 150, CreatedAt: time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{TransactionType: domain.TransactionTypeDebit, Amount: 30, BalanceBefore: 150, BalanceAfter: 120, CreatedAt: time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)},
+		{TransactionType: domain.TransactionTypeDebit, Amount: 20, BalanceBefore: 120, BalanceAfter: 100, CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	for i := range entries {
+		entries[i].TransactionID = uuid.New()
+		entries[i].UserID = userID
+		db.Create(&entries[i])
+	}
+
+	st, err := service.Generate(context.Background(), userID, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if st.OpeningBalance != 100 || st.ClosingBalance != 120 {
+		t.Fatalf("expected opening 100 and closing 120, got %v and %v", st.OpeningBalance, st.ClosingBalance)
+	}
+	if st.TotalCredits != 50 || st.TotalDebits != 30 {
+		t.Fatalf("expected credits 50 and debits 30, got %v and %v", st.TotalCredits, st.TotalDebits)
+	}
+	if len(st.Lines) != 2 || st.Lines[0].RunningBalance != 150 || st.Lines[1].RunningBalance != 120 {
+		t.Fatalf("unexpected statement lines: %+v", st.Lines)
+	}
+}
+
+func TestStatementServiceGenerateEmptyPeriod(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewStatementService(&testTransactionRepo{db: db})
+
+	st, err := service.Generate(context.Background(), uuid.New(), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if st.OpeningBalance != 0 || st.ClosingBalance != 0 || len(st.Lines) != 0 {
+		t.Fatalf("expected empty statement, got %+v", st)
+	}
+	if _, err := service.Generate(context.Background(), uuid.New(), st.To, st.From); err == nil {
+		t.Fatalf("expected error for invalid period")
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer memakai TracerProvider global; tanpa provider terpasang span tidak
+// dicatat sama sekali.
+var tracer = otel.Tracer("hexagonal-go/internal/core/services")
+
+// startSpan membuka span untuk satu operasi service.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan menandai span gagal jika err tidak nil lalu menutupnya.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
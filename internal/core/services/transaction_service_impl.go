@@ -1,126 +1,825 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"hexagonal-go/internal/core/domain"
 	"hexagonal-go/internal/core/ports"
 )
 
+// ErrInsufficientBalance dikembalikan ketika saldo tidak cukup untuk nominal
+// beserta biayanya.
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// ErrInvalidAmount dikembalikan ketika nominal deposit, penarikan, atau
+// transfer tidak lebih besar dari nol.
+var ErrInvalidAmount = errors.New("amount must be greater than zero")
+
 type TransactionService struct {
 	transactionRepo ports.TransactionRepository
 	db              *gorm.DB
+	fees            *FeeService
+	limits          *LimitService
+	outbox          ports.OutboxRepository
+	metrics         ports.Metrics
+	logger          *slog.Logger
+	audit           *AuditService
+	fraud           *FraudService
+	screening       *ScreeningService
+	kyc             *KYCService
 }
 
-func NewTransactionService(transactionRepo ports.TransactionRepository, db *gorm.DB) *TransactionService {
-	return &TransactionService{transactionRepo: transactionRepo, db: db}
+// TransactionServiceOption mengatur dependensi opsional TransactionService.
+type TransactionServiceOption func(*TransactionService)
+
+// WithFeeService mengaktifkan pembebanan biaya pada Withdraw dan Transfer.
+func WithFeeService(fees *FeeService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.fees = fees
+	}
 }
 
-func (s *TransactionService) Deposit(userID uuid.UUID, amount float64, remarks string) (*domain.Transaction, error) {
-	var user domain.User
-	if err := s.db.First(&user, "user_id = ?", userID).Error; err != nil {
+// WithLimitService mengaktifkan pemeriksaan limit pada Withdraw dan Transfer.
+func WithLimitService(limits *LimitService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.limits = limits
+	}
+}
+
+// WithTransactionOutbox menulis domain event setiap pergerakan dana ke outbox
+// dalam transaksi database yang sama.
+func WithTransactionOutbox(outbox ports.OutboxRepository) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.outbox = outbox
+	}
+}
+
+// WithTransactionMetrics melaporkan jumlah dan nominal setiap pergerakan dana
+// beserta hasilnya ke m.
+func WithTransactionMetrics(m ports.Metrics) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.metrics = m
+	}
+}
+
+// WithTransactionAudit mencatat setiap pergerakan dana ke audit log dalam
+// transaksi database yang sama.
+func WithTransactionAudit(audit *AuditService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.audit = audit
+	}
+}
+
+// WithFraudService menilai Withdraw dan Transfer dengan mesin fraud sebelum
+// dana dipindahkan.
+func WithFraudService(fraud *FraudService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.fraud = fraud
+	}
+}
+
+// WithTransactionScreening menyaring penerima setiap transfer terhadap daftar
+// pantauan sebelum dana dipindahkan.
+func WithTransactionScreening(screening *ScreeningService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.screening = screening
+	}
+}
+
+// WithKYCService membatasi penarikan, transfer keluar, dan saldo sesuai
+// kebijakan level KYC user.
+func WithKYCService(kyc *KYCService) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.kyc = kyc
+	}
+}
+
+// WithTransactionLogger mengganti logger untuk log pergerakan dana (default
+// slog.Default()).
+func WithTransactionLogger(logger *slog.Logger) TransactionServiceOption {
+	return func(s *TransactionService) {
+		s.logger = logger
+	}
+}
+
+func NewTransactionService(transactionRepo ports.TransactionRepository, db *gorm.DB, opts ...TransactionServiceOption) *TransactionService {
+	s := &TransactionService{transactionRepo: transactionRepo, db: db, metrics: ports.NoopMetrics{}, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *TransactionService) Deposit(ctx context.Context, userID uuid.UUID, amount float64, remarks string) (_ *domain.Transaction, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.Deposit",
+		attribute.String("user.id", userID.String()), attribute.Float64("amount", amount))
+	defer func() { endSpan(span, err) }()
+
+	if err := validateAmount(amount); err != nil {
 		return nil, err
 	}
-	balanceBefore := user.Balance
-	user.Balance += amount
-	if err := s.db.Save(&user).Error; err != nil {
+
+	var depositTx domain.Transaction
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := lockUser(tx, &user, userID); err != nil {
+			return err
+		}
+		if user.IsBlocked {
+			return ErrAccountBlocked
+		}
+		if user.IsFrozen {
+			return ErrAccountFrozen
+		}
+		if err := s.checkBalanceCap(&user, user.Balance+amount); err != nil {
+			return err
+		}
+		balanceBefore := user.Balance
+		user.Balance += amount
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+		depositTx = domain.Transaction{
+			UserID:          userID,
+			TransactionType: domain.TransactionTypeCredit,
+			Category:        domain.CategoryDeposit,
+			Amount:          amount,
+			Remarks:         remarks,
+			BalanceBefore:   balanceBefore,
+			BalanceAfter:    user.Balance,
+		}
+		if err := s.transactionRepo.CreateWithTx(ctx, tx, &depositTx); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventFundsDeposited, domain.FundsMovedPayload{UserID: userID, Transaction: depositTx, Balance: user.Balance}); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditDeposit,
+			TargetID: &userID,
+			Changes:  balanceChange(depositTx),
+			Metadata: map[string]interface{}{"transaction_id": depositTx.TransactionID, "amount": amount},
+		})
+	})
+	s.metrics.RecordTransaction(domain.CategoryDeposit, transactionOutcome(err), amount)
+	logFundsMovement(ctx, s.logger, domain.CategoryDeposit, err, userID, amount, &depositTx)
+	if err != nil {
 		return nil, err
 	}
-	tx := domain.Transaction{
-		UserID:          userID,
-		TransactionType: "CREDIT",
-		Amount:          amount,
-		Remarks:         remarks,
-		BalanceBefore:   balanceBefore,
-		BalanceAfter:    user.Balance,
+	return &depositTx, nil
+}
+
+func (s *TransactionService) Withdraw(ctx context.Context, userID uuid.UUID, amount float64, remarks string) (_ *domain.Transaction, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.Withdraw",
+		attribute.String("user.id", userID.String()), attribute.Float64("amount", amount))
+	defer func() { endSpan(span, err) }()
+
+	if err := validateAmount(amount); err != nil {
+		return nil, err
 	}
-	if err := s.transactionRepo.Create(&tx); err != nil {
+
+	var moved fundsMovement
+	if s.screening != nil {
+		err = s.screening.ScreenSender(ctx, userID)
+	}
+	if err == nil {
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+			moved, err = s.withdrawWithTx(ctx, tx, userID, amount, remarks, true)
+			return err
+		})
+	}
+	if err == nil && moved.held != nil {
+		err = &FraudDecisionError{Review: moved.held}
+	}
+	s.metrics.RecordTransaction(domain.CategoryWithdraw, transactionOutcome(err), amount)
+	logFundsMovement(ctx, s.logger, domain.CategoryWithdraw, err, userID, amount, &moved.debit, slog.Float64("fee", moved.fee))
+	if err != nil {
 		return nil, err
 	}
-	return &tx, nil
+	return &moved.debit, nil
 }
 
-func (s *TransactionService) Withdraw(userID uuid.UUID, amount float64, remarks string) (*domain.Transaction, error) {
+func (s *TransactionService) Transfer(ctx context.Context, fromID, toID uuid.UUID, amount float64, remarks string) (_, _ *domain.Transaction, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.Transfer",
+		attribute.String("user.id", fromID.String()), attribute.String("counterparty.id", toID.String()), attribute.Float64("amount", amount))
+	defer func() { endSpan(span, err) }()
+
+	if err := validateAmount(amount); err != nil {
+		return nil, nil, err
+	}
+
+	var moved fundsMovement
+	if s.screening != nil {
+		err = s.screening.ScreenSender(ctx, fromID)
+		if err == nil {
+			err = s.screening.ScreenCounterparty(ctx, toID)
+		}
+	}
+	if err == nil {
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+			moved, err = s.transferWithTx(ctx, tx, fromID, toID, amount, remarks, true)
+			return err
+		})
+	}
+	if err == nil && moved.held != nil {
+		err = &FraudDecisionError{Review: moved.held}
+	}
+	s.metrics.RecordTransaction(domain.CategoryTransfer, transactionOutcome(err), amount)
+	logFundsMovement(ctx, s.logger, domain.CategoryTransfer, err, fromID, amount, &moved.debit,
+		slog.String("counterparty_id", toID.String()), slog.Float64("fee", moved.fee))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &moved.debit, &moved.credit, nil
+}
+
+// fundsMovement adalah hasil withdrawWithTx dan transferWithTx. held tidak
+// nil berarti mesin fraud menahan atau memblokir transaksi dan dana tidak
+// dipindahkan.
+type fundsMovement struct {
+	debit  domain.Transaction
+	credit domain.Transaction
+	fee    float64
+	held   *domain.FraudReview
+}
+
+// withdrawWithTx memindahkan dana penarikan di dalam tx. screen=false
+// melewati mesin fraud, dipakai saat analis menyetujui kasus yang ditahan.
+func (s *TransactionService) withdrawWithTx(ctx context.Context, tx *gorm.DB, userID uuid.UUID, amount float64, remarks string, screen bool) (fundsMovement, error) {
+	var moved fundsMovement
 	var user domain.User
-	if err := s.db.First(&user, "user_id = ?", userID).Error; err != nil {
-		return nil, err
+	// baris user dikunci sebelum pemakaian limit dan saldo dihitung agar
+	// penarikan paralel menunggu dan melihat hasil penarikan sebelumnya
+	if err := lockUser(tx, &user, userID); err != nil {
+		return moved, err
+	}
+	if user.IsBlocked {
+		return moved, ErrAccountBlocked
 	}
-	if user.Balance < amount {
-		return nil, errors.New("insufficient balance")
+	if user.IsFrozen {
+		return moved, ErrAccountFrozen
+	}
+	if err := s.checkKYC(&user, domain.CategoryWithdraw); err != nil {
+		return moved, err
+	}
+	if err := s.checkLimits(ctx, tx, &user, domain.CategoryWithdraw, amount); err != nil {
+		return moved, err
+	}
+	quote := s.quoteFee(domain.CategoryWithdraw, amount, user.AccountTier)
+	moved.fee = quote.Fee
+	if user.Balance < quote.Total {
+		return moved, ErrInsufficientBalance
+	}
+	if screen {
+		held, err := s.screen(ctx, tx, domain.FraudCheck{Operation: domain.CategoryWithdraw, UserID: userID, Amount: amount}, remarks)
+		if err != nil || held != nil {
+			moved.held = held
+			return moved, err
+		}
 	}
 	balanceBefore := user.Balance
 	user.Balance -= amount
-	if err := s.db.Save(&user).Error; err != nil {
-		return nil, err
-	}
-	tx := domain.Transaction{
+	moved.debit = domain.Transaction{
 		UserID:          userID,
-		TransactionType: "DEBIT",
+		TransactionType: domain.TransactionTypeDebit,
+		Category:        domain.CategoryWithdraw,
 		Amount:          amount,
 		Remarks:         remarks,
 		BalanceBefore:   balanceBefore,
 		BalanceAfter:    user.Balance,
 	}
-	if err := s.transactionRepo.Create(&tx); err != nil {
-		return nil, err
+	if err := s.transactionRepo.CreateWithTx(ctx, tx, &moved.debit); err != nil {
+		return moved, err
+	}
+	feeTx, err := s.chargeFee(ctx, tx, &user, quote.Fee, remarks)
+	if err != nil {
+		return moved, err
+	}
+	if err := tx.Save(&user).Error; err != nil {
+		return moved, err
+	}
+	if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventFundsWithdrawn, domain.FundsMovedPayload{UserID: userID, Transaction: moved.debit, Fee: feeTx, Balance: user.Balance}); err != nil {
+		return moved, err
+	}
+	return moved, recordAudit(ctx, s.audit, tx, AuditRecord{
+		Action:   domain.AuditWithdraw,
+		TargetID: &userID,
+		Changes:  map[string]domain.AuditChange{"balance": {Before: moved.debit.BalanceBefore, After: user.Balance}},
+		Metadata: map[string]interface{}{"transaction_id": moved.debit.TransactionID, "amount": amount, "fee": quote.Fee},
+	})
+}
+
+// transferWithTx memindahkan dana transfer di dalam tx. screen=false
+// melewati mesin fraud, dipakai saat analis menyetujui kasus yang ditahan.
+func (s *TransactionService) transferWithTx(ctx context.Context, tx *gorm.DB, fromID, toID uuid.UUID, amount float64, remarks string, screen bool) (fundsMovement, error) {
+	var moved fundsMovement
+	var fromUser, toUser domain.User
+	// kedua baris dikunci dengan urutan user_id yang sama untuk setiap
+	// transfer agar transfer berlawanan arah tidak saling menunggu (deadlock)
+	first, second := &fromUser, &toUser
+	firstID, secondID := fromID, toID
+	if toID.String() < fromID.String() {
+		first, second = second, first
+		firstID, secondID = secondID, firstID
+	}
+	if err := lockUser(tx, first, firstID); err != nil {
+		return moved, err
+	}
+	if err := lockUser(tx, second, secondID); err != nil {
+		return moved, err
+	}
+	if fromUser.IsBlocked || toUser.IsBlocked {
+		return moved, ErrAccountBlocked
+	}
+	if fromUser.IsFrozen || toUser.IsFrozen {
+		return moved, ErrAccountFrozen
+	}
+	if err := s.checkKYC(&fromUser, domain.CategoryTransfer); err != nil {
+		return moved, err
+	}
+	if err := s.checkBalanceCap(&toUser, toUser.Balance+amount); err != nil {
+		return moved, err
+	}
+	if err := s.checkLimits(ctx, tx, &fromUser, domain.CategoryTransfer, amount); err != nil {
+		return moved, err
+	}
+	quote := s.quoteFee(domain.CategoryTransfer, amount, fromUser.AccountTier)
+	moved.fee = quote.Fee
+	if fromUser.Balance < quote.Total {
+		return moved, ErrInsufficientBalance
+	}
+	if screen {
+		held, err := s.screen(ctx, tx, domain.FraudCheck{Operation: domain.CategoryTransfer, UserID: fromID, CounterpartyID: &toID, Amount: amount}, remarks)
+		if err != nil || held != nil {
+			moved.held = held
+			return moved, err
+		}
+	}
+	fromBalanceBefore := fromUser.Balance
+	toBalanceBefore := toUser.Balance
+	fromUser.Balance -= amount
+	toUser.Balance += amount
+	moved.debit = domain.Transaction{
+		UserID:          fromID,
+		TransactionType: domain.TransactionTypeDebit,
+		Category:        domain.CategoryTransfer,
+		Amount:          amount,
+		Remarks:         remarks,
+		BalanceBefore:   fromBalanceBefore,
+		BalanceAfter:    fromUser.Balance,
+		CounterpartyID:  &toID,
+	}
+	moved.credit = domain.Transaction{
+		UserID:          toID,
+		TransactionType: domain.TransactionTypeCredit,
+		Category:        domain.CategoryTransfer,
+		Amount:          amount,
+		Remarks:         remarks,
+		BalanceBefore:   toBalanceBefore,
+		BalanceAfter:    toUser.Balance,
+		CounterpartyID:  &fromID,
+	}
+	if err := s.transactionRepo.CreateWithTx(ctx, tx, &moved.debit); err != nil {
+		return moved, err
+	}
+	if err := s.transactionRepo.CreateWithTx(ctx, tx, &moved.credit); err != nil {
+		return moved, err
+	}
+	feeTx, err := s.chargeFee(ctx, tx, &fromUser, quote.Fee, remarks, &toUser)
+	if err != nil {
+		return moved, err
+	}
+	if err := tx.Save(&fromUser).Error; err != nil {
+		return moved, err
+	}
+	if err := tx.Save(&toUser).Error; err != nil {
+		return moved, err
 	}
-	return &tx, nil
+	if err := recordEvent(ctx, s.outbox, tx, fromID, domain.EventTransferCompleted, domain.TransferCompletedPayload{
+		FromUserID:  fromID,
+		ToUserID:    toID,
+		Amount:      amount,
+		Debit:       moved.debit,
+		Credit:      moved.credit,
+		Fee:         feeTx,
+		FromBalance: fromUser.Balance,
+		ToBalance:   toUser.Balance,
+	}); err != nil {
+		return moved, err
+	}
+	return moved, recordAudit(ctx, s.audit, tx, AuditRecord{
+		Action:   domain.AuditTransfer,
+		TargetID: &fromID,
+		Changes: map[string]domain.AuditChange{
+			"balance":              {Before: fromBalanceBefore, After: fromUser.Balance},
+			"counterparty_balance": {Before: toBalanceBefore, After: toUser.Balance},
+		},
+		Metadata: map[string]interface{}{
+			"counterparty_id":       toID,
+			"debit_transaction_id":  moved.debit.TransactionID,
+			"credit_transaction_id": moved.credit.TransactionID,
+			"amount":                amount,
+			"fee":                   quote.Fee,
+		},
+	})
 }
 
-func (s *TransactionService) Transfer(fromID, toID uuid.UUID, amount float64, remarks string) (*domain.Transaction, *domain.Transaction, error) {
-	var debitTx, creditTx domain.Transaction
-	err := s.db.Transaction(func(tx *gorm.DB) error {
-		var fromUser, toUser domain.User
-		if err := tx.First(&fromUser, "user_id = ?", fromID).Error; err != nil {
+// Adjust membukukan koreksi saldo manual. amount positif menjadi CREDIT dan
+// negatif menjadi DEBIT; reason wajib diisi dan disimpan sebagai remarks.
+// Koreksi tidak dikenai biaya maupun limit, tetapi saldo tidak boleh negatif.
+func (s *TransactionService) Adjust(ctx context.Context, userID uuid.UUID, amount float64, reason string) (_ *domain.Transaction, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.Adjust",
+		attribute.String("user.id", userID.String()), attribute.Float64("amount", amount))
+	defer func() { endSpan(span, err) }()
+
+	if amount == 0 {
+		return nil, errors.New("adjustment amount must not be zero")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("adjustment reason required")
+	}
+	var adjustTx domain.Transaction
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := lockUser(tx, &user, userID); err != nil {
 			return err
 		}
-		if err := tx.First(&toUser, "user_id = ?", toID).Error; err != nil {
+		if user.Balance+amount < 0 {
+			return ErrInsufficientBalance
+		}
+		balanceBefore := user.Balance
+		user.Balance += amount
+		if err := tx.Save(&user).Error; err != nil {
 			return err
 		}
-		if fromUser.Balance < amount {
-			return errors.New("insufficient balance")
+		adjustTx = domain.Transaction{
+			UserID:          userID,
+			TransactionType: domain.TransactionTypeCredit,
+			Category:        domain.CategoryAdjustment,
+			Amount:          math.Abs(amount),
+			Remarks:         reason,
+			BalanceBefore:   balanceBefore,
+			BalanceAfter:    user.Balance,
 		}
-		fromBalanceBefore := fromUser.Balance
-		toBalanceBefore := toUser.Balance
-		fromUser.Balance -= amount
-		toUser.Balance += amount
-		if err := tx.Save(&fromUser).Error; err != nil {
+		if amount < 0 {
+			adjustTx.TransactionType = domain.TransactionTypeDebit
+		}
+		if err := s.transactionRepo.CreateWithTx(ctx, tx, &adjustTx); err != nil {
 			return err
 		}
-		if err := tx.Save(&toUser).Error; err != nil {
+		if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventFundsAdjusted, domain.FundsMovedPayload{UserID: userID, Transaction: adjustTx, Balance: user.Balance}); err != nil {
 			return err
 		}
-		debitTx = domain.Transaction{
-			UserID:          fromID,
-			TransactionType: "DEBIT",
-			Amount:          amount,
-			Remarks:         remarks,
-			BalanceBefore:   fromBalanceBefore,
-			BalanceAfter:    fromUser.Balance,
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditAdjustment,
+			TargetID: &userID,
+			Changes:  balanceChange(adjustTx),
+			Metadata: map[string]interface{}{"transaction_id": adjustTx.TransactionID, "amount": amount, "reason": reason},
+		})
+	})
+	s.metrics.RecordTransaction(domain.CategoryAdjustment, transactionOutcome(err), math.Abs(amount))
+	logFundsMovement(ctx, s.logger, domain.CategoryAdjustment, err, userID, amount, &adjustTx, slog.String("reason", reason))
+	if err != nil {
+		return nil, err
+	}
+	return &adjustTx, nil
+}
+
+// screen menilai transaksi dengan mesin fraud. Untuk keputusan REVIEW atau
+// BLOCK, kasus dan entri auditnya disimpan di dalam tx lalu dikembalikan;
+// pemanggil tidak boleh memindahkan dana dan tetap meng-commit tx agar kasus
+// tersimpan.
+func (s *TransactionService) screen(ctx context.Context, tx *gorm.DB, check domain.FraudCheck, remarks string) (*domain.FraudReview, error) {
+	if s.fraud == nil {
+		return nil, nil
+	}
+	assessment, err := s.fraud.Assess(ctx, tx, check)
+	if err != nil || assessment.Decision == domain.FraudDecisionAllow {
+		return nil, err
+	}
+	hits, err := json.Marshal(assessment.Hits)
+	if err != nil {
+		return nil, err
+	}
+	review := &domain.FraudReview{
+		ReviewID:       uuid.New(),
+		Operation:      check.Operation,
+		UserID:         check.UserID,
+		CounterpartyID: check.CounterpartyID,
+		Amount:         check.Amount,
+		Remarks:        remarks,
+		Score:          assessment.Score,
+		Decision:       assessment.Decision,
+		Hits:           string(hits),
+		Status:         domain.FraudReviewPending,
+	}
+	action := domain.AuditTransactionHeld
+	if assessment.Decision == domain.FraudDecisionBlock {
+		review.Status = domain.FraudReviewBlocked
+		action = domain.AuditTransactionBlocked
+	}
+	if err := s.fraud.fraudRepo.CreateReviewWithTx(ctx, tx, review); err != nil {
+		return nil, err
+	}
+	return review, recordAudit(ctx, s.audit, tx, AuditRecord{
+		Action:   action,
+		TargetID: &check.UserID,
+		Metadata: map[string]interface{}{"review_id": review.ReviewID, "operation": check.Operation, "amount": check.Amount, "score": assessment.Score},
+	})
+}
+
+// ApproveReview memindahkan dana kasus PENDING atas persetujuan analis.
+// Limit dan saldo diperiksa ulang; jika gagal, kasus tetap PENDING. Analis
+// diambil dari AuditActor pada ctx.
+func (s *TransactionService) ApproveReview(ctx context.Context, reviewID uuid.UUID, note string) (_ *domain.FraudReview, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.ApproveReview", attribute.String("review.id", reviewID.String()))
+	defer func() { endSpan(span, err) }()
+
+	var review *domain.FraudReview
+	var moved fundsMovement
+	err = s.decideReview(ctx, reviewID, note, func(tx *gorm.DB, r *domain.FraudReview) (err error) {
+		review = r
+		switch r.Operation {
+		case domain.CategoryWithdraw:
+			moved, err = s.withdrawWithTx(ctx, tx, r.UserID, r.Amount, r.Remarks, false)
+		case domain.CategoryTransfer:
+			moved, err = s.transferWithTx(ctx, tx, r.UserID, *r.CounterpartyID, r.Amount, r.Remarks, false)
+		default:
+			return errors.New("unsupported operation")
 		}
-		creditTx = domain.Transaction{
-			UserID:          toID,
-			TransactionType: "CREDIT",
-			Amount:          amount,
-			Remarks:         remarks,
-			BalanceBefore:   toBalanceBefore,
-			BalanceAfter:    toUser.Balance,
+		if err != nil {
+			return err
 		}
-		if err := s.transactionRepo.CreateWithTx(tx, &debitTx); err != nil {
+		r.Status = domain.FraudReviewApproved
+		r.TransactionID = &moved.debit.TransactionID
+		return nil
+	})
+	if review != nil {
+		s.metrics.RecordTransaction(review.Operation, transactionOutcome(err), review.Amount)
+		logFundsMovement(ctx, s.logger, review.Operation, err, review.UserID, review.Amount, &moved.debit,
+			slog.String("review_id", reviewID.String()), slog.Float64("fee", moved.fee))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// RejectReview menolak kasus PENDING tanpa memindahkan dana.
+func (s *TransactionService) RejectReview(ctx context.Context, reviewID uuid.UUID, note string) (_ *domain.FraudReview, err error) {
+	ctx, span := startSpan(ctx, "TransactionService.RejectReview", attribute.String("review.id", reviewID.String()))
+	defer func() { endSpan(span, err) }()
+
+	var review *domain.FraudReview
+	err = s.decideReview(ctx, reviewID, note, func(_ *gorm.DB, r *domain.FraudReview) error {
+		review = r
+		r.Status = domain.FraudReviewRejected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// decideReview mengunci kasus PENDING, menjalankan decide yang mengisi
+// status barunya, lalu menyimpan keputusan analis beserta entri audit dalam
+// satu transaksi database.
+func (s *TransactionService) decideReview(ctx context.Context, reviewID uuid.UUID, note string, decide func(*gorm.DB, *domain.FraudReview) error) error {
+	if s.fraud == nil {
+		return errors.New("fraud detection disabled")
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review, err := s.fraud.fraudRepo.FindReviewForUpdateWithTx(ctx, tx, reviewID)
+		if err != nil {
 			return err
 		}
-		if err := s.transactionRepo.CreateWithTx(tx, &creditTx); err != nil {
+		if review.Status != domain.FraudReviewPending {
+			return ErrReviewNotPending
+		}
+		if err := decide(tx, review); err != nil {
 			return err
 		}
-		return nil
+		now := time.Now()
+		review.ReviewedBy = AuditActorFrom(ctx).Name
+		review.ReviewNote = note
+		review.ReviewedAt = &now
+		if err := s.fraud.fraudRepo.UpdateReviewWithTx(ctx, tx, review); err != nil {
+			return err
+		}
+		action := domain.AuditReviewApproved
+		if review.Status == domain.FraudReviewRejected {
+			action = domain.AuditReviewRejected
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   action,
+			TargetID: &review.UserID,
+			Changes:  map[string]domain.AuditChange{"status": {Before: domain.FraudReviewPending, After: review.Status}},
+			Metadata: map[string]interface{}{"review_id": review.ReviewID, "transaction_id": review.TransactionID, "note": note},
+		})
 	})
+}
+
+func (s *TransactionService) GetTransactionsByUser(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
+	return s.transactionRepo.FindByUser(ctx, userID)
+}
+
+// ListTransactions mengembalikan paling banyak first transaksi user yang cocok
+// dengan filter, dari yang terbaru, setelah posisi after. hasNext bernilai true
+// jika masih ada transaksi berikutnya.
+func (s *TransactionService) ListTransactions(ctx context.Context, userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, first int) ([]domain.Transaction, bool, error) {
+	txs, err := s.transactionRepo.FindPage(ctx, userID, filter, after, first+1)
 	if err != nil {
-		return nil, nil, err
+		return nil, false, err
+	}
+	if len(txs) > first {
+		return txs[:first], true, nil
+	}
+	return txs, false, nil
+}
+
+// PreviewFee menghitung biaya sebuah operasi untuk pengguna tanpa memindahkan dana.
+func (s *TransactionService) PreviewFee(ctx context.Context, userID uuid.UUID, operation string, amount float64) (*domain.FeeQuote, error) {
+	if operation != domain.CategoryWithdraw && operation != domain.CategoryTransfer {
+		return nil, errors.New("unsupported operation")
+	}
+	var user domain.User
+	if err := s.db.WithContext(ctx).First(&user, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	quote := s.quoteFee(operation, amount, user.AccountTier)
+	return &quote, nil
+}
+
+// RemainingLimits menampilkan sisa limit pengguna untuk setiap operasi yang dibatasi.
+func (s *TransactionService) RemainingLimits(ctx context.Context, userID uuid.UUID) ([]domain.RemainingLimit, error) {
+	var user domain.User
+	if err := s.db.WithContext(ctx).First(&user, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	remaining := []domain.RemainingLimit{}
+	if s.limits == nil {
+		return remaining, nil
+	}
+	for _, operation := range []string{domain.CategoryWithdraw, domain.CategoryTransfer} {
+		policy, ok := s.limits.Policy(user.AccountTier, operation)
+		if !ok {
+			continue
+		}
+		daily, monthly, err := s.limitUsage(ctx, s.db.WithContext(ctx), userID, operation)
+		if err != nil {
+			return nil, err
+		}
+		remaining = append(remaining, s.limits.Remaining(policy, daily, monthly))
+	}
+	return remaining, nil
+}
+
+// checkKYC memastikan level KYC user mengizinkan operasi tersebut.
+func (s *TransactionService) checkKYC(user *domain.User, operation string) error {
+	if s.kyc == nil {
+		return nil
+	}
+	return s.kyc.Allow(user, operation)
+}
+
+// checkBalanceCap memastikan saldo baru user masih dalam batas saldo level
+// KYC-nya.
+func (s *TransactionService) checkBalanceCap(user *domain.User, balance float64) error {
+	if s.kyc == nil {
+		return nil
+	}
+	return s.kyc.CheckBalance(user, balance)
+}
+
+// checkLimits dijalankan di dalam transaksi database yang sama dengan
+// pemindahan dana sehingga pemakaian yang dihitung konsisten.
+func (s *TransactionService) checkLimits(ctx context.Context, tx *gorm.DB, user *domain.User, operation string, amount float64) error {
+	if s.limits == nil {
+		return nil
+	}
+	policy, ok := s.limits.Policy(user.AccountTier, operation)
+	if !ok {
+		return nil
+	}
+	daily, monthly, err := s.limitUsage(ctx, tx, user.UserID, operation)
+	if err != nil {
+		return err
+	}
+	return s.limits.Check(policy, amount, daily, monthly)
+}
+
+func (s *TransactionService) limitUsage(ctx context.Context, tx *gorm.DB, userID uuid.UUID, operation string) (domain.LimitUsage, domain.LimitUsage, error) {
+	daily, err := s.transactionRepo.UsageSinceWithTx(ctx, tx, userID, operation, s.limits.DayStart())
+	if err != nil {
+		return domain.LimitUsage{}, domain.LimitUsage{}, err
+	}
+	monthly, err := s.transactionRepo.UsageSinceWithTx(ctx, tx, userID, operation, s.limits.MonthStart())
+	if err != nil {
+		return domain.LimitUsage{}, domain.LimitUsage{}, err
 	}
-	return &debitTx, &creditTx, nil
+	return daily, monthly, nil
 }
 
-func (s *TransactionService) GetTransactionsByUser(userID uuid.UUID) ([]domain.Transaction, error) {
-	return s.transactionRepo.FindByUser(userID)
+func (s *TransactionService) quoteFee(operation string, amount float64, accountTier string) domain.FeeQuote {
+	if s.fees == nil {
+		return domain.FeeQuote{Operation: operation, Amount: amount, Total: amount}
+	}
+	return s.fees.Quote(operation, amount, accountTier)
+}
+
+// chargeFee membukukan biaya sebagai entri DEBIT terpisah pada user dan entri
+// CREDIT pada akun pendapatan biaya, di dalam transaksi database yang sama,
+// lalu mengembalikan entri DEBIT tersebut (nil jika tidak ada biaya).
+// Saldo user dikurangi di memori; pemanggil bertanggung jawab menyimpannya.
+// Jika akun pendapatan adalah user atau salah satu dari loaded, biaya
+// dikreditkan ke baris yang sudah dimuat itu agar tidak tertimpa saat
+// pemanggil menyimpannya; selain itu akun pendapatan dikunci, dibaca ulang,
+// dan disimpan di sini.
+func (s *TransactionService) chargeFee(ctx context.Context, tx *gorm.DB, user *domain.User, fee float64, remarks string, loaded ...*domain.User) (*domain.Transaction, error) {
+	if fee <= 0 {
+		return nil, nil
+	}
+	revenueID := s.fees.RevenueAccountID()
+	var revenue *domain.User
+	for _, candidate := range append([]*domain.User{user}, loaded...) {
+		if candidate.UserID == revenueID {
+			revenue = candidate
+			break
+		}
+	}
+	saveRevenue := revenue == nil
+	if saveRevenue {
+		revenue = &domain.User{}
+		if err := lockUser(tx, revenue, revenueID); err != nil {
+			return nil, err
+		}
+	}
+
+	balanceBefore := user.Balance
+	user.Balance -= fee
+	debitFee := domain.Transaction{
+		UserID:          user.UserID,
+		TransactionType: domain.TransactionTypeDebit,
+		Category:        domain.CategoryFee,
+		Amount:          fee,
+		Remarks:         "fee: " + remarks,
+		BalanceBefore:   balanceBefore,
+		BalanceAfter:    user.Balance,
+	}
+	if err := s.transactionRepo.CreateWithTx(ctx, tx, &debitFee); err != nil {
+		return nil, err
+	}
+
+	revenueBefore := revenue.Balance
+	revenue.Balance += fee
+	creditFee := domain.Transaction{
+		UserID:          revenue.UserID,
+		TransactionType: domain.TransactionTypeCredit,
+		Category:        domain.CategoryFee,
+		Amount:          fee,
+		Remarks:         "fee: " + remarks,
+		BalanceBefore:   revenueBefore,
+		BalanceAfter:    revenue.Balance,
+	}
+	if err := s.transactionRepo.CreateWithTx(ctx, tx, &creditFee); err != nil {
+		return nil, err
+	}
+	if saveRevenue {
+		if err := tx.Save(revenue).Error; err != nil {
+			return nil, err
+		}
+	}
+	return &debitFee, nil
+}
+
+// validateAmount menolak nominal nol, negatif, NaN, dan tak hingga. Nominal
+// negatif akan membalik arah perpindahan dana dan mengurangi pemakaian limit.
+func validateAmount(amount float64) error {
+	if !(amount > 0) || math.IsInf(amount, 1) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// lockUser membaca user di dalam tx dengan SELECT ... FOR UPDATE di Postgres
+// sehingga transaksi lain yang mengubah baris yang sama menunggu tx selesai.
+// Setiap alur yang membaca saldo lalu menyimpan baris user wajib memakainya;
+// tx.Save dari pembacaan tanpa kunci menimpa perubahan tx lain.
+func lockUser(tx *gorm.DB, user *domain.User, userID uuid.UUID) error {
+	query := tx
+	if tx.Dialector.Name() == "postgres" {
+		query = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return query.First(user, "user_id = ?", userID).Error
 }
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"math"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/uuid"
@@ -22,6 +26,10 @@ type userMigration struct {
 	Pin         string
 	Balance     float64
 	IsActive    bool
+	AccountTier string
+	IsBlocked   bool
+	IsFrozen    bool
+	KYCLevel    string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -32,10 +40,12 @@ type transactionMigration struct {
 	TransactionID   uuid.UUID `gorm:"primaryKey;type:uuid"`
 	UserID          uuid.UUID `gorm:"type:uuid;not null"`
 	TransactionType string
+	Category        string
 	Amount          float64
 	Remarks         string
 	BalanceBefore   float64
 	BalanceAfter    float64
+	CounterpartyID  *uuid.UUID `gorm:"type:uuid"`
 	CreatedAt       time.Time
 }
 
@@ -45,31 +55,98 @@ type mockTransactionRepository struct {
 	createFn       func(tx *domain.Transaction) error
 	createWithTxFn func(dbTx *gorm.DB, tx *domain.Transaction) error
 	findByUserFn   func(userID uuid.UUID) ([]domain.Transaction, error)
+	usageSinceFn   func(dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (domain.LimitUsage, error)
+	lastBeforeFn   func(userID uuid.UUID, before time.Time) (*domain.Transaction, error)
+	findBetweenFn  func(userID uuid.UUID, from, to time.Time) ([]domain.Transaction, error)
+	findByIDFn     func(id uuid.UUID) (*domain.Transaction, error)
+	findPageFn     func(userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error)
 }
 
 var _ ports.TransactionRepository = (*mockTransactionRepository)(nil)
 
-func (m *mockTransactionRepository) Create(tx *domain.Transaction) error {
+func (m *mockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
 	if m.createFn != nil {
 		return m.createFn(tx)
 	}
 	return nil
 }
 
-func (m *mockTransactionRepository) CreateWithTx(dbTx *gorm.DB, tx *domain.Transaction) error {
+func (m *mockTransactionRepository) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
 	if m.createWithTxFn != nil {
 		return m.createWithTxFn(dbTx, tx)
 	}
 	return nil
 }
 
-func (m *mockTransactionRepository) FindByUser(userID uuid.UUID) ([]domain.Transaction, error) {
+func (m *mockTransactionRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
 	if m.findByUserFn != nil {
 		return m.findByUserFn(userID)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockTransactionRepository) UsageSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (domain.LimitUsage, error) {
+	if m.usageSinceFn != nil {
+		return m.usageSinceFn(dbTx, userID, category, since)
+	}
+	return domain.LimitUsage{}, nil
+}
+
+func (m *mockTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockTransactionRepository) FindByUserBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.Transaction, error) {
+	if m.findBetweenFn != nil {
+		return m.findBetweenFn(userID, from, to)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockTransactionRepository) FindLastBefore(ctx context.Context, userID uuid.UUID, before time.Time) (*domain.Transaction, error) {
+	if m.lastBeforeFn != nil {
+		return m.lastBeforeFn(userID, before)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockTransactionRepository) FindPage(ctx context.Context, userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	if m.findPageFn != nil {
+		return m.findPageFn(userID, filter, after, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockTransactionRepository) FindAfter(ctx context.Context, userID uuid.UUID, after domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestTransactionService_ListTransactions(t *testing.T) {
+	userID := uuid.New()
+	var gotLimit int
+	repo := &mockTransactionRepository{findPageFn: func(id uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+		gotLimit = limit
+		return []domain.Transaction{{UserID: id}, {UserID: id}, {UserID: id}}, nil
+	}}
+	svc := NewTransactionService(repo, nil)
+
+	txs, hasNext, err := svc.ListTransactions(context.Background(), userID, domain.TransactionFilter{}, nil, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotLimit != 3 || len(txs) != 2 || !hasNext {
+		t.Fatalf("expected 2 transactions with next page (limit 3), got %d, %v (limit %d)", len(txs), hasNext, gotLimit)
+	}
+
+	_, hasNext, _ = svc.ListTransactions(context.Background(), userID, domain.TransactionFilter{}, nil, 3)
+	if hasNext {
+		t.Fatalf("expected no next page")
+	}
+}
+
 func TestTransactionService_GetTransactionsByUser(t *testing.T) {
 	userID := uuid.New()
 	expected := []domain.Transaction{{UserID: userID}}
@@ -82,7 +159,7 @@ func TestTransactionService_GetTransactionsByUser(t *testing.T) {
 		},
 	}
 	service := NewTransactionService(repo, nil)
-	txs, err := service.GetTransactionsByUser(userID)
+	txs, err := service.GetTransactionsByUser(context.Background(), userID)
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -99,7 +176,7 @@ func TestTransactionService_GetTransactionsByUserError(t *testing.T) {
 		},
 	}
 	service := NewTransactionService(repo, nil)
-	_, err := service.GetTransactionsByUser(userID)
+	_, err := service.GetTransactionsByUser(context.Background(), userID)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -109,20 +186,76 @@ type testTransactionRepo struct {
 	db *gorm.DB
 }
 
-func (r *testTransactionRepo) Create(tx *domain.Transaction) error {
+func (r *testTransactionRepo) Create(ctx context.Context, tx *domain.Transaction) error {
 	return r.db.Create(tx).Error
 }
 
-func (r *testTransactionRepo) CreateWithTx(dbTx *gorm.DB, tx *domain.Transaction) error {
+func (r *testTransactionRepo) CreateWithTx(ctx context.Context, dbTx *gorm.DB, tx *domain.Transaction) error {
 	return dbTx.Create(tx).Error
 }
 
-func (r *testTransactionRepo) FindByUser(userID uuid.UUID) ([]domain.Transaction, error) {
+func (r *testTransactionRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]domain.Transaction, error) {
 	var txs []domain.Transaction
 	err := r.db.Where("user_id = ?", userID).Find(&txs).Error
 	return txs, err
 }
 
+func (r *testTransactionRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	var tx domain.Transaction
+	err := r.db.Where("transaction_id = ?", id).First(&tx).Error
+	return &tx, err
+}
+
+func (r *testTransactionRepo) FindByUserBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.Transaction, error) {
+	var txs []domain.Transaction
+	err := r.db.Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).Order("created_at ASC, transaction_id ASC").Find(&txs).Error
+	return txs, err
+}
+
+func (r *testTransactionRepo) FindLastBefore(ctx context.Context, userID uuid.UUID, before time.Time) (*domain.Transaction, error) {
+	var tx domain.Transaction
+	err := r.db.Where("user_id = ? AND created_at < ?", userID, before).Order("created_at DESC").First(&tx).Error
+	return &tx, err
+}
+
+func (r *testTransactionRepo) FindPage(ctx context.Context, userID uuid.UUID, filter domain.TransactionFilter, after *domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if filter.TransactionType != "" {
+		query = query.Where("transaction_type = ?", filter.TransactionType)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if after != nil {
+		query = query.Where("created_at < ? OR (created_at = ? AND transaction_id < ?)", after.CreatedAt, after.CreatedAt, after.TransactionID)
+	}
+	var txs []domain.Transaction
+	err := query.Order("created_at DESC, transaction_id DESC").Limit(limit).Find(&txs).Error
+	return txs, err
+}
+
+func (r *testTransactionRepo) FindAfter(ctx context.Context, userID uuid.UUID, after domain.TransactionCursor, limit int) ([]domain.Transaction, error) {
+	var txs []domain.Transaction
+	err := r.db.Where("user_id = ? AND (created_at > ? OR (created_at = ? AND transaction_id > ?))", userID, after.CreatedAt, after.CreatedAt, after.TransactionID).
+		Order("created_at ASC, transaction_id ASC").Limit(limit).Find(&txs).Error
+	return txs, err
+}
+
+func (r *testTransactionRepo) UsageSinceWithTx(ctx context.Context, dbTx *gorm.DB, userID uuid.UUID, category string, since time.Time) (domain.LimitUsage, error) {
+	var usage domain.LimitUsage
+	err := dbTx.Model(&domain.Transaction{}).
+		Select("COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count").
+		Where("user_id = ? AND category = ? AND transaction_type = ? AND created_at >= ?", userID, category, domain.TransactionTypeDebit, since).
+		Scan(&usage).Error
+	return usage, err
+}
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
@@ -141,7 +274,7 @@ func TestTransactionService_Deposit_Success(t *testing.T) {
 	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
 	db.Create(&user)
 
-	tx, err := service.Deposit(user.UserID, 50, "deposit")
+	tx, err := service.Deposit(context.Background(), user.UserID, 50, "deposit")
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -169,7 +302,7 @@ func TestTransactionService_Withdraw_Success(t *testing.T) {
 	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
 	db.Create(&user)
 
-	tx, err := service.Withdraw(user.UserID, 40, "withdraw")
+	tx, err := service.Withdraw(context.Background(), user.UserID, 40, "withdraw")
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -197,7 +330,7 @@ func TestTransactionService_Withdraw_InsufficientFunds(t *testing.T) {
 	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 20}
 	db.Create(&user)
 
-	_, err := service.Withdraw(user.UserID, 40, "withdraw")
+	_, err := service.Withdraw(context.Background(), user.UserID, 40, "withdraw")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -224,7 +357,7 @@ func TestTransactionService_Transfer_Success(t *testing.T) {
 	db.Create(&fromUser)
 	db.Create(&toUser)
 
-	_, _, err := service.Transfer(fromUser.UserID, toUser.UserID, 30, "transfer")
+	_, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 30, "transfer")
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -255,7 +388,7 @@ func TestTransactionService_Transfer_InsufficientFunds(t *testing.T) {
 	db.Create(&fromUser)
 	db.Create(&toUser)
 
-	_, _, err := service.Transfer(fromUser.UserID, toUser.UserID, 30, "transfer")
+	_, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 30, "transfer")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -276,3 +409,254 @@ func TestTransactionService_Transfer_InsufficientFunds(t *testing.T) {
 		t.Fatalf("expected 0 transactions, got %d", count)
 	}
 }
+
+func TestTransactionService_RejectsNonPositiveAmount(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	service := NewTransactionService(repo, db)
+	attacker := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 0}
+	victim := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234", Balance: 1000}
+	db.Create(&attacker)
+	db.Create(&victim)
+
+	for _, amount := range []float64{-700, 0, math.NaN(), math.Inf(1)} {
+		if _, _, err := service.Transfer(context.Background(), attacker.UserID, victim.UserID, amount, "transfer"); !errors.Is(err, ErrInvalidAmount) {
+			t.Fatalf("transfer %v: expected ErrInvalidAmount, got %v", amount, err)
+		}
+		if _, err := service.Withdraw(context.Background(), attacker.UserID, amount, "withdraw"); !errors.Is(err, ErrInvalidAmount) {
+			t.Fatalf("withdraw %v: expected ErrInvalidAmount, got %v", amount, err)
+		}
+		if _, err := service.Deposit(context.Background(), victim.UserID, amount, "deposit"); !errors.Is(err, ErrInvalidAmount) {
+			t.Fatalf("deposit %v: expected ErrInvalidAmount, got %v", amount, err)
+		}
+	}
+
+	var updatedAttacker, updatedVictim domain.User
+	db.First(&updatedAttacker, "user_id = ?", attacker.UserID)
+	db.First(&updatedVictim, "user_id = ?", victim.UserID)
+	if updatedAttacker.Balance != 0 || updatedVictim.Balance != 1000 {
+		t.Fatalf("expected balances 0 and 1000, got %v and %v", updatedAttacker.Balance, updatedVictim.Balance)
+	}
+	var count int64
+	db.Model(&domain.Transaction{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no transactions, got %d", count)
+	}
+}
+
+func TestTransactionService_Withdraw_WithFee(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	revenue := domain.User{UserID: uuid.New(), FirstName: "Fee", LastName: "Revenue", PhoneNumber: "000", Address: "addr", Pin: "1234"}
+	db.Create(&revenue)
+	fees := NewFeeService([]domain.FeeRule{{Operation: domain.CategoryWithdraw, Type: domain.FeeTypeFlat, Flat: 5}}, revenue.UserID)
+	service := NewTransactionService(repo, db, WithFeeService(fees))
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	db.Create(&user)
+
+	if _, err := service.Withdraw(context.Background(), user.UserID, 40, "withdraw"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	var updated, updatedRevenue domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	db.First(&updatedRevenue, "user_id = ?", revenue.UserID)
+	if updated.Balance != 55 {
+		t.Fatalf("expected balance 55, got %v", updated.Balance)
+	}
+	if updatedRevenue.Balance != 5 {
+		t.Fatalf("expected revenue balance 5, got %v", updatedRevenue.Balance)
+	}
+
+	var feeCount int64
+	db.Model(&domain.Transaction{}).Where("category = ?", domain.CategoryFee).Count(&feeCount)
+	if feeCount != 2 {
+		t.Fatalf("expected 2 fee entries, got %d", feeCount)
+	}
+}
+
+func TestTransactionService_Transfer_FeeToRevenueAccount(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	revenue := domain.User{UserID: uuid.New(), FirstName: "Fee", LastName: "Revenue", PhoneNumber: "000", Address: "addr", Pin: "1234"}
+	db.Create(&revenue)
+	fees := NewFeeService([]domain.FeeRule{{Operation: domain.CategoryTransfer, Type: domain.FeeTypeFlat, Flat: 5}}, revenue.UserID)
+	service := NewTransactionService(repo, db, WithFeeService(fees))
+	fromUser := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	toUser := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234"}
+	db.Create(&fromUser)
+	db.Create(&toUser)
+
+	balance := func(userID uuid.UUID) float64 {
+		var user domain.User
+		db.First(&user, "user_id = ?", userID)
+		return user.Balance
+	}
+
+	// akun pendapatan sebagai penerima: biaya tidak boleh tertimpa saldo transfer
+	if _, _, err := service.Transfer(context.Background(), fromUser.UserID, revenue.UserID, 10, "to revenue"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got := balance(revenue.UserID); got != 15 {
+		t.Fatalf("expected revenue balance 15, got %v", got)
+	}
+	if got := balance(fromUser.UserID); got != 85 {
+		t.Fatalf("expected sender balance 85, got %v", got)
+	}
+
+	// akun pendapatan di luar transfer: dibaca ulang dan dikreditkan terpisah
+	if _, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 20, "to third party"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got := balance(revenue.UserID); got != 20 {
+		t.Fatalf("expected revenue balance 20, got %v", got)
+	}
+	if got := balance(toUser.UserID); got != 20 {
+		t.Fatalf("expected recipient balance 20, got %v", got)
+	}
+
+	// akun pendapatan sebagai pengirim: biaya kembali ke baris yang sama
+	if _, _, err := service.Transfer(context.Background(), revenue.UserID, toUser.UserID, 10, "from revenue"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if got := balance(revenue.UserID); got != 10 {
+		t.Fatalf("expected revenue balance 10, got %v", got)
+	}
+	if got := balance(toUser.UserID); got != 30 {
+		t.Fatalf("expected recipient balance 30, got %v", got)
+	}
+}
+
+func TestTransactionService_Transfer_FeeExceedsBalance(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	revenue := domain.User{UserID: uuid.New(), FirstName: "Fee", LastName: "Revenue", PhoneNumber: "000", Address: "addr", Pin: "1234"}
+	db.Create(&revenue)
+	fees := NewFeeService([]domain.FeeRule{{Operation: domain.CategoryTransfer, Type: domain.FeeTypeFlat, Flat: 5}}, revenue.UserID)
+	service := NewTransactionService(repo, db, WithFeeService(fees))
+	fromUser := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 30}
+	toUser := domain.User{UserID: uuid.New(), FirstName: "C", LastName: "D", PhoneNumber: "222", Address: "addr", Pin: "1234", Balance: 50}
+	db.Create(&fromUser)
+	db.Create(&toUser)
+
+	if _, _, err := service.Transfer(context.Background(), fromUser.UserID, toUser.UserID, 30, "transfer"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	var count int64
+	db.Model(&domain.Transaction{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected 0 transactions, got %d", count)
+	}
+}
+
+func TestTransactionService_Withdraw_DailyLimitExceeded(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	limits := NewLimitService([]domain.LimitPolicy{{AccountTier: domain.AccountTierRegular, Operation: domain.CategoryWithdraw, DailyAmount: 50}})
+	service := NewTransactionService(repo, db, WithLimitService(limits))
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100, AccountTier: domain.AccountTierRegular}
+	db.Create(&user)
+
+	if _, err := service.Withdraw(context.Background(), user.UserID, 30, "withdraw"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	_, err := service.Withdraw(context.Background(), user.UserID, 30, "withdraw")
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected limit exceeded error, got %v", err)
+	}
+
+	remaining, err := service.RemainingLimits(context.Background(), user.UserID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].DailyAmount == nil || *remaining[0].DailyAmount != 20 {
+		t.Fatalf("expected 20 remaining daily amount, got %+v", remaining)
+	}
+}
+
+func TestTransactionService_Withdraw_ConcurrentLimit(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql db: %v", err)
+	}
+	// database :memory: hanya hidup di satu koneksi
+	sqlDB.SetMaxOpenConns(1)
+	repo := &testTransactionRepo{db: db}
+	limits := NewLimitService([]domain.LimitPolicy{{AccountTier: domain.AccountTierRegular, Operation: domain.CategoryWithdraw, DailyAmount: 50}})
+	service := NewTransactionService(repo, db, WithLimitService(limits))
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100, AccountTier: domain.AccountTierRegular}
+	db.Create(&user)
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Withdraw(context.Background(), user.UserID, 20, "withdraw")
+			if err == nil {
+				succeeded.Add(1)
+			} else if !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("expected limit exceeded error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var updated domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	if succeeded.Load() != 2 || updated.Balance != 60 {
+		t.Fatalf("expected 2 withdrawals within the daily limit, got %d with balance %v", succeeded.Load(), updated.Balance)
+	}
+}
+
+func TestTransactionService_Adjust(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	service := NewTransactionService(repo, db)
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	db.Create(&user)
+
+	tx, err := service.Adjust(context.Background(), user.UserID, -30, "duplicate deposit")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if tx.TransactionType != domain.TransactionTypeDebit || tx.Category != domain.CategoryAdjustment || tx.Amount != 30 {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+	if tx.Remarks != "duplicate deposit" || tx.BalanceBefore != 100 || tx.BalanceAfter != 70 {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+
+	var updated domain.User
+	db.First(&updated, "user_id = ?", user.UserID)
+	if updated.Balance != 70 {
+		t.Fatalf("expected balance 70, got %v", updated.Balance)
+	}
+}
+
+func TestTransactionService_Adjust_Invalid(t *testing.T) {
+	db := setupTestDB(t)
+	repo := &testTransactionRepo{db: db}
+	service := NewTransactionService(repo, db)
+	user := domain.User{UserID: uuid.New(), FirstName: "A", LastName: "B", PhoneNumber: "111", Address: "addr", Pin: "1234", Balance: 100}
+	db.Create(&user)
+
+	if _, err := service.Adjust(context.Background(), user.UserID, -150, "chargeback"); !errors.Is(err, ErrInsufficientBalance) {
+		t.Fatalf("expected ErrInsufficientBalance, got %v", err)
+	}
+	if _, err := service.Adjust(context.Background(), user.UserID, 10, " "); err == nil {
+		t.Fatalf("expected error for missing reason")
+	}
+	if _, err := service.Adjust(context.Background(), user.UserID, 0, "noop"); err == nil {
+		t.Fatalf("expected error for zero amount")
+	}
+
+	var count int64
+	db.Model(&domain.Transaction{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no transactions, got %d", count)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+// streamBufferSize adalah kapasitas antrean pesan per koneksi. Jika klien
+// terlalu lambat dan antreannya penuh, koneksinya ditutup agar klien
+// melakukan resume dengan Last-Event-ID.
+const streamBufferSize = 64
+
+// streamReplayLimit membatasi jumlah pesan yang dikirim ulang dalam satu
+// resume.
+const streamReplayLimit = 500
+
+// TransactionStream mengubah domain event menjadi StreamMessage lalu
+// mengirimkannya ke koneksi lokal milik user terkait. Penyebaran antar replika
+// dilakukan oleh Broadcaster sebelum event sampai ke HandleEvent.
+type TransactionStream struct {
+	transactionRepo ports.TransactionRepository
+	hub             *userHub[domain.StreamMessage]
+}
+
+func NewTransactionStream(transactionRepo ports.TransactionRepository) *TransactionStream {
+	return &TransactionStream{
+		transactionRepo: transactionRepo,
+		hub:             newUserHub[domain.StreamMessage](streamBufferSize),
+	}
+}
+
+// HandleEvent dipasang sebagai subscriber event lokal replika.
+func (s *TransactionStream) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	messages, err := streamMessages(event)
+	if err != nil {
+		return err
+	}
+	for _, msg := range messages {
+		s.hub.dispatch(msg.UserID, msg)
+	}
+	return nil
+}
+
+// streamMessages mengubah event menjadi pesan per user. Biaya dikirim sebagai
+// pesan tersendiri setelah transaksinya, sama seperti urutan baris di Replay,
+// sehingga saldo pada pesan transaksi adalah saldo sebelum biaya.
+func streamMessages(event domain.OutboxEvent) ([]domain.StreamMessage, error) {
+	switch event.EventType {
+	case domain.EventFundsDeposited, domain.EventFundsWithdrawn, domain.EventFundsAdjusted:
+		var payload domain.FundsMovedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		return withFee(domain.StreamMessage{UserID: payload.UserID, Transaction: payload.Transaction, Balance: payload.Balance}, payload.Fee), nil
+	case domain.EventTransferCompleted:
+		var payload domain.TransferCompletedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, err
+		}
+		messages := withFee(domain.StreamMessage{UserID: payload.FromUserID, Transaction: payload.Debit, Balance: payload.FromBalance}, payload.Fee)
+		return append(messages, domain.StreamMessage{UserID: payload.ToUserID, Transaction: payload.Credit, Balance: payload.ToBalance}), nil
+	}
+	return nil, nil
+}
+
+// withFee menambahkan pesan biaya setelah msg jika fee tidak nil.
+func withFee(msg domain.StreamMessage, fee *domain.Transaction) []domain.StreamMessage {
+	if fee == nil {
+		return []domain.StreamMessage{msg}
+	}
+	feeMsg := domain.StreamMessage{UserID: msg.UserID, Transaction: *fee, Balance: msg.Balance}
+	msg.Balance = msg.Transaction.BalanceAfter
+	return []domain.StreamMessage{msg, feeMsg}
+}
+
+// Subscribe mendaftarkan koneksi baru untuk userID. Panggil fungsi yang
+// dikembalikan saat koneksi selesai.
+func (s *TransactionStream) Subscribe(userID uuid.UUID) (*Subscription[domain.StreamMessage], func()) {
+	return s.hub.subscribe(userID)
+}
+
+// Close menutup semua koneksi stream saat server berhenti.
+func (s *TransactionStream) Close() {
+	s.hub.closeAll()
+}
+
+// Replay mengembalikan paling banyak streamReplayLimit transaksi user setelah
+// transaksi lastEventID, dalam urutan (created_at, transaction_id), untuk
+// melanjutkan stream yang terputus. more bernilai true jika masih ada
+// transaksi setelah pesan terakhir; klien melanjutkan dengan Last-Event-ID
+// pesan tersebut.
+func (s *TransactionStream) Replay(ctx context.Context, userID, lastEventID uuid.UUID) (_ []domain.StreamMessage, more bool, err error) {
+	last, err := s.transactionRepo.FindByID(ctx, lastEventID)
+	if err != nil {
+		return nil, false, err
+	}
+	if last.UserID != userID {
+		return nil, false, errors.New("invalid last event id")
+	}
+	txs, err := s.transactionRepo.FindAfter(ctx, userID, domain.TransactionCursor{CreatedAt: last.CreatedAt, TransactionID: last.TransactionID}, streamReplayLimit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(txs) > streamReplayLimit {
+		txs, more = txs[:streamReplayLimit], true
+	}
+
+	messages := make([]domain.StreamMessage, 0, len(txs))
+	for _, tx := range txs {
+		messages = append(messages, domain.StreamMessage{UserID: userID, Transaction: tx, Balance: tx.BalanceAfter})
+	}
+	return messages, more, nil
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/core/domain"
+)
+
+func TestTransactionStreamDeliversTransferToBothParties(t *testing.T) {
+	stream := NewTransactionStream(&mockTransactionRepository{})
+
+	from, to, other := uuid.New(), uuid.New(), uuid.New()
+	fromSub, cancelFrom := stream.Subscribe(from)
+	defer cancelFrom()
+	toSub, cancelTo := stream.Subscribe(to)
+	defer cancelTo()
+	otherSub, cancelOther := stream.Subscribe(other)
+	defer cancelOther()
+
+	payload, _ := json.Marshal(domain.TransferCompletedPayload{
+		FromUserID:  from,
+		ToUserID:    to,
+		Amount:      30,
+		Debit:       domain.Transaction{TransactionID: uuid.New(), UserID: from, Amount: 30},
+		Credit:      domain.Transaction{TransactionID: uuid.New(), UserID: to, Amount: 30},
+		FromBalance: 70,
+		ToBalance:   80,
+	})
+	event := domain.OutboxEvent{EventID: 1, AggregateID: from, EventType: domain.EventTransferCompleted, Payload: string(payload)}
+	if err := stream.HandleEvent(context.Background(), event); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if msg := <-fromSub.Messages; msg.Balance != 70 || msg.Transaction.UserID != from {
+		t.Fatalf("unexpected message for sender: %+v", msg)
+	}
+	if msg := <-toSub.Messages; msg.Balance != 80 || msg.Transaction.UserID != to {
+		t.Fatalf("unexpected message for recipient: %+v", msg)
+	}
+	select {
+	case msg := <-otherSub.Messages:
+		t.Fatalf("unexpected message for unrelated user: %+v", msg)
+	default:
+	}
+}
+
+func TestTransactionStreamDeliversFeeRows(t *testing.T) {
+	stream := NewTransactionStream(&mockTransactionRepository{})
+	userID := uuid.New()
+	sub, cancel := stream.Subscribe(userID)
+	defer cancel()
+
+	debit := domain.Transaction{TransactionID: uuid.New(), UserID: userID, Category: domain.CategoryWithdraw, Amount: 100, BalanceBefore: 500, BalanceAfter: 400}
+	fee := domain.Transaction{TransactionID: uuid.New(), UserID: userID, Category: domain.CategoryFee, Amount: 5, BalanceBefore: 400, BalanceAfter: 395}
+	payload, _ := json.Marshal(domain.FundsMovedPayload{UserID: userID, Transaction: debit, Fee: &fee, Balance: 395})
+	if err := stream.HandleEvent(context.Background(), domain.OutboxEvent{EventID: 1, AggregateID: userID, EventType: domain.EventFundsWithdrawn, Payload: string(payload)}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	// sama dengan hasil Replay: satu pesan per baris dengan saldo setelah baris itu
+	if msg := <-sub.Messages; msg.Transaction.TransactionID != debit.TransactionID || msg.Balance != 400 {
+		t.Fatalf("unexpected withdrawal message: %+v", msg)
+	}
+	if msg := <-sub.Messages; msg.Transaction.TransactionID != fee.TransactionID || msg.Balance != 395 {
+		t.Fatalf("unexpected fee message: %+v", msg)
+	}
+}
+
+func TestTransactionStreamClosesSlowSubscriber(t *testing.T) {
+	stream := NewTransactionStream(&mockTransactionRepository{})
+
+	userID := uuid.New()
+	sub, cancel := stream.Subscribe(userID)
+	defer cancel()
+	for i := 0; i <= streamBufferSize; i++ {
+		stream.hub.dispatch(userID, domain.StreamMessage{UserID: userID})
+	}
+	select {
+	case <-sub.Closed:
+	default:
+		t.Fatalf("expected slow subscriber to be closed")
+	}
+}
+
+func TestTransactionStreamReplay(t *testing.T) {
+	db := setupTestDB(t)
+	stream := NewTransactionStream(&testTransactionRepo{db: db})
+	userID := uuid.New()
+	base := time.Now().Add(-time.Hour)
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		tx := domain.Transaction{TransactionID: uuid.New(), UserID: userID, TransactionType: domain.TransactionTypeCredit, Amount: 10, BalanceBefore: float64(i * 10), BalanceAfter: float64(i*10 + 10), CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		db.Create(&tx)
+		ids = append(ids, tx.TransactionID)
+	}
+
+	messages, more, err := stream.Replay(context.Background(), userID, ids[0])
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if more || len(messages) != 2 || messages[0].Transaction.TransactionID != ids[1] || messages[1].Balance != 30 {
+		t.Fatalf("unexpected replay: %+v", messages)
+	}
+	if _, _, err := stream.Replay(context.Background(), uuid.New(), ids[0]); err == nil {
+		t.Fatalf("expected error when replaying another user's transaction")
+	}
+}
+
+func TestTransactionStreamReplaySameTimestampAndLimit(t *testing.T) {
+	db := setupTestDB(t)
+	stream := NewTransactionStream(&testTransactionRepo{db: db})
+	userID := uuid.New()
+	// semua baris satu transfer bisa memiliki created_at yang sama
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	txs := make([]domain.Transaction, streamReplayLimit+3)
+	for i := range txs {
+		txs[i] = domain.Transaction{TransactionID: uuid.New(), UserID: userID, TransactionType: domain.TransactionTypeCredit, Amount: 1, CreatedAt: at}
+	}
+	if err := db.CreateInBatches(txs, 100).Error; err != nil {
+		t.Fatalf("failed to seed transactions: %v", err)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].TransactionID.String() < txs[j].TransactionID.String() })
+
+	messages, more, err := stream.Replay(context.Background(), userID, txs[0].TransactionID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !more || len(messages) != streamReplayLimit || messages[0].Transaction.TransactionID != txs[1].TransactionID {
+		t.Fatalf("expected first %d rows after the cursor, got %d (more=%v)", streamReplayLimit, len(messages), more)
+	}
+
+	last := messages[len(messages)-1].Transaction.TransactionID
+	messages, more, err = stream.Replay(context.Background(), userID, last)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if more || len(messages) != 2 || messages[0].Transaction.TransactionID != txs[streamReplayLimit+1].TransactionID {
+		t.Fatalf("expected remaining 2 rows, got %+v (more=%v)", messages, more)
+	}
+}
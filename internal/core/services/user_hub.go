@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Alasan penutupan Subscription oleh hub, dibaca lewat Subscription.Err.
+var (
+	ErrSubscriberTooSlow = errors.New("subscriber queue overflow")
+	ErrHubClosed         = errors.New("server shutting down")
+)
+
+// Subscription adalah satu koneksi real-time milik seorang user. Closed
+// ditutup ketika koneksi dilepas hub karena antreannya penuh atau karena
+// server berhenti; Err menjelaskan alasannya.
+type Subscription[T any] struct {
+	Messages <-chan T
+	Closed   <-chan struct{}
+
+	messages chan T
+	closed   chan struct{}
+	once     sync.Once
+	err      error
+}
+
+func (s *Subscription[T]) close(reason error) {
+	s.once.Do(func() {
+		s.err = reason
+		close(s.closed)
+	})
+}
+
+// Err mengembalikan alasan penutupan setelah Closed ditutup.
+func (s *Subscription[T]) Err() error {
+	<-s.closed
+	return s.err
+}
+
+// userHub menyimpan koneksi lokal per user. Setiap koneksi memiliki antrean
+// berkapasitas tetap; koneksi yang antreannya penuh ditutup alih-alih
+// memperlambat pengiriman ke koneksi lain.
+type userHub[T any] struct {
+	bufferSize int
+
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[*Subscription[T]]struct{}
+	shutdown    bool
+}
+
+func newUserHub[T any](bufferSize int) *userHub[T] {
+	return &userHub[T]{bufferSize: bufferSize, subscribers: make(map[uuid.UUID]map[*Subscription[T]]struct{})}
+}
+
+func (h *userHub[T]) subscribe(userID uuid.UUID) (*Subscription[T], func()) {
+	messages := make(chan T, h.bufferSize)
+	closed := make(chan struct{})
+	sub := &Subscription[T]{Messages: messages, Closed: closed, messages: messages, closed: closed}
+
+	h.mu.Lock()
+	if h.shutdown {
+		h.mu.Unlock()
+		sub.close(ErrHubClosed)
+		return sub, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription[T]]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub, func() {
+		h.mu.Lock()
+		delete(h.subscribers[userID], sub)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+		h.mu.Unlock()
+		sub.close(nil)
+	}
+}
+
+// closeAll menutup semua koneksi dan menolak koneksi baru, dipakai saat
+// server berhenti.
+func (h *userHub[T]) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.shutdown = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			sub.close(ErrHubClosed)
+		}
+	}
+}
+
+func (h *userHub[T]) dispatch(userID uuid.UUID, msg T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subscribers[userID] {
+		select {
+		case <-sub.closed:
+		case sub.messages <- msg:
+		default:
+			sub.close(ErrSubscriberTooSlow)
+		}
+	}
+}
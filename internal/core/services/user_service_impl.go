@@ -1,67 +1,456 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"log/slog"
+	"time"
+
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 	"hexagonal-go/internal/core/domain"
 	"hexagonal-go/internal/core/ports"
 )
 
+// Error login yang dapat dicocokkan adapter dengan errors.Is.
+var (
+	ErrUserInactive = errors.New("user inactive")
+	ErrInvalidPin   = errors.New("invalid pin")
+)
+
 type UserService struct {
-	userRepo ports.UserRepository
+	userRepo   ports.UserRepository
+	deviceRepo ports.DeviceRepository
+	db         *gorm.DB
+	outbox     ports.OutboxRepository
+	bcryptCost int
+	metrics    ports.Metrics
+	audit      *AuditService
+	screening  *ScreeningService
+}
+
+// UserServiceOption mengatur dependensi opsional UserService.
+type UserServiceOption func(*UserService)
+
+// WithUserOutbox menulis domain event perubahan akun ke outbox dalam transaksi
+// database yang sama dengan perubahannya.
+func WithUserOutbox(db *gorm.DB, outbox ports.OutboxRepository) UserServiceOption {
+	return func(s *UserService) {
+		s.db = db
+		s.outbox = outbox
+	}
+}
+
+// WithDeviceRepository mengaktifkan pencatatan perangkat login sehingga login
+// dari perangkat baru menghasilkan event NewDeviceLogin.
+func WithDeviceRepository(deviceRepo ports.DeviceRepository) UserServiceOption {
+	return func(s *UserService) {
+		s.deviceRepo = deviceRepo
+	}
+}
+
+// WithBcryptCost mengatur cost bcrypt untuk hash PIN (default bcrypt.DefaultCost).
+func WithBcryptCost(cost int) UserServiceOption {
+	return func(s *UserService) {
+		s.bcryptCost = cost
+	}
+}
+
+// WithUserMetrics melaporkan login yang gagal beserta alasannya ke m.
+func WithUserMetrics(m ports.Metrics) UserServiceOption {
+	return func(s *UserService) {
+		s.metrics = m
+	}
+}
+
+// WithUserAudit mencatat login, perubahan PIN, aktivasi, dan perubahan
+// profil ke audit log dalam transaksi database yang sama dengan perubahannya.
+func WithUserAudit(db *gorm.DB, audit *AuditService) UserServiceOption {
+	return func(s *UserService) {
+		s.db = db
+		s.audit = audit
+	}
 }
 
-func NewUserService(userRepo ports.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+// WithUserScreening menyaring nama user baru dan nama yang diubah lewat
+// profil terhadap daftar pantauan dalam transaksi database yang sama.
+func WithUserScreening(db *gorm.DB, screening *ScreeningService) UserServiceOption {
+	return func(s *UserService) {
+		s.db = db
+		s.screening = screening
+	}
+}
+
+func NewUserService(userRepo ports.UserRepository, opts ...UserServiceOption) *UserService {
+	s := &UserService{userRepo: userRepo, bcryptCost: bcrypt.DefaultCost, metrics: ports.NoopMetrics{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// withinTx menjalankan fn di dalam transaksi database jika outbox aktif, atau
+// langsung dengan repository biasa jika tidak.
+func (s *UserService) withinTx(ctx context.Context, fn func(repo ports.UserRepository, tx *gorm.DB) error) error {
+	if s.db == nil {
+		return fn(s.userRepo, nil)
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(s.userRepo.WithTx(tx), tx)
+	})
 }
 
-func (s *UserService) Register(user *domain.User) error {
-	hashedPin, err := bcrypt.GenerateFromPassword([]byte(user.Pin), bcrypt.DefaultCost)
+func (s *UserService) Register(ctx context.Context, user *domain.User) (err error) {
+	ctx, span := startSpan(ctx, "UserService.Register")
+	defer func() { endSpan(span, err) }()
+
+	hashedPin, err := s.hashPin(ctx, user.Pin)
 	if err != nil {
 		return err
 	}
 	user.Pin = string(hashedPin)
-	return s.userRepo.Create(user)
+	// Status akun selalu ditentukan server; saldo hanya berubah lewat ledger
+	// dan level KYC hanya lewat persetujuan reviewer
+	user.UserID = uuid.Nil
+	user.Balance = 0
+	user.IsActive = true
+	user.IsBlocked = false
+	user.IsFrozen = false
+	user.AccountTier = domain.AccountTierRegular
+	user.KYCLevel = domain.KYCLevelUnverified
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		if err := repo.Create(ctx, user); err != nil {
+			return err
+		}
+		if s.screening != nil {
+			if _, err := s.screening.ScreenWithTx(ctx, tx, user, domain.ScreeningTriggerRegister); err != nil {
+				return err
+			}
+		}
+		return recordEvent(ctx, s.outbox, tx, user.UserID, domain.EventUserRegistered, domain.UserRegisteredPayload{
+			UserID:    user.UserID,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		})
+	})
 }
 
-func (s *UserService) Login(phoneNumber, pin string) (*domain.User, error) {
-	user, err := s.userRepo.FindByPhoneNumber(phoneNumber)
+func (s *UserService) Login(ctx context.Context, phoneNumber, pin string) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.Login")
+	defer func() { endSpan(span, err) }()
+
+	user, err := s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.metrics.RecordLoginFailure(LoginFailureUnknownUser)
+		} else {
+			s.metrics.RecordLoginFailure(LoginFailureError)
+		}
 		return nil, err
 	}
+	// PIN diperiksa lebih dulu agar status akun (nonaktif atau diblokir
+	// screening) tidak terungkap kepada pihak yang tidak mengetahui PIN
+	if err := s.comparePin(ctx, user.Pin, pin); err != nil {
+		s.metrics.RecordLoginFailure(LoginFailureInvalidPin)
+		s.auditLoginFailure(ctx, user.UserID, LoginFailureInvalidPin)
+		return nil, ErrInvalidPin
+	}
 	if !user.IsActive {
-		return nil, errors.New("user inactive")
+		s.metrics.RecordLoginFailure(LoginFailureInactive)
+		s.auditLoginFailure(ctx, user.UserID, LoginFailureInactive)
+		return nil, ErrUserInactive
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Pin), []byte(pin)); err != nil {
-		return nil, errors.New("invalid pin")
+	if user.IsBlocked {
+		s.metrics.RecordLoginFailure(LoginFailureBlocked)
+		s.auditLoginFailure(ctx, user.UserID, LoginFailureBlocked)
+		return nil, ErrAccountBlocked
+	}
+
+	// Pelaku login adalah user itu sendiri walaupun request belum membawa token.
+	actor := AuditActorFrom(ctx)
+	actor.Type, actor.ID = domain.ActorUser, &user.UserID
+	if err := recordAudit(ctx, s.audit, nil, AuditRecord{Action: domain.AuditLogin, TargetID: &user.UserID, Actor: &actor}); err != nil {
+		return nil, err
 	}
 	return user, nil
 }
 
-func (s *UserService) GetByID(id uuid.UUID) (*domain.User, error) {
-	return s.userRepo.FindByID(id)
+// ValidateSession memastikan pemilik refresh token masih boleh menerima token
+// baru: akun ada, aktif, dan tidak diblokir.
+func (s *UserService) ValidateSession(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return ErrUserInactive
+	}
+	if user.IsBlocked {
+		return ErrAccountBlocked
+	}
+	return nil
 }
 
-func (s *UserService) UpdateProfile(user *domain.User) error {
-	return s.userRepo.Update(user)
+// auditLoginFailure mencatat login gagal untuk akun yang ada. Kegagalan
+// menulis audit hanya dicatat ke log agar error login asli tetap dikembalikan.
+func (s *UserService) auditLoginFailure(ctx context.Context, userID uuid.UUID, reason string) {
+	err := recordAudit(ctx, s.audit, nil, AuditRecord{
+		Action:   domain.AuditLoginFailed,
+		TargetID: &userID,
+		Metadata: map[string]interface{}{"reason": reason},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to audit login failure", "error", err)
+	}
+}
+
+func (s *UserService) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return s.userRepo.FindByID(ctx, id)
+}
+
+func (s *UserService) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	return s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
 }
 
-func (s *UserService) ChangePin(userID uuid.UUID, oldPin, newPin string) error {
-	user, err := s.userRepo.FindByID(userID)
+// GetByIDs mengambil beberapa user sekaligus. User yang tidak ditemukan tidak
+// disertakan dalam hasil.
+func (s *UserService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return s.userRepo.FindByIDs(ctx, ids)
+}
+
+// UpdateProfile menyimpan field profil user. Jika nama berubah, user disaring
+// ulang terhadap daftar pantauan dalam transaksi yang sama.
+func (s *UserService) UpdateProfile(ctx context.Context, user *domain.User) error {
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		stored, err := repo.FindByID(ctx, user.UserID)
+		if err != nil {
+			return err
+		}
+		before := *stored
+		applyProfile(stored, user)
+		if err := repo.Update(ctx, stored); err != nil {
+			return err
+		}
+		*user = *stored
+		if s.screening != nil && (before.FirstName != user.FirstName || before.LastName != user.LastName) {
+			if _, err := s.screening.ScreenWithTx(ctx, tx, user, domain.ScreeningTriggerProfileUpdate); err != nil {
+				return err
+			}
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditProfileUpdated,
+			TargetID: &user.UserID,
+			Changes:  profileChanges(&before, user),
+		})
+	})
+}
+
+// applyProfile menyalin hanya field profil yang boleh diubah user ke data
+// tersimpan; saldo, tier, status, dan field lain tetap seperti di database.
+func applyProfile(stored, profile *domain.User) {
+	stored.FirstName = profile.FirstName
+	stored.LastName = profile.LastName
+	stored.PhoneNumber = profile.PhoneNumber
+	stored.Address = profile.Address
+}
+
+// profileChanges membandingkan field profil sebelum dan sesudah perubahan.
+func profileChanges(before, after *domain.User) map[string]domain.AuditChange {
+	changes := make(map[string]domain.AuditChange)
+	for _, field := range []struct {
+		name          string
+		before, after string
+	}{
+		{"first_name", before.FirstName, after.FirstName},
+		{"last_name", before.LastName, after.LastName},
+		{"phone_number", before.PhoneNumber, after.PhoneNumber},
+		{"address", before.Address, after.Address},
+	} {
+		if field.before != field.after {
+			changes[field.name] = domain.AuditChange{Before: field.before, After: field.after}
+		}
+	}
+	return changes
+}
+
+// pinChange menandai PIN berubah tanpa menyimpan hash lama maupun baru.
+var pinChange = map[string]domain.AuditChange{"pin": {Before: auditRedacted, After: auditRedacted}}
+
+func (s *UserService) ChangePin(ctx context.Context, userID uuid.UUID, oldPin, newPin string) (err error) {
+	ctx, span := startSpan(ctx, "UserService.ChangePin", attribute.String("user.id", userID.String()))
+	defer func() { endSpan(span, err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Pin), []byte(oldPin)); err != nil {
+	if err := s.comparePin(ctx, user.Pin, oldPin); err != nil {
 		return errors.New("invalid old pin")
 	}
-	hashed, err := bcrypt.GenerateFromPassword([]byte(newPin), bcrypt.DefaultCost)
+	hashed, err := s.hashPin(ctx, newPin)
 	if err != nil {
 		return err
 	}
-	return s.userRepo.UpdatePin(userID, string(hashed))
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		if err := repo.UpdatePin(ctx, userID, string(hashed)); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventPinChanged, domain.AccountEventPayload{UserID: userID}); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{Action: domain.AuditPinChanged, TargetID: &userID, Changes: pinChange})
+	})
+}
+
+// ResetPin mengganti PIN tanpa memeriksa PIN lama. Dipakai petugas
+// operasional setelah verifikasi identitas di luar aplikasi.
+func (s *UserService) ResetPin(ctx context.Context, userID uuid.UUID, newPin string) error {
+	if newPin == "" {
+		return errors.New("pin required")
+	}
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+	hashed, err := s.hashPin(ctx, newPin)
+	if err != nil {
+		return err
+	}
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		if err := repo.UpdatePin(ctx, userID, string(hashed)); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventPinChanged, domain.AccountEventPayload{UserID: userID}); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{Action: domain.AuditPinReset, TargetID: &userID, Changes: pinChange})
+	})
+}
+
+func (s *UserService) SetActive(ctx context.Context, userID uuid.UUID, active bool) error {
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		wasActive := !active
+		if s.audit != nil {
+			user, err := repo.FindByID(ctx, userID)
+			if err != nil {
+				return err
+			}
+			wasActive = user.IsActive
+		}
+		if err := repo.SetActive(ctx, userID, active); err != nil {
+			return err
+		}
+		action := domain.AuditAccountActivated
+		if !active {
+			action = domain.AuditAccountDeactivated
+			if err := recordEvent(ctx, s.outbox, tx, userID, domain.EventAccountDeactivated, domain.AccountEventPayload{UserID: userID}); err != nil {
+				return err
+			}
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   action,
+			TargetID: &userID,
+			Changes:  map[string]domain.AuditChange{"is_active": {Before: wasActive, After: active}},
+		})
+	})
+}
+
+// Unfreeze mencairkan akun yang dibekukan rekonsiliasi. Hanya dipanggil dari
+// endpoint admin dan hexctl; akun yang tidak dibekukan dibiarkan apa adanya.
+func (s *UserService) Unfreeze(ctx context.Context, userID uuid.UUID) error {
+	return s.withinTx(ctx, func(repo ports.UserRepository, tx *gorm.DB) error {
+		user, err := repo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsFrozen {
+			return nil
+		}
+		if err := repo.SetFrozen(ctx, userID, false); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.audit, tx, AuditRecord{
+			Action:   domain.AuditAccountUnfrozen,
+			TargetID: &userID,
+			Changes:  map[string]domain.AuditChange{"is_frozen": {Before: true, After: false}},
+		})
+	})
+}
+
+// RecordLogin mencatat perangkat yang dipakai login. deviceKey adalah identitas
+// perangkat dari klien (misalnya header X-Device-ID). Mengembalikan true jika
+// perangkat belum pernah dipakai dan user sudah memiliki perangkat lain; hanya
+// pada kondisi itu event NewDeviceLogin dicatat.
+func (s *UserService) RecordLogin(ctx context.Context, userID uuid.UUID, deviceKey, userAgent, ipAddress string) (bool, error) {
+	if s.deviceRepo == nil {
+		return false, nil
+	}
+	sum := sha256.Sum256([]byte(deviceKey))
+	fingerprint := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	newDevice := false
+	err := s.withinTx(ctx, func(_ ports.UserRepository, tx *gorm.DB) error {
+		devices := s.deviceRepo
+		if tx != nil {
+			devices = devices.WithTx(tx)
+		}
+		device, err := devices.FindByFingerprint(ctx, userID, fingerprint)
+		if err == nil {
+			return devices.Touch(ctx, device.DeviceID, ipAddress, now)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		known, err := devices.CountByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		device = &domain.UserDevice{
+			DeviceID:    uuid.New(),
+			UserID:      userID,
+			Fingerprint: fingerprint,
+			UserAgent:   userAgent,
+			IPAddress:   ipAddress,
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+		}
+		if err := devices.Create(ctx, device); err != nil {
+			return err
+		}
+		if known == 0 {
+			return nil
+		}
+		newDevice = true
+		return recordEvent(ctx, s.outbox, tx, userID, domain.EventNewDeviceLogin, domain.NewDeviceLoginPayload{
+			UserID:    userID,
+			DeviceID:  device.DeviceID,
+			UserAgent: userAgent,
+			IPAddress: ipAddress,
+		})
+	})
+	return newDevice && err == nil, err
+}
+
+// hashPin dan comparePin membungkus bcrypt dalam span tersendiri karena cost
+// bcrypt sering menjadi bagian terbesar latensi login dan registrasi.
+func (s *UserService) hashPin(ctx context.Context, pin string) ([]byte, error) {
+	_, span := startSpan(ctx, "bcrypt.GenerateFromPassword", attribute.Int("bcrypt.cost", s.bcryptCost))
+	hashed, err := bcrypt.GenerateFromPassword([]byte(pin), s.bcryptCost)
+	endSpan(span, err)
+	return hashed, err
 }
 
-func (s *UserService) SetActive(userID uuid.UUID, active bool) error {
-	return s.userRepo.SetActive(userID, active)
+func (s *UserService) comparePin(ctx context.Context, hashed, pin string) error {
+	_, span := startSpan(ctx, "bcrypt.CompareHashAndPassword")
+	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(pin))
+	span.End()
+	return err
 }
@@ -1,11 +1,14 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"hexagonal-go/internal/adapters/repository"
 	"hexagonal-go/internal/core/domain"
 	"hexagonal-go/internal/core/ports"
 )
@@ -14,55 +17,75 @@ type mockUserRepository struct {
 	createFn            func(user *domain.User) error
 	findByPhoneNumberFn func(phoneNumber string) (*domain.User, error)
 	findByIDFn          func(id uuid.UUID) (*domain.User, error)
+	findByIDsFn         func(ids []uuid.UUID) ([]domain.User, error)
 	updateFn            func(user *domain.User) error
 	updatePinFn         func(userID uuid.UUID, hashedPin string) error
 	setActiveFn         func(userID uuid.UUID, active bool) error
+	setFrozenFn         func(userID uuid.UUID, frozen bool) error
 }
 
 var _ ports.UserRepository = (*mockUserRepository)(nil)
 
-func (m *mockUserRepository) Create(user *domain.User) error {
+func (m *mockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	if m.createFn != nil {
 		return m.createFn(user)
 	}
 	return nil
 }
 
-func (m *mockUserRepository) FindByPhoneNumber(phoneNumber string) (*domain.User, error) {
+func (m *mockUserRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
 	if m.findByPhoneNumberFn != nil {
 		return m.findByPhoneNumberFn(phoneNumber)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockUserRepository) FindByID(id uuid.UUID) (*domain.User, error) {
+func (m *mockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	if m.findByIDFn != nil {
 		return m.findByIDFn(id)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockUserRepository) Update(user *domain.User) error {
+func (m *mockUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.User, error) {
+	if m.findByIDsFn != nil {
+		return m.findByIDsFn(ids)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	if m.updateFn != nil {
 		return m.updateFn(user)
 	}
 	return nil
 }
 
-func (m *mockUserRepository) UpdatePin(userID uuid.UUID, hashedPin string) error {
+func (m *mockUserRepository) UpdatePin(ctx context.Context, userID uuid.UUID, hashedPin string) error {
 	if m.updatePinFn != nil {
 		return m.updatePinFn(userID, hashedPin)
 	}
 	return nil
 }
 
-func (m *mockUserRepository) SetActive(userID uuid.UUID, active bool) error {
+func (m *mockUserRepository) SetActive(ctx context.Context, userID uuid.UUID, active bool) error {
 	if m.setActiveFn != nil {
 		return m.setActiveFn(userID, active)
 	}
 	return nil
 }
 
+func (m *mockUserRepository) SetFrozen(ctx context.Context, userID uuid.UUID, frozen bool) error {
+	if m.setFrozenFn != nil {
+		return m.setFrozenFn(userID, frozen)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) WithTx(dbTx *gorm.DB) ports.UserRepository {
+	return m
+}
+
 func TestUserServiceRegister(t *testing.T) {
 	var savedUser *domain.User
 	repo := &mockUserRepository{
@@ -73,7 +96,7 @@ func TestUserServiceRegister(t *testing.T) {
 	}
 	service := NewUserService(repo)
 
-	if err := service.Register(&domain.User{PhoneNumber: "08123", Pin: "1234"}); err != nil {
+	if err := service.Register(context.Background(), &domain.User{PhoneNumber: "08123", Pin: "1234"}); err != nil {
 		t.Fatalf("Register returned error: %v", err)
 	}
 	if savedUser == nil {
@@ -87,6 +110,37 @@ func TestUserServiceRegister(t *testing.T) {
 	}
 }
 
+func TestUserServiceRegisterIgnoresClientControlledFields(t *testing.T) {
+	var savedUser *domain.User
+	repo := &mockUserRepository{
+		createFn: func(u *domain.User) error {
+			savedUser = u
+			return nil
+		},
+	}
+	service := NewUserService(repo)
+
+	user := &domain.User{
+		UserID:      uuid.New(),
+		PhoneNumber: "08123",
+		Pin:         "1234",
+		Balance:     5000000,
+		IsBlocked:   true,
+		IsFrozen:    true,
+		AccountTier: domain.AccountTierPremium,
+		KYCLevel:    domain.KYCLevelFull,
+	}
+	if err := service.Register(context.Background(), user); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if savedUser.UserID != uuid.Nil || savedUser.Balance != 0 || savedUser.IsBlocked || savedUser.IsFrozen || !savedUser.IsActive {
+		t.Fatalf("expected server-controlled account state, got %+v", savedUser)
+	}
+	if savedUser.AccountTier != domain.AccountTierRegular || savedUser.KYCLevel != domain.KYCLevelUnverified {
+		t.Fatalf("expected REGULAR/UNVERIFIED, got %s/%s", savedUser.AccountTier, savedUser.KYCLevel)
+	}
+}
+
 func TestUserServiceLogin(t *testing.T) {
 	hashed, _ := bcrypt.GenerateFromPassword([]byte("1234"), bcrypt.DefaultCost)
 	repo := &mockUserRepository{
@@ -96,10 +150,10 @@ func TestUserServiceLogin(t *testing.T) {
 	}
 	service := NewUserService(repo)
 
-	if _, err := service.Login("08123", "1234"); err != nil {
+	if _, err := service.Login(context.Background(), "08123", "1234"); err != nil {
 		t.Fatalf("expected login to succeed, got %v", err)
 	}
-	if _, err := service.Login("08123", "4321"); err == nil {
+	if _, err := service.Login(context.Background(), "08123", "4321"); err == nil {
 		t.Fatalf("expected error for invalid pin")
 	}
 }
@@ -113,11 +167,48 @@ func TestUserServiceLoginInactiveUser(t *testing.T) {
 	}
 	service := NewUserService(repo)
 
-	if _, err := service.Login("08123", "1234"); err == nil {
+	if _, err := service.Login(context.Background(), "08123", "1234"); err == nil {
 		t.Fatalf("expected error for inactive user")
 	}
 }
 
+func TestUserServiceLoginBlockedUserChecksPinFirst(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("1234"), bcrypt.MinCost)
+	repo := &mockUserRepository{
+		findByPhoneNumberFn: func(phone string) (*domain.User, error) {
+			return &domain.User{PhoneNumber: phone, Pin: string(hashed), IsActive: true, IsBlocked: true}, nil
+		},
+	}
+	service := NewUserService(repo)
+
+	if _, err := service.Login(context.Background(), "08123", "4321"); !errors.Is(err, ErrInvalidPin) {
+		t.Fatalf("expected ErrInvalidPin for a wrong pin, got %v", err)
+	}
+	if _, err := service.Login(context.Background(), "08123", "1234"); !errors.Is(err, ErrAccountBlocked) {
+		t.Fatalf("expected ErrAccountBlocked for the right pin, got %v", err)
+	}
+}
+
+func TestUserServiceValidateSession(t *testing.T) {
+	users := map[uuid.UUID]*domain.User{}
+	active, blocked, inactive := uuid.New(), uuid.New(), uuid.New()
+	users[active] = &domain.User{UserID: active, IsActive: true}
+	users[blocked] = &domain.User{UserID: blocked, IsActive: true, IsBlocked: true}
+	users[inactive] = &domain.User{UserID: inactive}
+	service := NewUserService(&mockUserRepository{findByIDFn: func(id uuid.UUID) (*domain.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		return nil, gorm.ErrRecordNotFound
+	}})
+
+	for id, want := range map[uuid.UUID]error{active: nil, blocked: ErrAccountBlocked, inactive: ErrUserInactive, uuid.New(): gorm.ErrRecordNotFound} {
+		if err := service.ValidateSession(context.Background(), id); !errors.Is(err, want) {
+			t.Errorf("expected %v, got %v", want, err)
+		}
+	}
+}
+
 func TestUserServiceGetByID(t *testing.T) {
 	userID := uuid.New()
 	expected := &domain.User{UserID: userID}
@@ -130,7 +221,7 @@ func TestUserServiceGetByID(t *testing.T) {
 		},
 	}
 	service := NewUserService(repo)
-	user, err := service.GetByID(userID)
+	user, err := service.GetByID(context.Background(), userID)
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -142,18 +233,27 @@ func TestUserServiceGetByID(t *testing.T) {
 func TestUserServiceUpdateProfile(t *testing.T) {
 	var updatedUser *domain.User
 	repo := &mockUserRepository{
+		findByIDFn: func(id uuid.UUID) (*domain.User, error) {
+			return &domain.User{UserID: id, FirstName: "Old", Balance: 100, AccountTier: domain.AccountTierRegular, KYCLevel: domain.KYCLevelBasic}, nil
+		},
 		updateFn: func(u *domain.User) error {
 			updatedUser = u
 			return nil
 		},
 	}
 	service := NewUserService(repo)
-	user := &domain.User{UserID: uuid.New(), FirstName: "New"}
-	if err := service.UpdateProfile(user); err != nil {
+	user := &domain.User{UserID: uuid.New(), FirstName: "New", Address: "Jl. Baru", Balance: 999999, AccountTier: domain.AccountTierPremium, IsBlocked: true, KYCLevel: domain.KYCLevelFull}
+	if err := service.UpdateProfile(context.Background(), user); err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
-	if updatedUser != user {
-		t.Fatalf("expected update to be called with user")
+	if updatedUser == nil || updatedUser.FirstName != "New" || updatedUser.Address != "Jl. Baru" {
+		t.Fatalf("expected profile fields to be updated, got %+v", updatedUser)
+	}
+	if updatedUser.Balance != 100 || updatedUser.AccountTier != domain.AccountTierRegular || updatedUser.IsBlocked || updatedUser.KYCLevel != domain.KYCLevelBasic {
+		t.Fatalf("expected non-profile fields to be kept, got %+v", updatedUser)
+	}
+	if *user != *updatedUser {
+		t.Fatalf("expected user to be replaced with the stored profile, got %+v", user)
 	}
 }
 
@@ -177,7 +277,7 @@ func TestUserServiceChangePin(t *testing.T) {
 		},
 	}
 	service := NewUserService(repo)
-	if err := service.ChangePin(userID, "1234", "4321"); err != nil {
+	if err := service.ChangePin(context.Background(), userID, "1234", "4321"); err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
 	if !updated {
@@ -198,7 +298,81 @@ func TestUserServiceChangePinInvalidOldPin(t *testing.T) {
 		},
 	}
 	service := NewUserService(repo)
-	if err := service.ChangePin(userID, "0000", "4321"); err == nil {
+	if err := service.ChangePin(context.Background(), userID, "0000", "4321"); err == nil {
 		t.Fatalf("expected error for invalid old pin")
 	}
 }
+
+func TestUserServiceResetPin(t *testing.T) {
+	userID := uuid.New()
+	var updated bool
+	repo := &mockUserRepository{
+		findByIDFn: func(id uuid.UUID) (*domain.User, error) {
+			return &domain.User{UserID: userID}, nil
+		},
+		updatePinFn: func(id uuid.UUID, hashedPin string) error {
+			updated = true
+			if err := bcrypt.CompareHashAndPassword([]byte(hashedPin), []byte("654321")); err != nil {
+				t.Errorf("pin was not hashed correctly: %v", err)
+			}
+			return nil
+		},
+	}
+	service := NewUserService(repo)
+	if err := service.ResetPin(context.Background(), userID, "654321"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if !updated {
+		t.Fatalf("expected UpdatePin to be called")
+	}
+}
+
+func TestUserServiceResetPinUnknownUser(t *testing.T) {
+	repo := &mockUserRepository{
+		findByIDFn: func(id uuid.UUID) (*domain.User, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		updatePinFn: func(id uuid.UUID, hashedPin string) error {
+			t.Fatalf("UpdatePin should not be called for unknown user")
+			return nil
+		},
+	}
+	service := NewUserService(repo)
+	if err := service.ResetPin(context.Background(), uuid.New(), "654321"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestUserServiceRecordLoginNewDevice(t *testing.T) {
+	db := setupOutboxDB(t)
+	if err := db.AutoMigrate(&domain.UserDevice{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	service := NewUserService(&mockUserRepository{},
+		WithUserOutbox(db, &testOutboxRepo{db: db}),
+		WithDeviceRepository(repository.NewDeviceRepositoryImpl(db)))
+	userID := uuid.New()
+
+	// Perangkat pertama dan login ulang dari perangkat yang sama tidak memicu notifikasi.
+	for i := 0; i < 2; i++ {
+		isNew, err := service.RecordLogin(context.Background(), userID, "phone-1", "app/1.0", "10.0.0.1")
+		if err != nil || isNew {
+			t.Fatalf("expected known device, got new=%v err=%v", isNew, err)
+		}
+	}
+	isNew, err := service.RecordLogin(context.Background(), userID, "phone-2", "app/1.0", "10.0.0.2")
+	if err != nil || !isNew {
+		t.Fatalf("expected new device, got new=%v err=%v", isNew, err)
+	}
+
+	var events []domain.OutboxEvent
+	db.Find(&events)
+	if len(events) != 1 || events[0].EventType != domain.EventNewDeviceLogin || events[0].AggregateID != userID {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	var devices int64
+	db.Model(&domain.UserDevice{}).Where("user_id = ?", userID).Count(&devices)
+	if devices != 2 {
+		t.Fatalf("expected 2 devices, got %d", devices)
+	}
+}
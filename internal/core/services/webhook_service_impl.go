@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"hexagonal-go/internal/core/domain"
+	"hexagonal-go/internal/core/ports"
+)
+
+const (
+	webhookMaxAttempts = 8
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+	webhookBatchSize   = 50
+	// webhookClaimLease harus lebih lama dari timeout sender dikali
+	// webhookBatchSize agar delivery tidak diklaim ulang saat batch masih dikirim.
+	webhookClaimLease = 10 * time.Minute
+)
+
+// WebhookService mengelola subscription webhook partner dan mengirimkan event
+// akun kepada mereka dengan retry exponential backoff. Delivery yang gagal
+// webhookMaxAttempts kali dipindahkan ke status DEAD_LETTER.
+type WebhookService struct {
+	webhookRepo ports.WebhookRepository
+	sender      ports.WebhookSender
+	now         func() time.Time
+}
+
+func NewWebhookService(webhookRepo ports.WebhookRepository, sender ports.WebhookSender) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo, sender: sender, now: time.Now}
+}
+
+// CreateSubscription mendaftarkan URL partner dan mengembalikan secret untuk
+// verifikasi signature. Secret hanya dikembalikan sekali saat pendaftaran.
+func (s *WebhookService) CreateSubscription(ctx context.Context, ownerID uuid.UUID, rawURL string, eventTypes []string) (*domain.WebhookSubscription, string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, "", errors.New("invalid webhook url")
+	}
+	if len(eventTypes) == 0 {
+		return nil, "", errors.New("event types required")
+	}
+	for _, eventType := range eventTypes {
+		if !isWebhookEvent(eventType) {
+			return nil, "", fmt.Errorf("unsupported event type %q", eventType)
+		}
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", err
+	}
+	secret := hex.EncodeToString(secretBytes)
+	sub := &domain.WebhookSubscription{
+		SubscriptionID: uuid.New(),
+		OwnerID:        ownerID,
+		URL:            rawURL,
+		EventTypes:     strings.Join(eventTypes, ","),
+		Secret:         secret,
+		IsActive:       true,
+	}
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, "", err
+	}
+	return sub, secret, nil
+}
+
+func isWebhookEvent(eventType string) bool {
+	switch eventType {
+	case domain.EventUserRegistered, domain.EventFundsDeposited, domain.EventFundsWithdrawn,
+		domain.EventTransferCompleted, domain.EventPinChanged, domain.EventAccountDeactivated:
+		return true
+	}
+	return false
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	return s.webhookRepo.FindSubscriptionsByOwner(ctx, ownerID)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, ownerID, subscriptionID uuid.UUID) error {
+	if _, err := s.ownedSubscription(ctx, ownerID, subscriptionID); err != nil {
+		return err
+	}
+	return s.webhookRepo.DeleteSubscription(ctx, subscriptionID)
+}
+
+func (s *WebhookService) ownedSubscription(ctx context.Context, ownerID, subscriptionID uuid.UUID) (*domain.WebhookSubscription, error) {
+	sub, err := s.webhookRepo.FindSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub.OwnerID != ownerID {
+		return nil, errors.New("subscription not found")
+	}
+	return sub, nil
+}
+
+// HandleEvent membuat delivery untuk setiap subscription yang berlangganan
+// event ini. Dipasang sebagai subscriber pada EventPublisher.
+func (s *WebhookService) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	if !isWebhookEvent(event.EventType) {
+		return nil
+	}
+	// data berisi payload per pemilik; pihak lain dalam transfer tidak boleh
+	// melihat baris transaksi dan saldo lawannya
+	data := map[uuid.UUID]interface{}{event.AggregateID: json.RawMessage(event.Payload)}
+	if event.EventType == domain.EventTransferCompleted {
+		var payload domain.TransferCompletedPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return err
+		}
+		data = map[uuid.UUID]interface{}{
+			payload.FromUserID: transferWebhookData{payload.FromUserID, payload.ToUserID, payload.Amount, payload.Debit, payload.Fee, payload.FromBalance},
+			payload.ToUserID:   transferWebhookData{payload.FromUserID, payload.ToUserID, payload.Amount, payload.Credit, nil, payload.ToBalance},
+		}
+	}
+	owners := make([]uuid.UUID, 0, len(data))
+	for owner := range data {
+		owners = append(owners, owner)
+	}
+
+	subs, err := s.webhookRepo.FindActiveSubscriptionsByOwners(ctx, owners)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if !sub.Matches(event.EventType) {
+			continue
+		}
+		body, err := json.Marshal(struct {
+			EventID    uint64      `json:"event_id"`
+			EventType  string      `json:"event_type"`
+			OccurredAt time.Time   `json:"occurred_at"`
+			Data       interface{} `json:"data"`
+		}{event.EventID, event.EventType, event.OccurredAt, data[sub.OwnerID]})
+		if err != nil {
+			return err
+		}
+		delivery := &domain.WebhookDelivery{
+			DeliveryID:     uuid.New(),
+			SubscriptionID: sub.SubscriptionID,
+			EventID:        event.EventID,
+			EventType:      event.EventType,
+			Payload:        string(body),
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  s.now(),
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferWebhookData adalah data TransferCompleted untuk satu pihak: hanya
+// baris transaksi dan saldo milik pemilik subscription.
+type transferWebhookData struct {
+	FromUserID  uuid.UUID           `json:"from_user_id"`
+	ToUserID    uuid.UUID           `json:"to_user_id"`
+	Amount      float64             `json:"amount"`
+	Transaction domain.Transaction  `json:"transaction"`
+	Fee         *domain.Transaction `json:"fee,omitempty"`
+	Balance     float64             `json:"balance"`
+}
+
+// DeliverDue mengirim delivery yang sudah jatuh tempo dan mengembalikan jumlah
+// yang berhasil terkirim. Delivery diklaim lebih dulu agar replika lain tidak
+// mengirim delivery yang sama; klaim yang tidak selesai kedaluwarsa setelah
+// webhookClaimLease.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, s.now(), webhookClaimLease, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for i := range deliveries {
+		ok, err := s.deliver(ctx, &deliveries[i])
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, delivery *domain.WebhookDelivery) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.deliver",
+		attribute.String("webhook.delivery_id", delivery.DeliveryID.String()), attribute.String("webhook.event", delivery.EventType))
+	defer func() { endSpan(span, err) }()
+
+	sub, err := s.webhookRepo.FindSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		delivery.Status = domain.DeliveryDeadLetter
+		delivery.LastError = "subscription not found"
+		return false, s.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	headers := map[string]string{
+		"X-Webhook-Event":     delivery.EventType,
+		"X-Webhook-Delivery":  delivery.DeliveryID.String(),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + SignWebhook(sub.Secret, timestamp, []byte(delivery.Payload)),
+	}
+
+	started := time.Now()
+	statusCode, sendErr := s.sender.Send(ctx, sub.URL, headers, []byte(delivery.Payload))
+	span.SetAttributes(attribute.Int("http.status_code", statusCode))
+	attempt := &domain.WebhookAttempt{
+		AttemptID:   uuid.New(),
+		DeliveryID:  delivery.DeliveryID,
+		StatusCode:  statusCode,
+		DurationMs:  time.Since(started).Milliseconds(),
+		AttemptedAt: s.now(),
+	}
+	if sendErr == nil && (statusCode < 200 || statusCode > 299) {
+		sendErr = fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+	if err := s.webhookRepo.CreateAttempt(ctx, attempt); err != nil {
+		return false, err
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	if sendErr == nil {
+		deliveredAt := s.now()
+		delivery.Status = domain.DeliverySucceeded
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = ""
+		return true, s.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = domain.DeliveryDeadLetter
+	} else {
+		delivery.NextAttemptAt = s.now().Add(webhookBackoff(delivery.Attempts))
+	}
+	return false, s.webhookRepo.UpdateDelivery(ctx, delivery)
+}
+
+// webhookBackoff menghitung jeda sebelum percobaan berikutnya: 30 detik
+// digandakan setiap kegagalan, maksimal 6 jam.
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
+
+// SignWebhook menghasilkan HMAC-SHA256 (hex) atas "timestamp.body". Partner
+// memverifikasi header X-Webhook-Signature dengan cara yang sama.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, ownerID, subscriptionID uuid.UUID) ([]domain.WebhookDelivery, error) {
+	if _, err := s.ownedSubscription(ctx, ownerID, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.FindDeliveriesBySubscription(ctx, subscriptionID)
+}
+
+// GetDelivery mengembalikan delivery beserta log seluruh percobaannya.
+func (s *WebhookService) GetDelivery(ctx context.Context, ownerID, deliveryID uuid.UUID) (*domain.WebhookDelivery, []domain.WebhookAttempt, error) {
+	delivery, err := s.webhookRepo.FindDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.ownedSubscription(ctx, ownerID, delivery.SubscriptionID); err != nil {
+		return nil, nil, err
+	}
+	attempts, err := s.webhookRepo.FindAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, attempts, nil
+}
+
+// Redeliver menjadwalkan ulang delivery (termasuk yang DEAD_LETTER) untuk
+// segera dikirim dengan siklus retry baru.
+func (s *WebhookService) Redeliver(ctx context.Context, ownerID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.FindDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ownedSubscription(ctx, ownerID, delivery.SubscriptionID); err != nil {
+		return nil, err
+	}
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now()
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Run mengirim delivery yang jatuh tempo setiap interval sampai ctx dibatalkan.
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.DeliverDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "webhook delivery failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"hexagonal-go/internal/adapters/repository"
+	"hexagonal-go/internal/adapters/webhook"
+	"hexagonal-go/internal/core/domain"
+)
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func setupWebhookTest(t *testing.T, receiver *webhookReceiver) (*WebhookService, *repository.WebhookRepositoryImpl, string) {
+	db := setupTestDB(t)
+	if err := db.AutoMigrate(&domain.WebhookSubscription{}, &domain.WebhookDelivery{}, &domain.WebhookAttempt{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+	repo := repository.NewWebhookRepositoryImpl(db)
+	return NewWebhookService(repo, webhook.NewHTTPSender(time.Second, webhook.WithPrivateTargets())), repo, server.URL
+}
+
+func transferEvent(t *testing.T, from, to uuid.UUID) domain.OutboxEvent {
+	payload, err := json.Marshal(domain.TransferCompletedPayload{FromUserID: from, ToUserID: to, Amount: 10})
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	return domain.OutboxEvent{EventID: 1, AggregateType: domain.AggregateUser, AggregateID: from, EventType: domain.EventTransferCompleted, Payload: string(payload)}
+}
+
+func TestWebhookServiceDeliversSignedPayloadWithRetry(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	service, _, url := setupWebhookTest(t, receiver)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	owner := uuid.New()
+	sub, secret, err := service.CreateSubscription(context.Background(), owner, url, []string{domain.EventTransferCompleted})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	// owner adalah penerima transfer; event yang dipublikasikan ulang tidak menggandakan delivery
+	event := transferEvent(t, uuid.New(), owner)
+	for i := 0; i < 2; i++ {
+		if err := service.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+	deliveries, err := service.ListDeliveries(context.Background(), owner, sub.SubscriptionID)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d (%v)", len(deliveries), err)
+	}
+
+	if delivered, err := service.DeliverDue(context.Background()); err != nil || delivered != 0 {
+		t.Fatalf("expected failed delivery, got %d (%v)", delivered, err)
+	}
+	if delivered, _ := service.DeliverDue(context.Background()); delivered != 0 || len(receiver.requests) != 1 {
+		t.Fatalf("expected retry to wait for backoff")
+	}
+
+	receiver.status = http.StatusOK
+	now = now.Add(webhookBaseBackoff)
+	if delivered, err := service.DeliverDue(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("expected successful delivery, got %d (%v)", delivered, err)
+	}
+
+	req := receiver.requests[1]
+	expected := "sha256=" + SignWebhook(secret, req.Header.Get("X-Webhook-Timestamp"), receiver.bodies[1])
+	if req.Header.Get("X-Webhook-Signature") != expected {
+		t.Fatalf("invalid signature header %q", req.Header.Get("X-Webhook-Signature"))
+	}
+
+	delivery, attempts, err := service.GetDelivery(context.Background(), owner, deliveries[0].DeliveryID)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if delivery.Status != domain.DeliverySucceeded || delivery.Attempts != 2 || len(attempts) != 2 {
+		t.Fatalf("unexpected delivery state: %+v with %d attempts", delivery, len(attempts))
+	}
+}
+
+func TestWebhookServiceDeadLetterAndRedeliver(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusBadGateway}
+	service, repo, url := setupWebhookTest(t, receiver)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	owner := uuid.New()
+	sub, _, err := service.CreateSubscription(context.Background(), owner, url, []string{domain.EventTransferCompleted})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := service.HandleEvent(context.Background(), transferEvent(t, owner, uuid.New())); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	for i := 0; i < webhookMaxAttempts; i++ {
+		if _, err := service.DeliverDue(context.Background()); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		now = now.Add(webhookMaxBackoff)
+	}
+	deliveries, _ := repo.FindDeliveriesBySubscription(context.Background(), sub.SubscriptionID)
+	if deliveries[0].Status != domain.DeliveryDeadLetter || deliveries[0].Attempts != webhookMaxAttempts {
+		t.Fatalf("expected dead letter after %d attempts, got %+v", webhookMaxAttempts, deliveries[0])
+	}
+
+	if _, err := service.Redeliver(context.Background(), uuid.New(), deliveries[0].DeliveryID); err == nil {
+		t.Fatalf("expected other users to be unable to redeliver")
+	}
+	receiver.status = http.StatusNoContent
+	if _, err := service.Redeliver(context.Background(), owner, deliveries[0].DeliveryID); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if delivered, err := service.DeliverDue(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("expected redelivery to succeed, got %d (%v)", delivered, err)
+	}
+}
+
+func TestWebhookServiceRejectsInvalidSubscription(t *testing.T) {
+	service, _, url := setupWebhookTest(t, &webhookReceiver{status: http.StatusOK})
+	if _, _, err := service.CreateSubscription(context.Background(), uuid.New(), "ftp://example.com", []string{domain.EventFundsDeposited}); err == nil {
+		t.Fatalf("expected error for invalid url")
+	}
+	if _, _, err := service.CreateSubscription(context.Background(), uuid.New(), url, []string{"Unknown"}); err == nil {
+		t.Fatalf("expected error for unknown event type")
+	}
+}
+
+func TestWebhookServiceSendsOnlyOwnTransferLeg(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	service, _, url := setupWebhookTest(t, receiver)
+	ctx := context.Background()
+	sender, recipient := uuid.New(), uuid.New()
+	for _, owner := range []uuid.UUID{sender, recipient} {
+		if _, _, err := service.CreateSubscription(ctx, owner, url, []string{domain.EventTransferCompleted}); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+	payload, _ := json.Marshal(domain.TransferCompletedPayload{FromUserID: sender, ToUserID: recipient, Amount: 10,
+		Debit: domain.Transaction{UserID: sender, TransactionType: "DEBIT", Amount: 10}, Credit: domain.Transaction{UserID: recipient, TransactionType: "CREDIT", Amount: 10},
+		FromBalance: 90, ToBalance: 510})
+	event := domain.OutboxEvent{EventID: 1, AggregateType: domain.AggregateUser, AggregateID: sender, EventType: domain.EventTransferCompleted, Payload: string(payload)}
+	if err := service.HandleEvent(ctx, event); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 2 {
+		t.Fatalf("expected 2 deliveries, got %d (%v)", delivered, err)
+	}
+
+	balances := map[uuid.UUID]float64{}
+	for _, body := range receiver.bodies {
+		var message struct {
+			Data map[string]json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Fatalf("invalid body %s: %v", body, err)
+		}
+		if _, ok := message.Data["from_balance"]; ok {
+			t.Fatalf("expected counterparty fields to be omitted: %s", body)
+		}
+		if _, ok := message.Data["to_balance"]; ok {
+			t.Fatalf("expected counterparty fields to be omitted: %s", body)
+		}
+		var transaction domain.Transaction
+		var balance float64
+		json.Unmarshal(message.Data["transaction"], &transaction)
+		json.Unmarshal(message.Data["balance"], &balance)
+		balances[transaction.UserID] = balance
+	}
+	if len(balances) != 2 || balances[sender] != 90 || balances[recipient] != 510 {
+		t.Fatalf("expected each owner to receive only their leg, got %v", balances)
+	}
+}
+
+func TestWebhookServiceClaimsDeliveriesOnce(t *testing.T) {
+	receiver := &webhookReceiver{status: http.StatusOK}
+	service, repo, url := setupWebhookTest(t, receiver)
+	ctx := context.Background()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	owner := uuid.New()
+	if _, _, err := service.CreateSubscription(ctx, owner, url, []string{domain.EventTransferCompleted}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := service.HandleEvent(ctx, transferEvent(t, owner, uuid.New())); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	// replika lain sudah mengklaim delivery ini tetapi belum selesai mengirim
+	if claimed, err := repo.ClaimDueDeliveries(ctx, now, webhookClaimLease, webhookBatchSize); err != nil || len(claimed) != 1 {
+		t.Fatalf("expected 1 claimed delivery, got %d (%v)", len(claimed), err)
+	}
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 0 || len(receiver.requests) != 0 {
+		t.Fatalf("expected claimed delivery to be skipped, got %d (%v)", delivered, err)
+	}
+	now = now.Add(webhookClaimLease)
+	if delivered, err := service.DeliverDue(ctx); err != nil || delivered != 1 {
+		t.Fatalf("expected expired claim to be delivered, got %d (%v)", delivered, err)
+	}
+}
@@ -0,0 +1,159 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Hook adalah satu komponen yang dijalankan Lifecycle. Start tidak boleh
+// memblokir; komponen yang berjalan terus harus memakai goroutine sendiri.
+// Stop harus kembali paling lambat saat ctx selesai. Alive, jika diisi,
+// melaporkan apakah komponen masih berjalan dan dipakai oleh Check.
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+	Alive func() bool
+}
+
+// Lifecycle menjalankan hook sesuai urutan pendaftaran dan menghentikannya
+// dalam urutan terbalik, sehingga komponen yang didaftarkan lebih dulu
+// (mis. koneksi database) berhenti paling akhir setelah server selesai
+// menguras request yang sedang berjalan.
+type Lifecycle struct {
+	hooks   []Hook
+	started int
+	failed  chan error
+}
+
+func New() *Lifecycle {
+	return &Lifecycle{failed: make(chan error, 1)}
+}
+
+// Append mendaftarkan hook.
+func (l *Lifecycle) Append(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Fail melaporkan kegagalan fatal dari komponen yang sedang berjalan, mis.
+// server yang berhenti menerima koneksi. Run kemudian memulai shutdown.
+func (l *Lifecycle) Fail(err error) {
+	select {
+	case l.failed <- err:
+	default:
+	}
+}
+
+// Start menjalankan semua hook. Jika salah satu gagal, hook yang sudah
+// berjalan dihentikan kembali.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, hook := range l.hooks {
+		if hook.Start != nil {
+			if err := hook.Start(ctx); err != nil {
+				err = fmt.Errorf("start %s: %w", hook.Name, err)
+				return errors.Join(err, l.Stop(ctx))
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+// Stop menghentikan hook yang sudah berjalan dalam urutan terbalik dan
+// mengumpulkan semua error.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.Stop == nil {
+			continue
+		}
+		if err := hook.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run menjalankan semua hook, menunggu sampai ctx selesai (mis. karena
+// SIGTERM) atau ada komponen yang gagal, lalu menghentikan semuanya dengan
+// batas waktu shutdownTimeout.
+func (l *Lifecycle) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	if err := l.Start(ctx); err != nil {
+		return err
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	case runErr = <-l.failed:
+		slog.Error("shutting down after failure", "error", runErr)
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return errors.Join(runErr, l.Stop(stopCtx))
+}
+
+// Check mengembalikan error jika ada komponen yang dipantau tidak sedang
+// berjalan, misalnya job latar belakang yang berhenti sendiri.
+func (l *Lifecycle) Check(ctx context.Context) error {
+	var stopped []string
+	for _, hook := range l.hooks {
+		if hook.Alive != nil && !hook.Alive() {
+			stopped = append(stopped, hook.Name)
+		}
+	}
+	if len(stopped) > 0 {
+		return fmt.Errorf("not running: %s", strings.Join(stopped, ", "))
+	}
+	return nil
+}
+
+// Worker membuat hook untuk job latar belakang yang berjalan sampai
+// context-nya dibatalkan. Stop membatalkan context tersebut lalu menunggu run
+// selesai, misalnya menuntaskan batch yang sedang diproses.
+func Worker(name string, run func(ctx context.Context)) Hook {
+	var (
+		cancel  context.CancelFunc
+		done    chan struct{}
+		mu      sync.Mutex
+		running atomic.Bool
+	)
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+			running.Store(true)
+			go func() {
+				defer close(done)
+				defer running.Store(false)
+				run(ctx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		Alive: running.Load,
+	}
+}
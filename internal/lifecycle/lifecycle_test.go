@@ -0,0 +1,132 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func recordingHook(name string, events *[]string, startErr error) Hook {
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			*events = append(*events, "start "+name)
+			return startErr
+		},
+		Stop: func(context.Context) error {
+			*events = append(*events, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestLifecycleStopsInReverseOrder(t *testing.T) {
+	var events []string
+	l := New()
+	l.Append(recordingHook("db", &events, nil))
+	l.Append(recordingHook("worker", &events, nil))
+	l.Append(recordingHook("http", &events, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Run(ctx, time.Second); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	want := []string{"start db", "start worker", "start http", "stop http", "stop worker", "stop db"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("unexpected order: %v", events)
+	}
+}
+
+func TestLifecycleStartFailureStopsStartedHooks(t *testing.T) {
+	var events []string
+	boom := errors.New("address in use")
+	l := New()
+	l.Append(recordingHook("db", &events, nil))
+	l.Append(recordingHook("http", &events, boom))
+	l.Append(recordingHook("grpc", &events, nil))
+
+	if err := l.Start(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected start error, got %v", err)
+	}
+	want := []string{"start db", "start http", "stop db"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
+
+func TestLifecycleFailTriggersShutdown(t *testing.T) {
+	var events []string
+	boom := errors.New("listener closed")
+	l := New()
+	l.Append(recordingHook("db", &events, nil))
+	l.Fail(boom)
+
+	if err := l.Run(context.Background(), time.Second); !errors.Is(err, boom) {
+		t.Fatalf("expected failure to be returned, got %v", err)
+	}
+	if !reflect.DeepEqual(events, []string{"start db", "stop db"}) {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
+
+func TestWorkerWaitsForRunToReturn(t *testing.T) {
+	finished := make(chan struct{})
+	hook := Worker("relay", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond) // menuntaskan batch terakhir
+		close(finished)
+	})
+	if err := hook.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if err := hook.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Stop returned before the worker finished")
+	}
+}
+
+func TestWorkerStopHonoursDeadline(t *testing.T) {
+	hook := Worker("stuck", func(ctx context.Context) { select {} })
+	hook.Start(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := hook.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+}
+
+func TestCheckReportsStoppedWorkers(t *testing.T) {
+	l := New()
+	l.Append(Worker("relay", func(ctx context.Context) { <-ctx.Done() }))
+	l.Append(Worker("scheduler", func(ctx context.Context) {}))
+	l.Append(Hook{Name: "database"})
+
+	if err := l.Check(context.Background()); err == nil {
+		t.Fatal("expected workers to be reported before start")
+	}
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	defer l.Stop(context.Background())
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := l.Check(context.Background())
+		if err != nil && err.Error() == "not running: scheduler" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected only the exited worker to be reported, got %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,34 @@
+package logging
+
+import "context"
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+)
+
+// WithRequestID menyimpan ID request di ctx agar ikut tercatat pada setiap log
+// yang ditulis dengan ctx tersebut.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID mengembalikan ID request dari ctx, atau "" jika tidak ada.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithUserID menyimpan ID user yang terautentikasi di ctx.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID mengembalikan ID user dari ctx, atau "" jika request belum
+// terautentikasi.
+func UserID(ctx context.Context) string {
+	id, _ := ctx.Value(userIDKey).(string)
+	return id
+}
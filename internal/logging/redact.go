@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted menggantikan nilai rahasia di log.
+const Redacted = "[REDACTED]"
+
+// sensitiveSuffixes adalah akhiran nama atribut (tanpa "_" dan "-", huruf
+// kecil) yang nilainya tidak boleh tercatat, mis. pin, new_pin, access_token.
+var sensitiveSuffixes = []string{"pin", "password", "token", "secret", "authorization", "cookie"}
+
+// tokenPattern menangkap JWT dan bearer token yang terselip di pesan atau
+// error, misalnya dari URL yang dicatat apa adanya.
+var tokenPattern = regexp.MustCompile(`(?i)bearer\s+\S+|eyJ[\w-]+\.[\w-]+\.[\w-]*`)
+
+// Redact dipakai sebagai slog.HandlerOptions.ReplaceAttr. Atribut PIN, token,
+// dan secret diganti Redacted, nomor telepon disamarkan kecuali empat digit
+// terakhir, dan token di dalam string lain dihapus.
+func Redact(_ []string, a slog.Attr) slog.Attr {
+	key := normalizeKey(a.Key)
+	switch {
+	case isSensitive(key):
+		return slog.String(a.Key, Redacted)
+	case strings.Contains(key, "phone") && a.Value.Kind() == slog.KindString:
+		return slog.String(a.Key, MaskPhone(a.Value.String()))
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactString(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, RedactString(err.Error()))
+		}
+	}
+	return a
+}
+
+// RedactString menghapus JWT dan bearer token dari s.
+func RedactString(s string) string {
+	return tokenPattern.ReplaceAllString(s, Redacted)
+}
+
+// MaskPhone menyamarkan nomor telepon, mis. "081234567890" menjadi
+// "********7890".
+func MaskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
+
+// RedactQuery menyunting parameter query yang sensitif, mis. access_token
+// pada handshake WebSocket, sebelum URL dicatat.
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	for key, vals := range values {
+		normalized := normalizeKey(key)
+		for i, v := range vals {
+			switch {
+			case isSensitive(normalized):
+				vals[i] = Redacted
+			case strings.Contains(normalized, "phone"):
+				vals[i] = MaskPhone(v)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+func isSensitive(normalizedKey string) bool {
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(normalizedKey, suffix) {
+			return true
+		}
+	}
+	return false
+}
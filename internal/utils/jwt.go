@@ -3,26 +3,32 @@ package utils
 import (
 	"errors"
 	"fmt"
-	"os"
 	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/joho/godotenv"
-	"time"
 )
 
-var jwtKey []byte
-
-// refreshTokenStore menyimpan refresh token yang valid untuk
-// memungkinkan kontrol revokasi sederhana.
-var refreshTokenStore = struct {
-	sync.RWMutex
-	tokens map[string]string
-}{tokens: make(map[string]string)}
+// TokenManager menerbitkan dan memvalidasi access token serta refresh token
+// dengan secret dan masa berlaku dari konfigurasi.
+type TokenManager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+
+	// refreshTokens menyimpan refresh token yang valid untuk
+	// memungkinkan kontrol revokasi sederhana.
+	mu            sync.RWMutex
+	refreshTokens map[string]string
+}
 
-func init() {
-	_ = godotenv.Load()
-	jwtKey = []byte(os.Getenv("JWT_SECRET"))
+func NewTokenManager(secret string, accessTTL, refreshTTL time.Duration) *TokenManager {
+	return &TokenManager{
+		secret:        []byte(secret),
+		accessTTL:     accessTTL,
+		refreshTTL:    refreshTTL,
+		refreshTokens: make(map[string]string),
+	}
 }
 
 type Claims struct {
@@ -30,8 +36,8 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+func (m *TokenManager) GenerateJWT(userID string) (string, error) {
+	expirationTime := time.Now().Add(m.accessTTL)
 
 	claims := &Claims{
 		UserID: userID,
@@ -41,30 +47,42 @@ func GenerateJWT(userID string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	tokenString, err := token.SignedString(m.secret)
 	if err != nil {
 		return "", err
 	}
 	return tokenString, nil
 }
 
-func ValidateJWT(tokenString string) (string, error) {
+func (m *TokenManager) ValidateJWT(tokenString string) (string, error) {
+	claims, err := m.ParseJWT(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+// ParseJWT memvalidasi access token dan mengembalikan seluruh klaimnya,
+// termasuk waktu kedaluwarsa.
+func (m *TokenManager) ParseJWT(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, m.keyFunc)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
-	return claims.UserID, nil
+	return claims, nil
+}
+
+func (m *TokenManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return m.secret, nil
 }
 
 // RefreshClaims adalah klaim khusus untuk refresh token dengan masa berlaku
@@ -75,8 +93,8 @@ type RefreshClaims struct {
 }
 
 // GenerateRefreshToken membuat refresh token baru dan menyimpannya di store.
-func GenerateRefreshToken(userID string) (string, error) {
-	expirationTime := time.Now().Add(7 * 24 * time.Hour)
+func (m *TokenManager) GenerateRefreshToken(userID string) (string, error) {
+	expirationTime := time.Now().Add(m.refreshTTL)
 
 	claims := &RefreshClaims{
 		UserID: userID,
@@ -86,29 +104,24 @@ func GenerateRefreshToken(userID string) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	tokenString, err := token.SignedString(m.secret)
 	if err != nil {
 		return "", err
 	}
 
-	refreshTokenStore.Lock()
-	refreshTokenStore.tokens[tokenString] = userID
-	refreshTokenStore.Unlock()
+	m.mu.Lock()
+	m.refreshTokens[tokenString] = userID
+	m.mu.Unlock()
 
 	return tokenString, nil
 }
 
 // ValidateRefreshToken memvalidasi refresh token dan memastikan token tersebut
 // belum direvoke.
-func ValidateRefreshToken(tokenString string) (string, error) {
+func (m *TokenManager) ValidateRefreshToken(tokenString string) (string, error) {
 	claims := &RefreshClaims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, m.keyFunc)
 	if err != nil {
 		return "", err
 	}
@@ -117,9 +130,9 @@ func ValidateRefreshToken(tokenString string) (string, error) {
 		return "", errors.New("invalid token")
 	}
 
-	refreshTokenStore.RLock()
-	_, exists := refreshTokenStore.tokens[tokenString]
-	refreshTokenStore.RUnlock()
+	m.mu.RLock()
+	_, exists := m.refreshTokens[tokenString]
+	m.mu.RUnlock()
 	if !exists {
 		return "", errors.New("token revoked")
 	}
@@ -128,8 +141,8 @@ func ValidateRefreshToken(tokenString string) (string, error) {
 }
 
 // RevokeRefreshToken menghapus refresh token dari store.
-func RevokeRefreshToken(tokenString string) {
-	refreshTokenStore.Lock()
-	delete(refreshTokenStore.tokens, tokenString)
-	refreshTokenStore.Unlock()
+func (m *TokenManager) RevokeRefreshToken(tokenString string) {
+	m.mu.Lock()
+	delete(m.refreshTokens, tokenString)
+	m.mu.Unlock()
 }